@@ -0,0 +1,41 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/jonesrussell/gocrawl/internal/config"
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/content/enrich"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+)
+
+// CreateEnrichment creates the article enrichment pipeline. The gazetteer is
+// read from crawler.enrichment.gazetteer and extended with the city and
+// coordinates of each source.
+func CreateEnrichment(cfg config.Interface, sourceManager sources.Interface) (*enrich.Pipeline, error) {
+	enrichmentCfg := crawlerconfig.NewEnrichmentConfig()
+	if crawlerCfg := cfg.GetCrawlerConfig(); crawlerCfg != nil {
+		enrichmentCfg = crawlerCfg.Enrichment
+	}
+
+	gazetteer := enrich.NewGazetteer()
+	if enrichmentCfg.Gazetteer != "" {
+		loaded, err := enrich.LoadGazetteer(enrichmentCfg.Gazetteer)
+		if err != nil {
+			return nil, fmt.Errorf("create enrichment: %w", err)
+		}
+		gazetteer = loaded
+	}
+
+	if sourceManager != nil {
+		sourceConfigs, err := sourceManager.GetSources()
+		if err != nil {
+			return nil, fmt.Errorf("create enrichment: %w", err)
+		}
+		for i := range sourceConfigs {
+			gazetteer.AddPlace(sourceConfigs[i].CityName, sourceConfigs[i].Location())
+		}
+	}
+
+	return enrich.NewPipeline(enrichmentCfg, gazetteer), nil
+}
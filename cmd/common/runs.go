@@ -0,0 +1,18 @@
+package common
+
+import (
+	"github.com/jonesrussell/gocrawl/internal/config"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/runhistory"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+// CreateRunRecorder creates the recorder of the crawl run history, or returns
+// nil when crawler.runs.enabled is off.
+func CreateRunRecorder(cfg config.Interface, log logger.Interface, storage types.Interface) *runhistory.Recorder {
+	crawlerCfg := cfg.GetCrawlerConfig()
+	if crawlerCfg == nil || !crawlerCfg.Runs.Enabled {
+		return nil
+	}
+	return runhistory.NewRecorder(log, storage, crawlerCfg.Runs)
+}
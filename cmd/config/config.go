@@ -0,0 +1,167 @@
+// Package config implements the commands that explain the configuration:
+// which values are in effect, where each of them comes from and whether the
+// configuration files are valid.
+package config
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jonesrussell/gocrawl/cmd/common"
+	"github.com/jonesrussell/gocrawl/internal/config"
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	internalsources "github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// flagKeys are the configuration keys set by global flags.
+var flagKeys = map[string]string{
+	"debug":        "app.debug",
+	"sources-file": "crawler.sources_file",
+}
+
+// Command returns the config command for use in the root command
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(createShowCmd(), createValidateCmd(), createSchemaCmd())
+	return cmd
+}
+
+// createShowCmd creates the show command
+func createShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective configuration and where each value comes from",
+		Long: `Print every configuration value in effect after the config file, the
+profile (--profile), environment variables and flags were merged, along with
+the layer that set it. With --source, the crawler settings are those the source
+is crawled with: its own max_depth and rate_limit (as crawler.delay) and the
+overrides of its crawler block. Secrets are masked.
+
+Examples:
+  gocrawl config show --profile prod
+  gocrawl config show --source "Sudbury Star"`,
+		Args: cobra.NoArgs,
+		RunE: runShow,
+	}
+	cmd.Flags().String("source", "", "Show the crawler settings of this source")
+	return cmd
+}
+
+// runShow executes the show command.
+func runShow(cmd *cobra.Command, _ []string) error {
+	deps, err := common.NewCommandDeps()
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+
+	configFile := viper.ConfigFileUsed()
+	profile := viper.GetString("profile")
+	var profileFile string
+	if profile != "" {
+		profileFile = config.ProfileFile(configFile, profile)
+	}
+	origins, err := config.NewOrigins(configFile, profile, profileFile)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration files: %w", err)
+	}
+	for flag, key := range flagKeys {
+		if f := cmd.Flags().Lookup(flag); f != nil && f.Changed {
+			origins.SetFlag(key, flag)
+		}
+	}
+
+	crawlerCfg := deps.Config.GetCrawlerConfig()
+	if sourceName, _ := cmd.Flags().GetString("source"); sourceName != "" {
+		crawlerCfg, err = sourceCrawlerConfig(deps, sourceName, origins)
+		if err != nil {
+			return err
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(w, "Config file: %s\n", orNone(configFile))
+	if profile != "" {
+		fmt.Fprintf(w, "Profile:     %s (%s)\n", profile, profileFile)
+	}
+	renderSettings(w, [][]config.Setting{
+		config.Settings("app", deps.Config.GetAppConfig(), origins),
+		config.Settings("logger", deps.Config.GetLogConfig(), origins),
+		config.Settings("server", deps.Config.GetServerConfig(), origins),
+		config.Settings("elasticsearch", deps.Config.GetElasticsearchConfig(), origins),
+		config.Settings("crawler", crawlerCfg, origins),
+	})
+	return nil
+}
+
+// sourceCrawlerConfig returns the crawler configuration of a source and
+// records the keys its crawler block overrides. Unless overridden, the depth
+// and delay are the source's own max_depth and rate_limit, as when it is crawled.
+func sourceCrawlerConfig(
+	deps common.CommandDeps,
+	name string,
+	origins *config.Origins,
+) (*crawlerconfig.Config, error) {
+	sourceManager, err := internalsources.LoadSources(deps.Config, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sources: %w", err)
+	}
+	source := sourceManager.FindByName(name)
+	if source == nil {
+		return nil, fmt.Errorf("source not found: %s", name)
+	}
+
+	crawlerCfg, err := deps.Config.GetCrawlerConfig().WithOverrides(source.Crawler)
+	if err != nil {
+		return nil, fmt.Errorf("source %s: %w", source.Name, err)
+	}
+	keys := crawlerconfig.OverrideKeys(source.Crawler)
+	if !slices.Contains(keys, "max_depth") {
+		crawlerCfg.MaxDepth = source.MaxDepth
+		keys = append(keys, "max_depth")
+	}
+	if !slices.Contains(keys, "delay") {
+		crawlerCfg.Delay = source.RateLimit
+		keys = append(keys, "delay")
+	}
+	for i := range keys {
+		keys[i] = "crawler." + keys[i]
+	}
+	origins.SetSource(source.Name, keys)
+	return crawlerCfg, nil
+}
+
+// renderSettings prints the settings of each section as a table.
+func renderSettings(w io.Writer, sections [][]config.Setting) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"Key", "Value", "Origin"})
+	for i, settings := range sections {
+		if i > 0 {
+			t.AppendSeparator()
+		}
+		for _, setting := range settings {
+			t.AppendRow(table.Row{setting.Key, setting.Value, setting.Origin})
+		}
+	}
+	t.Render()
+}
+
+// orNone shows an unset value.
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jonesrussell/gocrawl/internal/config"
+	"github.com/jonesrussell/gocrawl/internal/config/schema"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// createValidateCmd creates the validate command
+func createValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [file...]",
+		Short: "Check configuration files for unknown keys and invalid values",
+		Long: `Check configuration files against the configuration schema. Unknown keys,
+which are otherwise silently ignored, and values of the wrong type are errors;
+deprecated keys and suspicious values, such as a parallelism of 0, are warnings.
+Without arguments, the config file in use and the file of the selected profile
+are checked. The command fails when a file has errors.
+
+Examples:
+  gocrawl config validate
+  gocrawl config validate config.yaml config.prod.yaml`,
+		RunE: runValidate,
+		// The issues are already listed; main reports the error once, without usage
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+}
+
+// runValidate executes the validate command.
+func runValidate(cmd *cobra.Command, args []string) error {
+	files := args
+	if len(files) == 0 {
+		files = configFiles()
+	}
+	if len(files) == 0 {
+		return errors.New("no config file in use; pass the files to check")
+	}
+
+	w := cmd.OutOrStdout()
+	valid := true
+	for _, file := range files {
+		issues, err := schema.LintConfigFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to check %s: %w", file, err)
+		}
+		schema.WriteIssues(w, file, issues)
+		if schema.HasErrors(issues) {
+			valid = false
+		}
+	}
+	if !valid {
+		return schema.ErrInvalid
+	}
+	fmt.Fprintf(w, "%d file(s) valid\n", len(files))
+	return nil
+}
+
+// configFiles returns the config file in use and the file of the selected
+// profile, if any.
+func configFiles() []string {
+	var files []string
+	configFile := viper.ConfigFileUsed()
+	if configFile != "" {
+		files = append(files, configFile)
+	}
+	if profile := viper.GetString("profile"); profile != "" {
+		files = append(files, config.ProfileFile(configFile, profile))
+	}
+	return files
+}
+
+// createSchemaCmd creates the schema command
+func createSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the configuration file",
+		Long: `Print the JSON Schema of the configuration file, or with --sources of a
+sources file, generated from the types they are loaded into. Editors that
+support JSON Schema use it to complete and check YAML files.
+
+Examples:
+  gocrawl config schema > gocrawl.schema.json
+  gocrawl config schema --sources > sources.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			s := schema.Config()
+			if sourcesSchema, _ := cmd.Flags().GetBool("sources"); sourcesSchema {
+				s = schema.Sources()
+			}
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(s); err != nil {
+				return fmt.Errorf("failed to encode schema: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Bool("sources", false, "Print the schema of a sources file")
+	return cmd
+}
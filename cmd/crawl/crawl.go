@@ -5,16 +5,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	cmdcommon "github.com/jonesrussell/gocrawl/cmd/common"
 	"github.com/jonesrussell/gocrawl/internal/config"
 	"github.com/jonesrussell/gocrawl/internal/constants"
 	articlespkg "github.com/jonesrussell/gocrawl/internal/content/articles"
+	"github.com/jonesrussell/gocrawl/internal/content/dedup"
 	pagepkg "github.com/jonesrussell/gocrawl/internal/content/page"
 	"github.com/jonesrussell/gocrawl/internal/crawler"
 	"github.com/jonesrussell/gocrawl/internal/crawler/events"
 	"github.com/jonesrussell/gocrawl/internal/job"
+	"github.com/jonesrussell/gocrawl/internal/linkgraph"
 	loggerpkg "github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/runhistory"
 	sourcespkg "github.com/jonesrussell/gocrawl/internal/sources"
 	"github.com/jonesrussell/gocrawl/internal/sources/loader"
 	"github.com/spf13/cobra"
@@ -96,16 +100,28 @@ func (c *Crawler) Start(ctx context.Context) error {
 // Command returns the crawl command for use in the root command.
 func Command() *cobra.Command {
 	var maxDepth int
+	var group string
 
 	cmd := &cobra.Command{
 		Use:   "crawl [source]",
 		Short: "Crawl a website for content",
 		Long: `This command crawls a website for content and stores it in the configured storage.
-Specify the source name as an argument.
+Specify the source name as an argument, or use --group to crawl every source of a
+group one after the other. A source belongs to the group named by its group ID and
+to those named by its tags. The sources of a group with a rate_limit share one request
+budget (crawler.groups.<group>.rate_limit).
 
 The --max-depth flag can be used to override the max_depth setting from the source configuration.`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 0) == (group == "") {
+				return errors.New("specify either a source name or --group")
+			}
+			sourceName := ""
+			if len(args) > 0 {
+				sourceName = args[0]
+			}
+
 			// Get dependencies
 			deps, err := cmdcommon.NewCommandDeps()
 			if err != nil {
@@ -113,7 +129,8 @@ The --max-depth flag can be used to override the max_depth setting from the sour
 			}
 
 			// Construct dependencies
-			crawlerInstance, err := constructCrawlerDependencies(deps.Logger, deps.Config, args[0], maxDepth)
+			crawlerInstance, err := constructCrawlerDependencies(
+				deps.Logger, deps.Config, sourceName, group, maxDepth)
 			if err != nil {
 				return fmt.Errorf("failed to construct crawler dependencies: %w", err)
 			}
@@ -125,6 +142,7 @@ The --max-depth flag can be used to override the max_depth setting from the sour
 	// Add --max-depth flag
 	cmd.Flags().IntVar(&maxDepth, "max-depth", 0,
 		"Override the max_depth setting from source configuration (0 means use source default)")
+	cmd.Flags().StringVar(&group, "group", "", "Crawl every source of a group instead of a single source")
 
 	return cmd
 }
@@ -146,6 +164,48 @@ func getIndexNamesForSource(sourceManager sourcespkg.Interface, sourceName strin
 	return articleIndex, pageIndex
 }
 
+// resolveSourceNames returns the names of the sources to crawl: the named
+// source, or every enabled source of the group.
+func resolveSourceNames(sourceManager sourcespkg.Interface, sourceName, group string, now time.Time) ([]string, error) {
+	if group == "" {
+		if source := sourceManager.FindByName(sourceName); source != nil && !source.IsEnabled(now) {
+			return nil, fmt.Errorf("source %s is disabled: %s", sourceName, disabledReason(source))
+		}
+		return []string{sourceName}, nil
+	}
+
+	sourceConfigs, err := sourceManager.GetSources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sources: %w", err)
+	}
+	members := sourcespkg.FilterByGroup(sourceConfigs, group)
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no sources in group: %s", group)
+	}
+	members = sourcespkg.FilterEnabled(members, now)
+	if len(members) == 0 {
+		return nil, fmt.Errorf("every source of group %s is disabled", group)
+	}
+
+	names := make([]string, 0, len(members))
+	for i := range members {
+		names = append(names, members[i].Name)
+	}
+	return names, nil
+}
+
+// disabledReason describes why a source is disabled.
+func disabledReason(source *sourcespkg.Config) string {
+	reason := source.DisabledReason
+	if reason == "" {
+		reason = "no reason given"
+	}
+	if source.DisabledUntil != nil {
+		reason += " (until " + source.DisabledUntil.Format(time.RFC3339) + ")"
+	}
+	return reason
+}
+
 // createCrawlerInstance creates a crawler instance with the given services.
 // This is a helper function to consolidate crawler creation logic.
 func createCrawlerInstance(
@@ -155,6 +215,7 @@ func createCrawlerInstance(
 	storageResult *cmdcommon.StorageResult,
 	articleService articlespkg.Interface,
 	pageService pagepkg.Interface,
+	runRecorder *runhistory.Recorder,
 ) (crawler.Interface, error) {
 	// Create event bus
 	bus := events.NewEventBus(log)
@@ -165,6 +226,12 @@ func createCrawlerInstance(
 		return nil, errors.New("crawler configuration is required")
 	}
 
+	// Record the link graph if configured
+	var linkRecorder *linkgraph.Recorder
+	if crawlerCfg.Links.Enabled {
+		linkRecorder = linkgraph.NewRecorder(log, storageResult.Storage, crawlerCfg.Links)
+	}
+
 	// Create crawler using NewCrawlerWithParams
 	crawlerResult, err := crawler.NewCrawlerWithParams(crawler.CrawlerParams{
 		Logger:         log,
@@ -175,6 +242,8 @@ func createCrawlerInstance(
 		ArticleService: articleService,
 		PageService:    pageService,
 		Storage:        storageResult.Storage,
+		LinkRecorder:   linkRecorder,
+		RunRecorder:    runRecorder,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create crawler: %w", err)
@@ -184,11 +253,13 @@ func createCrawlerInstance(
 }
 
 // constructCrawlerDependencies constructs all dependencies needed for the crawl command.
+// Either sourceName or group selects the sources to crawl.
 // maxDepthOverride: if > 0, overrides the source's max_depth setting.
 func constructCrawlerDependencies(
 	log loggerpkg.Interface,
 	cfg config.Interface,
 	sourceName string,
+	group string,
 	maxDepthOverride int,
 ) (*Crawler, error) {
 	// Load sources
@@ -197,14 +268,22 @@ func constructCrawlerDependencies(
 		return nil, fmt.Errorf("failed to load sources: %w", err)
 	}
 
+	sourceNames, err := resolveSourceNames(sourceManager, sourceName, group, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if group != "" {
+		log.Info("Crawling source group", "group", group, "sources", sourceNames)
+	}
+
 	// Create storage
 	storageResult, err := cmdcommon.CreateStorage(cfg, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create storage: %w", err)
 	}
 
-	// Get index names for this source
-	articleIndex, pageIndex := getIndexNamesForSource(sourceManager, sourceName)
+	// Get index names for the first source; the services use each page's own source indices
+	articleIndex, pageIndex := getIndexNamesForSource(sourceManager, sourceNames[0])
 
 	// Create article and page services
 	articleService := articlespkg.NewContentServiceWithSources(
@@ -212,9 +291,25 @@ func constructCrawlerDependencies(
 	pageService := pagepkg.NewContentServiceWithSources(
 		log, storageResult.Storage, pageIndex, sourceManager)
 
+	// Enable near-duplicate detection if configured
+	if crawlerCfg := cfg.GetCrawlerConfig(); crawlerCfg != nil && crawlerCfg.Dedup.Enabled {
+		articleService.SetDeduplicator(dedup.NewDeduplicator(log, storageResult.Storage, crawlerCfg.Dedup))
+	}
+
+	// Run the enrichers listed on each source
+	enrichment, err := cmdcommon.CreateEnrichment(cfg, sourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create enrichment: %w", err)
+	}
+	articleService.SetEnrichment(enrichment)
+
+	// Record each run in the source's run history if configured
+	runRecorder := cmdcommon.CreateRunRecorder(cfg, log, storageResult.Storage)
+	articleService.SetRunRecorder(runRecorder)
+
 	// Create crawler
 	crawlerInstance, err := createCrawlerInstance(
-		log, cfg, sourceManager, storageResult, articleService, pageService)
+		log, cfg, sourceManager, storageResult, articleService, pageService, runRecorder)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create crawler: %w", err)
 	}
@@ -236,7 +331,7 @@ func constructCrawlerDependencies(
 		Done:             done,
 		Storage:          storageResult.Storage,
 		ProcessorFactory: processorFactory,
-		SourceName:       sourceName,
+		SourceNames:      sourceNames,
 	})
 
 	return NewCrawler(cfg, log, jobService, sourceManager, crawlerInstance, done), nil
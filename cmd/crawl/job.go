@@ -24,7 +24,7 @@ type JobService struct {
 	activeJobs       atomic.Int32 // Use atomic.Int32 directly
 	storage          storagetypes.Interface
 	processorFactory crawler.ProcessorFactory
-	sourceName       string
+	sourceNames      []string
 }
 
 // JobServiceParams holds parameters for creating a new JobService.
@@ -35,7 +35,8 @@ type JobServiceParams struct {
 	Done             chan struct{}
 	Storage          storagetypes.Interface
 	ProcessorFactory crawler.ProcessorFactory
-	SourceName       string `name:"sourceName"`
+	// SourceNames are the sources crawled, one after the other
+	SourceNames []string `name:"sourceNames"`
 }
 
 // NewJobService creates a new JobService instance.
@@ -48,26 +49,21 @@ func NewJobService(p JobServiceParams) job.Service {
 		// activeJobs is zero-initialized (no need to set it)
 		storage:          p.Storage,
 		processorFactory: p.ProcessorFactory,
-		sourceName:       p.SourceName,
+		sourceNames:      p.SourceNames,
 	}
 }
 
 // Start begins the job service.
 func (s *JobService) Start(ctx context.Context) error {
 	s.logger.Info("Starting job service")
-	s.logger.Info("Starting crawl for source", "source", s.sourceName)
 
 	// Start the crawler in a goroutine so it doesn't block
 	go func() {
-		// Start the crawler with the source name
-		if err := s.crawler.Start(ctx, s.sourceName); err != nil {
-			s.logger.Error("Crawler failed", "error", err)
-		}
-		// Wait for the crawler to complete all async operations
-		// crawler.Start() returns immediately after starting async operations,
-		// so we must wait for them to complete
-		if err := s.crawler.Wait(); err != nil {
-			s.logger.Error("Error waiting for crawler", "error", err)
+		for _, sourceName := range s.sourceNames {
+			if ctx.Err() != nil {
+				break
+			}
+			s.crawlSource(ctx, sourceName)
 		}
 		// Signal completion when crawler finishes
 		s.doneOnce.Do(func() {
@@ -78,6 +74,24 @@ func (s *JobService) Start(ctx context.Context) error {
 	return nil
 }
 
+// crawlSource crawls a source and waits for the crawl to finish.
+func (s *JobService) crawlSource(ctx context.Context, sourceName string) {
+	s.activeJobs.Add(1)
+	defer s.activeJobs.Add(-1)
+
+	s.logger.Info("Starting crawl for source", "source", sourceName)
+	// Start the crawler with the source name
+	if err := s.crawler.Start(ctx, sourceName); err != nil {
+		s.logger.Error("Crawler failed", "source", sourceName, "error", err)
+	}
+	// Wait for the crawler to complete all async operations
+	// crawler.Start() returns immediately after starting async operations,
+	// so we must wait for them to complete
+	if err := s.crawler.Wait(); err != nil {
+		s.logger.Error("Error waiting for crawler", "source", sourceName, "error", err)
+	}
+}
+
 // Stop implements the job.Service interface.
 func (s *JobService) Stop(ctx context.Context) error {
 	s.logger.Info("Stopping crawl job")
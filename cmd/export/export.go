@@ -0,0 +1,141 @@
+// Package export implements the export and import commands for moving indexed
+// content between Elasticsearch and files.
+package export
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	cmdcommon "github.com/jonesrussell/gocrawl/cmd/common"
+	"github.com/jonesrussell/gocrawl/internal/constants"
+	"github.com/jonesrussell/gocrawl/internal/export"
+	"github.com/spf13/cobra"
+)
+
+// sinceLayout is the date layout accepted by --since in addition to RFC 3339.
+const sinceLayout = "2006-01-02"
+
+// Command returns the export command for use in the root command
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export indexed content to a file",
+		Long: `Export documents from an index as JSON lines, CSV or Elasticsearch bulk NDJSON.
+Documents are paged with the scroll API, so exports of any size are supported.
+
+Examples:
+  # Export all articles published this year as JSON lines
+  gocrawl export --index articles --since 2026-01-01 > articles.jsonl
+
+  # Export matching articles as CSV with selected columns
+  gocrawl export --index articles --query 'title:election' --format csv \
+    --fields _id,title,published_date,source -o election.csv
+
+  # Create a backup that can be replayed with the bulk API or gocrawl import
+  gocrawl export --index articles --format ndjson-bulk -o articles.ndjson`,
+		Args: cobra.NoArgs,
+		RunE: runExport,
+	}
+
+	cmd.Flags().StringP("index", "i", constants.DefaultArticleIndex, "Index or alias to export")
+	cmd.Flags().StringP("query", "q", "", "Query string or JSON query DSL clause to filter documents")
+	cmd.Flags().StringP("format", "f", string(export.FormatJSONL), "Output format: jsonl, csv or ndjson-bulk")
+	cmd.Flags().String("since", "", "Only export documents on or after this date (YYYY-MM-DD or RFC 3339)")
+	cmd.Flags().String("date-field", "published_date", "Date field used by --since")
+	cmd.Flags().StringSlice("fields", nil, "CSV columns to export (default: all fields of the first document)")
+	cmd.Flags().Int("batch-size", export.DefaultBatchSize, "Number of documents fetched per page")
+	cmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+
+	return cmd
+}
+
+// runExport executes the export command
+func runExport(cmd *cobra.Command, _ []string) error {
+	format, err := export.ParseFormat(cmd.Flag("format").Value.String())
+	if err != nil {
+		return err
+	}
+
+	since, err := parseSince(cmd.Flag("since").Value.String())
+	if err != nil {
+		return err
+	}
+
+	fields, err := cmd.Flags().GetStringSlice("fields")
+	if err != nil {
+		return fmt.Errorf("failed to read fields flag: %w", err)
+	}
+	batchSize, err := cmd.Flags().GetInt("batch-size")
+	if err != nil {
+		return fmt.Errorf("failed to read batch-size flag: %w", err)
+	}
+
+	deps, err := cmdcommon.NewCommandDeps()
+	if err != nil {
+		return fmt.Errorf("failed to initialize dependencies: %w", err)
+	}
+
+	storageResult, err := cmdcommon.CreateStorage(deps.Config, deps.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	out, closeOutput, err := openOutput(cmd.Flag("output").Value.String())
+	if err != nil {
+		return err
+	}
+
+	buffered := bufio.NewWriter(out)
+	exporter := export.NewExporter(deps.Logger, storageResult.Storage)
+	count, exportErr := exporter.Export(cmd.Context(), buffered, export.Options{
+		Index:     cmd.Flag("index").Value.String(),
+		Query:     cmd.Flag("query").Value.String(),
+		Since:     since,
+		DateField: cmd.Flag("date-field").Value.String(),
+		Format:    format,
+		Fields:    fields,
+		BatchSize: batchSize,
+	})
+
+	flushErr := buffered.Flush()
+	closeErr := closeOutput()
+	if err = errors.Join(exportErr, flushErr, closeErr); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d documents\n", count)
+	return nil
+}
+
+// parseSince parses the --since flag.
+func parseSince(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(sinceLayout, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since date %q, expected YYYY-MM-DD or RFC 3339", value)
+	}
+	return t, nil
+}
+
+// openOutput opens the output file, or stdout when no path is given.
+func openOutput(path string) (io.Writer, func() error, error) {
+	if path == "" || path == "-" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return file, file.Close, nil
+}
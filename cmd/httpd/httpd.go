@@ -19,7 +19,9 @@ var Cmd = &cobra.Command{
 	Use:   "httpd",
 	Short: "Start the HTTP server for search",
 	Long: `This command starts an HTTP server that listens for search requests.
-You can send POST requests to /search with a JSON body containing the search parameters.`,
+You can send POST requests to /search with a JSON body containing the search parameters.
+With server security enabled, requests need an X-API-Key header holding a key
+with the search:read scope; see gocrawl httpd keygen.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		// Get dependencies
 		deps, err := cmdcommon.NewCommandDeps()
@@ -37,11 +39,16 @@ You can send POST requests to /search with a JSON body containing the search par
 		searchManager := storage.NewSearchManager(storageResult.Storage, deps.Logger)
 
 		// Create HTTP server
-		srv, _, err := api.StartHTTPServer(deps.Logger, searchManager, deps.Config)
+		srv, security, err := api.StartHTTPServer(deps.Logger, searchManager, deps.Config)
 		if err != nil {
 			return fmt.Errorf("failed to start HTTP server: %w", err)
 		}
 
+		// Rotate keys without a restart by editing the API key file
+		if watchErr := security.WatchKeys(cmd.Context()); watchErr != nil {
+			return fmt.Errorf("failed to watch API keys: %w", watchErr)
+		}
+
 		// Start server in goroutine
 		deps.Logger.Info("Starting HTTP server", "addr", deps.Config.GetServerConfig().Address)
 		errChan := make(chan error, 1)
@@ -74,6 +81,10 @@ You can send POST requests to /search with a JSON body containing the search par
 	},
 }
 
+func init() {
+	Cmd.AddCommand(newKeygenCommand())
+}
+
 // Command returns the httpd command for use in the root command
 func Command() *cobra.Command {
 	return Cmd
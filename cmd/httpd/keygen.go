@@ -0,0 +1,76 @@
+package httpd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/api/auth"
+	"github.com/jonesrussell/gocrawl/internal/config/server"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// expiresLayout is the date format of the --expires flag.
+const expiresLayout = "2006-01-02"
+
+// newKeygenCommand creates the keygen command
+func newKeygenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keygen <id>",
+		Short: "Generate an API key",
+		Long: `Generate a random API key and print it once, along with the entry to add to
+server.api_keys in the config file or to the keys of the API key file
+(server.api_keys_file). Only the hash of the key is stored. The API key file is
+reloaded when it changes, so keys can be rotated without restarting the server:
+add the new key, move clients over, then remove the old one.
+
+Scopes: ` + strings.Join(server.Scopes, ", ") + `
+
+Examples:
+  gocrawl httpd keygen dashboard --scopes search:read --rate-limit 120
+  gocrawl httpd keygen ops --scopes admin --expires 2027-01-01`,
+		Args: cobra.ExactArgs(1),
+		RunE: runKeygen,
+	}
+	cmd.Flags().StringSlice("scopes", []string{server.ScopeSearchRead}, "Scopes of the key")
+	cmd.Flags().Int("rate-limit", 0, "Requests allowed per minute; 0 applies the per-client limit")
+	cmd.Flags().String("expires", "", "Date the key expires, e.g. 2027-01-01")
+	return cmd
+}
+
+// runKeygen executes the keygen command.
+func runKeygen(cmd *cobra.Command, args []string) error {
+	scopes, _ := cmd.Flags().GetStringSlice("scopes")
+	rateLimit, _ := cmd.Flags().GetInt("rate-limit")
+	expires, _ := cmd.Flags().GetString("expires")
+
+	var expiresAt time.Time
+	if expires != "" {
+		parsed, parseErr := time.Parse(expiresLayout, expires)
+		if parseErr != nil {
+			return fmt.Errorf("invalid expiry date %q: %w", expires, parseErr)
+		}
+		expiresAt = parsed
+	}
+
+	key, keyCfg, err := auth.GenerateKey(server.APIKeyConfig{
+		ID:        args[0],
+		Scopes:    scopes,
+		RateLimit: rateLimit,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	entry, err := yaml.Marshal([]server.APIKeyConfig{keyCfg})
+	if err != nil {
+		return fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(w, "API key (send as X-API-Key; it is not shown again):\n  %s\n\n", key)
+	fmt.Fprintf(w, "Key entry:\n%s", entry)
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
+	"sort"
 	"strings"
 
 	cmdcommon "github.com/jonesrussell/gocrawl/cmd/common"
@@ -30,6 +32,7 @@ var (
 type DeleteParams struct {
 	ConfigPath string
 	SourceName string
+	Group      string
 	Force      bool
 	Indices    []string
 }
@@ -43,6 +46,7 @@ type Deleter struct {
 	index      []string
 	force      bool
 	sourceName string
+	group      string
 }
 
 // NewDeleter creates a new deleter instance
@@ -61,11 +65,20 @@ func NewDeleter(
 		index:      params.Indices,
 		force:      params.Force,
 		sourceName: params.SourceName,
+		group:      params.Group,
 	}
 }
 
 // Start executes the delete operation
 func (d *Deleter) Start(ctx context.Context) error {
+	// Resolve source and group indices first so that the confirmation lists them
+	if err := d.resolveIndicesFromSource(); err != nil {
+		return err
+	}
+	if err := d.resolveIndicesFromGroup(); err != nil {
+		return err
+	}
+
 	if err := d.confirmDeletion(); err != nil {
 		return err
 	}
@@ -117,10 +130,6 @@ func (d *Deleter) deleteIndices(ctx context.Context) error {
 		return fmt.Errorf("failed to connect to storage: %w", err)
 	}
 
-	if err := d.resolveIndicesFromSource(); err != nil {
-		return err
-	}
-
 	if len(d.index) == 0 {
 		return errors.New("no index specified")
 	}
@@ -173,6 +182,59 @@ func (d *Deleter) resolveIndicesFromSource() error {
 	return nil
 }
 
+// resolveIndicesFromGroup resolves the indices of every source in the group if
+// one is provided. Indices also used by sources outside the group are kept.
+func (d *Deleter) resolveIndicesFromGroup() error {
+	if d.group == "" {
+		return nil
+	}
+
+	sourceConfigs, err := d.sources.GetSources()
+	if err != nil {
+		return fmt.Errorf("failed to get sources: %w", err)
+	}
+
+	inGroup := make(map[string]bool)
+	shared := make(map[string]bool)
+	for i := range sourceConfigs {
+		member := sourceConfigs[i].InGroup(d.group)
+		for _, index := range sourceIndices(&sourceConfigs[i]) {
+			if member {
+				inGroup[index] = true
+			} else {
+				shared[index] = true
+			}
+		}
+	}
+
+	d.index = make([]string, 0, len(inGroup))
+	for index := range inGroup {
+		if shared[index] {
+			d.logger.Warn("Keeping index shared with sources outside the group", "index", index, "group", d.group)
+			continue
+		}
+		d.index = append(d.index, index)
+	}
+	if len(d.index) == 0 {
+		return fmt.Errorf("group %s has no indices of its own", d.group)
+	}
+	sort.Strings(d.index)
+
+	d.logger.Info("Resolved group index", "index", d.index, "group", d.group)
+	return nil
+}
+
+// sourceIndices returns the indices configured for a source.
+func sourceIndices(source *sources.Config) []string {
+	var indices []string
+	for _, index := range []string{source.Index, source.ArticleIndex, source.PageIndex} {
+		if index != "" && !slices.Contains(indices, index) {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
 // deleteFilteredIndices deletes the filtered indices.
 func (d *Deleter) deleteFilteredIndices(ctx context.Context, indicesToDelete []string) error {
 	d.logger.Info("Indices to delete", "index", indicesToDelete)
@@ -238,9 +300,15 @@ func (d *Deleter) reportMissingIndices(missingIndices []string) {
 
 // runDeleteCmd executes the delete command
 func runDeleteCmd(cmd *cobra.Command, args []string) error {
-	// Validate that either --source is provided or at least one index name is provided
-	if sourceName == "" && len(args) == 0 {
-		return errors.New("either --source flag or at least one index name must be provided")
+	// Validate that exactly one of --source, --group or index names is provided
+	selectors := 0
+	for _, set := range []bool{sourceName != "", groupName != "", len(args) > 0} {
+		if set {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return errors.New("provide exactly one of --source, --group or index names")
 	}
 
 	ctx := cmd.Context()
@@ -265,6 +333,7 @@ func runDeleteCmd(cmd *cobra.Command, args []string) error {
 	deleter := NewDeleter(deps.Config, deps.Logger, storageResult.Storage, sourcesManager, DeleteParams{
 		Force:      forceDelete,
 		SourceName: sourceName,
+		Group:      groupName,
 		Indices:    args,
 	})
 
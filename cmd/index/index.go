@@ -10,6 +10,7 @@ import (
 var (
 	forceDelete bool
 	sourceName  string
+	groupName   string
 )
 
 // Command returns the index command for use in the root command
@@ -22,7 +23,7 @@ func Command() *cobra.Command {
 			return cmd.Help()
 		},
 	}
-	cmd.AddCommand(createListCmd(), createCreateCmd(), createDeleteCmd())
+	cmd.AddCommand(createListCmd(), createCreateCmd(), createDeleteCmd(), createPruneCmd())
 	return cmd
 }
 
@@ -50,12 +51,14 @@ func createDeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delete [index-name...]",
 		Short: "Delete an index",
-		Long: `Delete one or more indices. Either provide index names as arguments ` +
-			`or use --source to delete indices for a specific source.`,
+		Long: `Delete one or more indices. Either provide index names as arguments, ` +
+			`use --source to delete indices for a specific source, ` +
+			`or use --group to delete the indices of every source in a group.`,
 		Args: cobra.MinimumNArgs(0),
 		RunE: runDeleteCmd,
 	}
 	cmd.Flags().BoolVarP(&forceDelete, "force", "f", false, "Force deletion without confirmation")
 	cmd.Flags().StringVar(&sourceName, "source", "", "Delete index for a specific source by name")
+	cmd.Flags().StringVar(&groupName, "group", "", "Delete the indices of every source in a group")
 	return cmd
 }
@@ -0,0 +1,221 @@
+// Package index implements the command-line interface for managing Elasticsearch
+// index in GoCrawl. This file contains the implementation of the prune command
+// that enforces per-source retention policies.
+package index
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	cmdcommon "github.com/jonesrussell/gocrawl/cmd/common"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/retention"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	storagetypes "github.com/jonesrussell/gocrawl/internal/storage/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneDryRun   bool
+	pruneRollover bool
+)
+
+// PruneParams holds the parameters for the prune command
+type PruneParams struct {
+	SourceName string
+	DryRun     bool
+	Rollover   bool
+}
+
+// Pruner implements the index prune command
+type Pruner struct {
+	logger     logger.Interface
+	storage    storagetypes.Interface
+	sources    sources.Interface
+	sourceName string
+	dryRun     bool
+	rollover   bool
+}
+
+// NewPruner creates a new pruner instance
+func NewPruner(
+	log logger.Interface,
+	stor storagetypes.Interface,
+	sourcesManager sources.Interface,
+	params PruneParams,
+) *Pruner {
+	return &Pruner{
+		logger:     log,
+		storage:    stor,
+		sources:    sourcesManager,
+		sourceName: params.SourceName,
+		dryRun:     params.DryRun,
+		rollover:   params.Rollover,
+	}
+}
+
+// Start executes the prune operation
+func (p *Pruner) Start(ctx context.Context) error {
+	if err := p.storage.TestConnection(ctx); err != nil {
+		p.logger.Error("Failed to connect to storage", "error", err)
+		return fmt.Errorf("failed to connect to storage: %w", err)
+	}
+
+	policies, err := p.resolvePolicies()
+	if err != nil {
+		return err
+	}
+
+	if len(policies) == 0 {
+		p.logger.Info("No retention policies configured")
+		return nil
+	}
+
+	enforcer := retention.NewEnforcer(p.logger, p.storage, p.dryRun)
+
+	if p.rollover {
+		if rolloverErr := p.rolloverIndices(ctx, enforcer, policies); rolloverErr != nil {
+			return rolloverErr
+		}
+	}
+
+	reports, enforceErr := enforcer.Enforce(ctx, policies)
+	renderPruneReports(reports)
+
+	return enforceErr
+}
+
+// resolvePolicies returns the retention policies to enforce.
+func (p *Pruner) resolvePolicies() ([]retention.Policy, error) {
+	if p.sourceName != "" {
+		source := p.sources.FindByName(p.sourceName)
+		if source == nil {
+			return nil, fmt.Errorf("source not found: %s", p.sourceName)
+		}
+
+		policy := retention.PolicyFromSource(source)
+		if !policy.Enabled() {
+			return nil, fmt.Errorf("source %s has no retention or max_docs configured", p.sourceName)
+		}
+		return []retention.Policy{policy}, nil
+	}
+
+	configs, err := p.sources.GetSources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sources: %w", err)
+	}
+
+	return retention.PoliciesFromSources(configs), nil
+}
+
+// rolloverIndices moves the article alias of every policy to the current month's index.
+func (p *Pruner) rolloverIndices(ctx context.Context, enforcer *retention.Enforcer, policies []retention.Policy) error {
+	for _, policy := range policies {
+		if policy.ArticleIndex == "" {
+			continue
+		}
+
+		index, err := enforcer.Rollover(ctx, policy.ArticleIndex)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "Alias %s now writes to %s\n", policy.ArticleIndex, index)
+	}
+	return nil
+}
+
+// renderPruneReports prints what retention removed.
+func renderPruneReports(reports []retention.Report) {
+	if len(reports) == 0 {
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Source", "Index", "Cutoff", "Deleted Docs", "Deleted Indices", "Dry Run"})
+
+	for i := range reports {
+		report := &reports[i]
+		cutoff := "-"
+		if !report.Cutoff.IsZero() {
+			cutoff = report.Cutoff.Format("2006-01-02")
+		}
+
+		t.AppendRow(table.Row{
+			report.Source,
+			report.Index,
+			cutoff,
+			strconv.FormatInt(report.DeletedDocs, 10),
+			strings.Join(report.DeletedIndices, ", "),
+			strconv.FormatBool(report.DryRun),
+		})
+	}
+
+	t.Render()
+}
+
+// createPruneCmd creates the prune command
+func createPruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Enforce per-source retention policies",
+		Long: `Delete documents that fall outside the retention or max_docs settings of each source.
+Documents are aged by published_date, falling back to created_at. Only documents
+whose URL is on the host of the source or one of its allowed domains are removed,
+so sources that share an index keep their own retention. With --rollover the
+article alias of each source is first pointed at the current monthly index
+(e.g. articles-2026.10) so whole expired months can be dropped, unless they hold
+documents of other sources.
+
+Crawls create each article index as a concrete index, which --rollover refuses
+to replace. Migrate an existing index once, with crawls of its source stopped,
+by cloning it into a monthly index of the previous month and putting an alias
+with its name in front of the clone:
+
+  PUT  /articles/_settings           {"index.blocks.write": true}
+  POST /articles/_clone/articles-2026.09
+  PUT  /articles-2026.09/_settings   {"index.blocks.write": null}
+  DELETE /articles
+  POST /_aliases {"actions": [{"add": {"index": "articles-2026.09",
+                  "alias": "articles", "is_write_index": true}}]}
+
+then run gocrawl index prune --rollover. The clone keeps the mapping of the
+original; it is dropped as a whole once its month falls outside the retention.`,
+		Args: cobra.NoArgs,
+		RunE: runPruneCmd,
+	}
+	cmd.Flags().StringVar(&sourceName, "source", "", "Only prune indices of a specific source by name")
+	cmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Report what would be removed without deleting anything")
+	cmd.Flags().BoolVar(&pruneRollover, "rollover", false, "Roll article aliases over to the current monthly index")
+	return cmd
+}
+
+// runPruneCmd executes the prune command
+func runPruneCmd(cmd *cobra.Command, _ []string) error {
+	deps, err := cmdcommon.NewCommandDeps()
+	if err != nil {
+		return fmt.Errorf("failed to initialize dependencies: %w", err)
+	}
+
+	storageResult, err := cmdcommon.CreateStorage(deps.Config, deps.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	sourcesManager, err := sources.LoadSources(deps.Config, deps.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to load sources: %w", err)
+	}
+
+	pruner := NewPruner(deps.Logger, storageResult.Storage, sourcesManager, PruneParams{
+		SourceName: sourceName,
+		DryRun:     pruneDryRun,
+		Rollover:   pruneRollover,
+	})
+
+	return pruner.Start(cmd.Context())
+}
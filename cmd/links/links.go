@@ -0,0 +1,289 @@
+// Package links implements the commands that report on the link graph recorded
+// while crawling (see crawler.links in the configuration).
+package links
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	cmdcommon "github.com/jonesrussell/gocrawl/cmd/common"
+	"github.com/jonesrussell/gocrawl/internal/common/urlnorm"
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/constants"
+	"github.com/jonesrussell/gocrawl/internal/linkgraph"
+	sourcespkg "github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultTopLimit is the number of pages reported by links top.
+	defaultTopLimit = 20
+	// articleOverfetch is how many more pages than requested are ranked when
+	// only articles are reported, since section fronts usually rank highest.
+	articleOverfetch = 5
+	// maxTopTargets caps the number of pages ranked by links top.
+	maxTopTargets = 1000
+)
+
+// Command returns the links command for use in the root command
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "links",
+		Short: "Report on the recorded link graph",
+		Long: `Report on the links recorded between crawled pages.
+Links are only recorded when crawler.links.enabled is set.
+
+Examples:
+  # The 20 articles of a source with the most internal inlinks
+  gocrawl links top --source "Example News"
+
+  # Pages that cannot be reached from the homepage
+  gocrawl links orphans --source "Example News" --since 168h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.PersistentFlags().String("source", "", "Name of the source to report on")
+	cmd.PersistentFlags().String("index", "", "Links index (default: crawler.links.index)")
+
+	cmd.AddCommand(createTopCmd(), createOrphansCmd())
+	return cmd
+}
+
+// createTopCmd creates the top command
+func createTopCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "List the most linked-to articles of a source",
+		Args:  cobra.NoArgs,
+		RunE:  runTop,
+	}
+	cmd.Flags().IntP("limit", "n", defaultTopLimit, "Number of pages to list")
+	cmd.Flags().Bool("all", false, "Include pages that are not indexed articles, such as section fronts")
+	return cmd
+}
+
+// createOrphansCmd creates the orphans command
+func createOrphansCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "List crawled pages that are not linked from the homepage",
+		Long: `List crawled pages that cannot be reached from the source's homepage by
+following internal links. With --direct only links on the homepage itself count.`,
+		Args: cobra.NoArgs,
+		RunE: runOrphans,
+	}
+	cmd.Flags().Bool("direct", false, "Only count links directly on the homepage")
+	cmd.Flags().Duration("since", 0, "Only use links seen within this period, e.g. 168h (default: all)")
+	return cmd
+}
+
+// reportDeps holds what the links subcommands need.
+type reportDeps struct {
+	storage types.Interface
+	source  *sourcespkg.Config
+	graph   *linkgraph.Graph
+}
+
+// newReportDeps loads the configuration, storage and the requested source.
+func newReportDeps(cmd *cobra.Command) (*reportDeps, error) {
+	sourceName := cmd.Flag("source").Value.String()
+	if sourceName == "" {
+		return nil, errors.New("--source is required")
+	}
+
+	deps, err := cmdcommon.NewCommandDeps()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize dependencies: %w", err)
+	}
+
+	sourceManager, err := sourcespkg.LoadSources(deps.Config, deps.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sources: %w", err)
+	}
+	source := sourceManager.FindByName(sourceName)
+	if source == nil {
+		return nil, fmt.Errorf("source not found: %s", sourceName)
+	}
+
+	storageResult, err := cmdcommon.CreateStorage(deps.Config, deps.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	index := cmd.Flag("index").Value.String()
+	if index == "" {
+		index = crawlerconfig.DefaultLinksIndex
+		if crawlerCfg := deps.Config.GetCrawlerConfig(); crawlerCfg != nil && crawlerCfg.Links.Index != "" {
+			index = crawlerCfg.Links.Index
+		}
+	}
+
+	return &reportDeps{
+		storage: storageResult.Storage,
+		source:  source,
+		graph:   linkgraph.NewGraph(storageResult.Storage, index),
+	}, nil
+}
+
+// runTop executes the top command
+func runTop(cmd *cobra.Command, _ []string) error {
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to read limit flag: %w", err)
+	}
+	if limit < 1 {
+		return errors.New("--limit must be positive")
+	}
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return fmt.Errorf("failed to read all flag: %w", err)
+	}
+
+	deps, err := newReportDeps(cmd)
+	if err != nil {
+		return err
+	}
+
+	ranked := limit
+	if !all {
+		ranked = min(limit*articleOverfetch, maxTopTargets)
+	}
+	targets, err := deps.graph.TopLinked(cmd.Context(), deps.source.Name, ranked)
+	if err != nil {
+		return err
+	}
+
+	titles := map[string]string{}
+	if !all {
+		titles, err = articleTitles(cmd.Context(), deps.storage, articleIndex(deps.source), targets)
+		if err != nil {
+			return err
+		}
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"#", "Inlinks", "URL", "Title"})
+	rank := 0
+	for _, target := range targets {
+		title, isArticle := titles[target.URL]
+		if !all && !isArticle {
+			continue
+		}
+		rank++
+		t.AppendRow(table.Row{rank, target.Inlinks, target.URL, title})
+		if rank == limit {
+			break
+		}
+	}
+	t.Render()
+	return nil
+}
+
+// runOrphans executes the orphans command
+func runOrphans(cmd *cobra.Command, _ []string) error {
+	direct, err := cmd.Flags().GetBool("direct")
+	if err != nil {
+		return fmt.Errorf("failed to read direct flag: %w", err)
+	}
+	window, err := cmd.Flags().GetDuration("since")
+	if err != nil {
+		return fmt.Errorf("failed to read since flag: %w", err)
+	}
+
+	deps, err := newReportDeps(cmd)
+	if err != nil {
+		return err
+	}
+
+	canonicalizer, err := urlnorm.New(deps.source.URLRewrites)
+	if err != nil {
+		return fmt.Errorf("failed to create URL canonicalizer: %w", err)
+	}
+	homepage, err := canonicalizer.Key(deps.source.URL)
+	if err != nil {
+		return fmt.Errorf("failed to canonicalize source URL: %w", err)
+	}
+
+	var since time.Time
+	if window > 0 {
+		since = time.Now().Add(-window)
+	}
+	links, err := deps.graph.Links(cmd.Context(), deps.source.Name, since)
+	if err != nil {
+		return err
+	}
+
+	orphans := linkgraph.Orphans(links, homepage, direct)
+	for _, page := range orphans {
+		fmt.Fprintln(os.Stdout, page)
+	}
+	fmt.Fprintf(os.Stderr, "Found %d orphaned pages\n", len(orphans))
+	return nil
+}
+
+// articleIndex returns the article index of a source.
+func articleIndex(source *sourcespkg.Config) string {
+	if source.ArticleIndex != "" {
+		return source.ArticleIndex
+	}
+	return constants.DefaultArticleIndex
+}
+
+// articleTitles returns the titles of the targets that are indexed articles, keyed by the target URL.
+func articleTitles(
+	ctx context.Context,
+	storage types.Interface,
+	index string,
+	targets []linkgraph.Target,
+) (map[string]string, error) {
+	titles := make(map[string]string, len(targets))
+	if len(targets) == 0 {
+		return titles, nil
+	}
+
+	// Targets are keyed with the scheme folded onto https while articles keep
+	// the scheme of their site, so look up both copies of every URL
+	urls := make([]string, 0, 2*len(targets))
+	for _, target := range targets {
+		urls = append(urls, target.URL)
+		if rest, found := strings.CutPrefix(target.URL, "https://"); found {
+			urls = append(urls, "http://"+rest)
+		}
+	}
+
+	hits, err := storage.Search(ctx, index, map[string]any{
+		"size":    len(urls),
+		"_source": []string{"title", "canonical_url"},
+		"query":   map[string]any{"terms": map[string]any{"canonical_url": urls}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up articles: %w", err)
+	}
+
+	for _, hit := range hits {
+		hitMap, isMap := hit.(map[string]any)
+		if !isMap {
+			continue
+		}
+		source, isMap := hitMap["_source"].(map[string]any)
+		if !isMap {
+			continue
+		}
+		canonicalURL, _ := source["canonical_url"].(string)
+		title, _ := source["title"].(string)
+		if canonicalURL != "" {
+			titles[urlnorm.Key(canonicalURL)] = title
+		}
+	}
+	return titles, nil
+}
@@ -12,9 +12,12 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/joho/godotenv"
+	cmdconfig "github.com/jonesrussell/gocrawl/cmd/config"
 	"github.com/jonesrussell/gocrawl/cmd/crawl"
+	cmdexport "github.com/jonesrussell/gocrawl/cmd/export"
 	"github.com/jonesrussell/gocrawl/cmd/httpd"
 	"github.com/jonesrussell/gocrawl/cmd/index"
+	"github.com/jonesrussell/gocrawl/cmd/links"
 	cmdscheduler "github.com/jonesrussell/gocrawl/cmd/scheduler"
 	"github.com/jonesrussell/gocrawl/cmd/search"
 	cmdsources "github.com/jonesrussell/gocrawl/cmd/sources"
@@ -29,6 +32,12 @@ var (
 	// Debug enables debug mode for all commands
 	Debug bool
 
+	// sourcesFile is a sources file or snapshot used instead of the sources API.
+	sourcesFile string
+
+	// profile is the configuration profile merged over the configuration file.
+	profile string
+
 	// rootCmd represents the root command for the GoCrawl CLI.
 	rootCmd = &cobra.Command{
 		Use:   "gocrawl",
@@ -67,6 +76,18 @@ func init() {
 		"config file (default is ./config.yaml, ~/.crawler/config.yaml, or /etc/crawler/config.yaml)",
 	)
 	rootCmd.PersistentFlags().BoolVar(&Debug, "debug", false, "enable debug mode")
+	rootCmd.PersistentFlags().StringVar(
+		&sourcesFile,
+		"sources-file",
+		"",
+		"load sources from this YAML sources file or .json sources snapshot instead of the sources API",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&profile,
+		"profile",
+		"",
+		"configuration profile merged over the config file, e.g. prod for config.prod.yaml (env GOCRAWL_PROFILE)",
+	)
 
 	// Add version command
 	rootCmd.AddCommand(&cobra.Command{
@@ -82,8 +103,12 @@ func init() {
 	rootCmd.AddCommand(index.Command())
 	rootCmd.AddCommand(cmdsources.NewSourcesCommand())
 	rootCmd.AddCommand(search.Command())
+	rootCmd.AddCommand(cmdexport.Command())
+	rootCmd.AddCommand(cmdexport.ImportCommand())
+	rootCmd.AddCommand(links.Command())
 	rootCmd.AddCommand(httpd.Command())
 	rootCmd.AddCommand(cmdscheduler.Command())
+	rootCmd.AddCommand(cmdconfig.Command())
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -123,6 +148,16 @@ func initConfig() error {
 		fmt.Fprintf(os.Stderr, "Warning: Config file not found: %v (using defaults and environment variables)\n", err)
 	}
 
+	// Merge the configuration profile over the config file
+	if profile == "" {
+		profile = os.Getenv("GOCRAWL_PROFILE")
+	}
+	if profile != "" {
+		if _, err := config.MergeProfile(viper.GetViper(), profile); err != nil {
+			return err
+		}
+	}
+
 	// Bind command-line flags to Viper
 	if err := bindCommandLineFlags(); err != nil {
 		return err
@@ -152,21 +187,36 @@ func bindCommandLineFlags() error {
 	if err := viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config")); err != nil {
 		return fmt.Errorf("failed to bind config flag: %w", err)
 	}
+	if err := viper.BindPFlag("crawler.sources_file", rootCmd.PersistentFlags().Lookup("sources-file")); err != nil {
+		return fmt.Errorf("failed to bind sources-file flag: %w", err)
+	}
+	if err := viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile")); err != nil {
+		return fmt.Errorf("failed to bind profile flag: %w", err)
+	}
+	if err := config.BindEnv("profile", "GOCRAWL_PROFILE"); err != nil {
+		return fmt.Errorf("failed to bind GOCRAWL_PROFILE: %w", err)
+	}
 	return nil
 }
 
 // bindAppEnvVars binds application and logger environment variables to config keys.
 func bindAppEnvVars() error {
-	if err := viper.BindEnv("app.environment", "APP_ENV"); err != nil {
+	if err := config.BindEnv("app.environment", "APP_ENV"); err != nil {
 		return fmt.Errorf("failed to bind APP_ENV: %w", err)
 	}
-	if err := viper.BindEnv("app.debug", "APP_DEBUG"); err != nil {
+	if err := config.BindEnv("app.debug", "APP_DEBUG"); err != nil {
 		return fmt.Errorf("failed to bind APP_DEBUG: %w", err)
 	}
-	if err := viper.BindEnv("logger.level", "LOG_LEVEL"); err != nil {
+	if err := config.BindEnv("crawler.sources_api.token", "SOURCES_API_TOKEN"); err != nil {
+		return fmt.Errorf("failed to bind SOURCES_API_TOKEN: %w", err)
+	}
+	if err := config.BindEnv("crawler.sources_api.api_key", "SOURCES_API_KEY"); err != nil {
+		return fmt.Errorf("failed to bind SOURCES_API_KEY: %w", err)
+	}
+	if err := config.BindEnv("logger.level", "LOG_LEVEL"); err != nil {
 		return fmt.Errorf("failed to bind LOG_LEVEL: %w", err)
 	}
-	if err := viper.BindEnv("logger.encoding", "LOG_FORMAT"); err != nil {
+	if err := config.BindEnv("logger.encoding", "LOG_FORMAT"); err != nil {
 		return fmt.Errorf("failed to bind LOG_FORMAT: %w", err)
 	}
 	return nil
@@ -177,30 +227,30 @@ func bindElasticsearchEnvVars() error {
 	// Support both ELASTICSEARCH_HOSTS and ELASTICSEARCH_ADDRESSES
 	// Note: ELASTICSEARCH_ADDRESSES is also handled by AutomaticEnv via the replacer,
 	// but we explicitly bind ELASTICSEARCH_HOSTS for clarity
-	if err := viper.BindEnv("elasticsearch.addresses", "ELASTICSEARCH_HOSTS", "ELASTICSEARCH_ADDRESSES"); err != nil {
+	if err := config.BindEnv("elasticsearch.addresses", "ELASTICSEARCH_HOSTS", "ELASTICSEARCH_ADDRESSES"); err != nil {
 		return fmt.Errorf("failed to bind Elasticsearch addresses: %w", err)
 	}
-	if err := viper.BindEnv("elasticsearch.password", "ELASTIC_PASSWORD", "ELASTICSEARCH_PASSWORD"); err != nil {
+	if err := config.BindEnv("elasticsearch.password", "ELASTIC_PASSWORD", "ELASTICSEARCH_PASSWORD"); err != nil {
 		return fmt.Errorf("failed to bind Elasticsearch password: %w", err)
 	}
-	if err := viper.BindEnv("elasticsearch.tls.insecure_skip_verify", "ELASTICSEARCH_SKIP_TLS"); err != nil {
+	if err := config.BindEnv("elasticsearch.tls.insecure_skip_verify", "ELASTICSEARCH_SKIP_TLS"); err != nil {
 		return fmt.Errorf("failed to bind Elasticsearch TLS skip verify: %w", err)
 	}
-	if err := viper.BindEnv("elasticsearch.api_key", "ELASTICSEARCH_API_KEY"); err != nil {
+	if err := config.BindEnv("elasticsearch.api_key", "ELASTICSEARCH_API_KEY"); err != nil {
 		return fmt.Errorf("failed to bind Elasticsearch API key: %w", err)
 	}
 	// Bind index_name (supports both ELASTICSEARCH_INDEX_PREFIX and ELASTICSEARCH_INDEX_NAME)
-	if err := viper.BindEnv("elasticsearch.index_name",
+	if err := config.BindEnv("elasticsearch.index_name",
 		"ELASTICSEARCH_INDEX_PREFIX", "ELASTICSEARCH_INDEX_NAME"); err != nil {
 		return fmt.Errorf("failed to bind Elasticsearch index name: %w", err)
 	}
-	if err := viper.BindEnv("elasticsearch.retry.max_retries", "ELASTICSEARCH_MAX_RETRIES"); err != nil {
+	if err := config.BindEnv("elasticsearch.retry.max_retries", "ELASTICSEARCH_MAX_RETRIES"); err != nil {
 		return fmt.Errorf("failed to bind Elasticsearch max retries: %w", err)
 	}
-	if err := viper.BindEnv("elasticsearch.retry.initial_wait", "ELASTICSEARCH_RETRY_INITIAL_WAIT"); err != nil {
+	if err := config.BindEnv("elasticsearch.retry.initial_wait", "ELASTICSEARCH_RETRY_INITIAL_WAIT"); err != nil {
 		return fmt.Errorf("failed to bind Elasticsearch retry initial wait: %w", err)
 	}
-	if err := viper.BindEnv("elasticsearch.retry.max_wait", "ELASTICSEARCH_RETRY_MAX_WAIT"); err != nil {
+	if err := config.BindEnv("elasticsearch.retry.max_wait", "ELASTICSEARCH_RETRY_MAX_WAIT"); err != nil {
 		return fmt.Errorf("failed to bind Elasticsearch retry max wait: %w", err)
 	}
 	return nil
@@ -296,6 +346,7 @@ func setDefaults() {
 		"max_depth":          crawler.DefaultMaxDepth,
 		"max_concurrency":    crawler.DefaultParallelism,
 		"request_timeout":    "30s",
+		"max_body_size":      crawler.DefaultMaxBodySize,
 		"user_agent":         crawler.DefaultUserAgent,
 		"respect_robots_txt": true,
 		"delay":              "1s",
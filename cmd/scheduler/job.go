@@ -5,15 +5,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/jonesrussell/gocrawl/internal/config"
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
 	"github.com/jonesrussell/gocrawl/internal/content"
 	"github.com/jonesrussell/gocrawl/internal/crawler"
 	"github.com/jonesrussell/gocrawl/internal/job"
 	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/monitor"
+	"github.com/jonesrussell/gocrawl/internal/retention"
 	"github.com/jonesrussell/gocrawl/internal/sources"
 	"github.com/jonesrussell/gocrawl/internal/storage/types"
 )
@@ -30,6 +34,12 @@ type SchedulerService struct {
 	storage          types.Interface
 	processorFactory crawler.ProcessorFactory
 	items            map[string][]*content.Item
+	// retentionInterval is how often retention policies are enforced; zero disables it
+	retentionInterval time.Duration
+	lastRetention     time.Time
+	// selectorMonitor checks source selectors against recently indexed articles; nil disables it
+	selectorMonitor *monitor.SelectorMonitor
+	lastMonitor     time.Time
 }
 
 // NewSchedulerService creates a new SchedulerService instance.
@@ -41,6 +51,8 @@ func NewSchedulerService(
 	cfg config.Interface,
 	storage types.Interface,
 	processorFactory crawler.ProcessorFactory,
+	retentionInterval time.Duration,
+	selectorMonitor *monitor.SelectorMonitor,
 ) job.Service {
 	return &SchedulerService{
 		logger:  log,
@@ -49,9 +61,11 @@ func NewSchedulerService(
 		done:    done,
 		config:  cfg,
 		// activeJobs is zero-initialized
-		storage:          storage,
-		processorFactory: processorFactory,
-		items:            make(map[string][]*content.Item),
+		storage:           storage,
+		processorFactory:  processorFactory,
+		items:             make(map[string][]*content.Item),
+		retentionInterval: retentionInterval,
+		selectorMonitor:   selectorMonitor,
 	}
 }
 
@@ -82,6 +96,8 @@ func (s *SchedulerService) Start(ctx context.Context) error {
 				if err := s.checkAndRunJobs(ctx, t); err != nil {
 					s.logger.Error("Failed to run jobs", "error", err)
 				}
+				s.checkAndRunRetention(ctx, t)
+				s.checkAndRunMonitor(ctx, t)
 			}
 		}
 	}()
@@ -89,6 +105,53 @@ func (s *SchedulerService) Start(ctx context.Context) error {
 	return nil
 }
 
+// checkAndRunRetention enforces source retention policies once the retention interval has elapsed.
+func (s *SchedulerService) checkAndRunRetention(ctx context.Context, now time.Time) {
+	if s.retentionInterval <= 0 || now.Sub(s.lastRetention) < s.retentionInterval {
+		return
+	}
+	s.lastRetention = now
+
+	sourceConfigs, err := s.sources.GetSources()
+	if err != nil {
+		s.logger.Error("Failed to get sources for retention", "error", err)
+		return
+	}
+
+	policies := retention.PoliciesFromSources(sourceConfigs)
+	if len(policies) == 0 {
+		return
+	}
+
+	reports, err := retention.NewEnforcer(s.logger, s.storage, false).Enforce(ctx, policies)
+	if err != nil {
+		s.logger.Error("Failed to enforce retention", "error", err)
+	}
+
+	var deleted int64
+	for i := range reports {
+		deleted += reports[i].DeletedDocs
+	}
+	s.logger.Info("Retention run completed", "indices", len(reports), "deleted_docs", deleted)
+}
+
+// checkAndRunMonitor checks the selectors of every source once the monitor interval has elapsed.
+func (s *SchedulerService) checkAndRunMonitor(ctx context.Context, now time.Time) {
+	if s.selectorMonitor == nil || now.Sub(s.lastMonitor) < s.selectorMonitor.Interval() {
+		return
+	}
+	s.lastMonitor = now
+
+	sourceConfigs, err := s.sources.GetSources()
+	if err != nil {
+		s.logger.Error("Failed to get sources for selector monitoring", "error", err)
+		return
+	}
+
+	results := s.selectorMonitor.CheckAll(ctx, sourceConfigs, now)
+	s.logger.Info("Selector check completed", "sources", len(results))
+}
+
 // Stop stops the scheduler service.
 func (s *SchedulerService) Stop(ctx context.Context) error {
 	s.logger.Info("Stopping scheduler service")
@@ -158,15 +221,25 @@ func (s *SchedulerService) checkAndRunJobs(ctx context.Context, now time.Time) e
 		return fmt.Errorf("failed to get sources: %w", err)
 	}
 
+	// Sources are also crawled at the times of their groups
+	var groups map[string]crawlerconfig.GroupConfig
+	if s.config != nil {
+		if crawlerCfg := s.config.GetCrawlerConfig(); crawlerCfg != nil {
+			groups = crawlerCfg.Groups
+		}
+	}
+
 	for i := range sourcesList {
 		source := &sourcesList[i]
-		for _, scheduledTime := range source.Time {
-			if currentTime == scheduledTime {
-				if crawlErr := s.executeCrawl(ctx, source); crawlErr != nil {
-					s.logger.Error("Failed to execute crawl", "error", crawlErr)
-					continue
-				}
-			}
+		if !slices.Contains(sources.ScheduledTimes(source, groups), currentTime) {
+			continue
+		}
+		if !source.IsEnabled(now) {
+			s.logger.Info("Skipping disabled source", "source", source.Name, "reason", source.DisabledReason)
+			continue
+		}
+		if crawlErr := s.executeCrawl(ctx, source); crawlErr != nil {
+			s.logger.Error("Failed to execute crawl", "error", crawlErr)
 		}
 	}
 
@@ -5,15 +5,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	cmdcommon "github.com/jonesrussell/gocrawl/cmd/common"
 	"github.com/jonesrussell/gocrawl/internal/config"
 	"github.com/jonesrussell/gocrawl/internal/constants"
 	articlespkg "github.com/jonesrussell/gocrawl/internal/content/articles"
+	"github.com/jonesrussell/gocrawl/internal/content/dedup"
 	pagepkg "github.com/jonesrussell/gocrawl/internal/content/page"
 	"github.com/jonesrussell/gocrawl/internal/crawler"
 	"github.com/jonesrussell/gocrawl/internal/crawler/events"
+	"github.com/jonesrussell/gocrawl/internal/linkgraph"
 	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/monitor"
+	"github.com/jonesrussell/gocrawl/internal/runhistory"
 	"github.com/jonesrussell/gocrawl/internal/sources"
 	"github.com/spf13/cobra"
 )
@@ -27,6 +32,23 @@ The scheduler will run continuously until interrupted with Ctrl+C.`,
 	RunE: runScheduler,
 }
 
+var (
+	// retentionInterval is how often the scheduler enforces source retention policies.
+	retentionInterval time.Duration
+	// reloadInterval is how often the scheduler polls the sources API for changes.
+	reloadInterval time.Duration
+)
+
+// defaultReloadInterval is how often the sources API is polled for changes by default.
+const defaultReloadInterval = time.Minute
+
+func init() {
+	Cmd.Flags().DurationVar(&retentionInterval, "retention-interval", 0,
+		"Enforce source retention policies at this interval (e.g. 24h); 0 disables it")
+	Cmd.Flags().DurationVar(&reloadInterval, "reload-interval", defaultReloadInterval,
+		"Poll the sources API for changed sources at this interval; 0 disables it")
+}
+
 // runScheduler executes the scheduler command
 func runScheduler(cmd *cobra.Command, _ []string) error {
 	// Get dependencies
@@ -41,6 +63,11 @@ func runScheduler(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to load sources: %w", err)
 	}
 
+	// Pick up source changes without a restart
+	if reloadErr := startSourceReload(cmd.Context(), deps.Config, deps.Logger, sourceManager); reloadErr != nil {
+		return fmt.Errorf("failed to start source reload: %w", reloadErr)
+	}
+
 	// Create storage using common function
 	storageResult, err := cmdcommon.CreateStorage(deps.Config, deps.Logger)
 	if err != nil {
@@ -54,9 +81,39 @@ func runScheduler(cmd *cobra.Command, _ []string) error {
 	pageService := pagepkg.NewContentService(
 		deps.Logger, storageResult.Storage, constants.DefaultPageIndex)
 
+	// Enable near-duplicate detection if configured
+	if crawlerCfg := deps.Config.GetCrawlerConfig(); crawlerCfg != nil && crawlerCfg.Dedup.Enabled {
+		articleService.SetDeduplicator(dedup.NewDeduplicator(deps.Logger, storageResult.Storage, crawlerCfg.Dedup))
+	}
+
+	// Run the enrichers listed on each source
+	enrichment, err := cmdcommon.CreateEnrichment(deps.Config, sourceManager)
+	if err != nil {
+		return fmt.Errorf("failed to create enrichment: %w", err)
+	}
+	articleService.SetEnrichment(enrichment)
+
+	// Create event bus, shared by the crawler and monitoring alerts
+	bus := events.NewEventBus(deps.Logger)
+
+	// Create the alerter of the monitoring checks
+	alerter, err := createAlerter(deps.Logger, deps.Config, bus)
+	if err != nil {
+		return fmt.Errorf("failed to create alerter: %w", err)
+	}
+
+	// Record each run in the source's run history and alert on anomalous runs
+	runRecorder := cmdcommon.CreateRunRecorder(deps.Config, deps.Logger, storageResult.Storage)
+	runRecorder.OnAnomaly(func(ctx context.Context, run *runhistory.Run) {
+		if alertErr := alerter.Alert(ctx, monitor.NewRunAlert(run)); alertErr != nil {
+			deps.Logger.Error("Failed to send alert", "source", run.Source, "error", alertErr)
+		}
+	})
+	articleService.SetRunRecorder(runRecorder)
+
 	// Create crawler
 	crawlerInstance, err := createCrawlerInstance(
-		deps.Logger, deps.Config, sourceManager, storageResult, articleService, pageService)
+		deps.Logger, deps.Config, sourceManager, storageResult, articleService, pageService, bus, runRecorder)
 	if err != nil {
 		return fmt.Errorf("failed to create crawler: %w", err)
 	}
@@ -64,6 +121,9 @@ func runScheduler(cmd *cobra.Command, _ []string) error {
 	// Create processor factory
 	processorFactory := crawler.NewProcessorFactory(deps.Logger, storageResult.Storage, constants.DefaultContentIndex)
 
+	// Check source selectors against recently indexed articles if configured
+	selectorMonitor := createSelectorMonitor(deps.Logger, deps.Config, storageResult, alerter)
+
 	// Create done channel
 	done := make(chan struct{})
 
@@ -76,6 +136,8 @@ func runScheduler(cmd *cobra.Command, _ []string) error {
 		deps.Config,
 		storageResult.Storage,
 		processorFactory,
+		retentionInterval,
+		selectorMonitor,
 	)
 
 	// Start the scheduler service
@@ -110,6 +172,32 @@ func runScheduler(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// startSourceReload watches the sources file, or polls the sources API, and
+// applies changed sources to the runs that follow.
+func startSourceReload(
+	ctx context.Context,
+	cfg config.Interface,
+	log logger.Interface,
+	sourceManager *sources.Sources,
+) error {
+	crawlerCfg := cfg.GetCrawlerConfig()
+	if crawlerCfg == nil {
+		return errors.New("crawler configuration is required")
+	}
+	reloader := sources.NewReloader(sourceManager, log, crawlerCfg)
+	reloader.SetAPIConfig(crawlerCfg.SourcesAPI, crawlerCfg.SourcesCache)
+
+	if crawlerCfg.SourcesFile != "" {
+		log.Info("Watching sources file for changes", "path", crawlerCfg.SourcesFile)
+		return reloader.WatchFile(ctx, crawlerCfg.SourcesFile)
+	}
+	if reloadInterval > 0 {
+		log.Info("Polling sources API for changes", "interval", reloadInterval)
+		reloader.PollAPI(ctx, crawlerCfg.SourcesAPIURL, reloadInterval)
+	}
+	return nil
+}
+
 // createCrawlerInstance creates a crawler instance with the given services.
 // This is a helper function to consolidate crawler creation logic.
 func createCrawlerInstance(
@@ -119,16 +207,21 @@ func createCrawlerInstance(
 	storageResult *cmdcommon.StorageResult,
 	articleService articlespkg.Interface,
 	pageService pagepkg.Interface,
+	bus *events.EventBus,
+	runRecorder *runhistory.Recorder,
 ) (crawler.Interface, error) {
-	// Create event bus
-	bus := events.NewEventBus(log)
-
 	// Get crawler config
 	crawlerCfg := cfg.GetCrawlerConfig()
 	if crawlerCfg == nil {
 		return nil, errors.New("crawler configuration is required")
 	}
 
+	// Record the link graph if configured
+	var linkRecorder *linkgraph.Recorder
+	if crawlerCfg.Links.Enabled {
+		linkRecorder = linkgraph.NewRecorder(log, storageResult.Storage, crawlerCfg.Links)
+	}
+
 	// Create crawler using NewCrawlerWithParams
 	crawlerResult, err := crawler.NewCrawlerWithParams(crawler.CrawlerParams{
 		Logger:         log,
@@ -139,6 +232,8 @@ func createCrawlerInstance(
 		ArticleService: articleService,
 		PageService:    pageService,
 		Storage:        storageResult.Storage,
+		LinkRecorder:   linkRecorder,
+		RunRecorder:    runRecorder,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create crawler: %w", err)
@@ -147,6 +242,31 @@ func createCrawlerInstance(
 	return crawlerResult.Crawler, nil
 }
 
+// createAlerter creates the alerter sending to the configured alert sinks.
+func createAlerter(log logger.Interface, cfg config.Interface, bus *events.EventBus) (monitor.Alerter, error) {
+	crawlerCfg := cfg.GetCrawlerConfig()
+	if crawlerCfg == nil {
+		return nil, errors.New("crawler configuration is required")
+	}
+	return monitor.NewAlerter(crawlerCfg.Alerts, log, bus)
+}
+
+// createSelectorMonitor creates the selector monitor, or returns nil when monitoring is disabled.
+func createSelectorMonitor(
+	log logger.Interface,
+	cfg config.Interface,
+	storageResult *cmdcommon.StorageResult,
+	alerter monitor.Alerter,
+) *monitor.SelectorMonitor {
+	crawlerCfg := cfg.GetCrawlerConfig()
+	if crawlerCfg == nil || !crawlerCfg.Monitor.Enabled {
+		return nil
+	}
+
+	log.Info("Monitoring source selectors", "interval", crawlerCfg.Monitor.Interval)
+	return monitor.NewSelectorMonitor(log, storageResult.Storage, crawlerCfg.Monitor, alerter)
+}
+
 // Command returns the scheduler command for use in the root command.
 func Command() *cobra.Command {
 	return Cmd
@@ -52,6 +52,10 @@ type Params struct {
 	IndexName string
 	// Query contains the search query string
 	Query string
+	// Lang selects the language analyzers to search with; empty detects it from the query
+	Lang string
+	// Group restricts results to the documents of a source group
+	Group string
 	// ResultSize determines how many results to return
 	ResultSize int
 }
@@ -80,6 +84,8 @@ Flags:
   -i, --index string   Index to search (default "articles")
   -q, --query string   Query string to search for (required)
   -s, --size int      Number of results to return (default 10)
+  -l, --lang string    Language to search in, e.g. en or fr (default: detected from the query)
+  -g, --group string   Only search the documents of a source group
 `,
 	RunE: runSearch,
 }
@@ -90,6 +96,8 @@ func Command() *cobra.Command {
 	Cmd.Flags().StringP("index", "i", "articles", "Index to search")
 	Cmd.Flags().IntP("size", "s", DefaultSearchSize, "Number of results to return")
 	Cmd.Flags().StringP("query", "q", "", "Query string to search for")
+	Cmd.Flags().StringP("lang", "l", "", "Language to search in, e.g. en or fr (default: detected from the query)")
+	Cmd.Flags().StringP("group", "g", "", "Only search the documents of a source group")
 
 	// Mark the query flag as required
 	if err := Cmd.MarkFlagRequired("query"); err != nil {
@@ -118,6 +126,8 @@ func runSearch(cmd *cobra.Command, _ []string) error {
 	// Get command-line parameters
 	indexName := cmd.Flag("index").Value.String()
 	queryStr := cmd.Flag("query").Value.String()
+	lang := cmd.Flag("lang").Value.String()
+	group := cmd.Flag("group").Value.String()
 
 	// Create storage using common function
 	storageResult, err := common.CreateStorage(deps.Config, deps.Logger)
@@ -135,6 +145,8 @@ func runSearch(cmd *cobra.Command, _ []string) error {
 		SearchManager: searchManager,
 		IndexName:     indexName,
 		Query:         queryStr,
+		Lang:          lang,
+		Group:         group,
 		ResultSize:    size,
 	}
 
@@ -142,18 +154,6 @@ func runSearch(cmd *cobra.Command, _ []string) error {
 	return ExecuteSearch(cmd.Context(), params)
 }
 
-// buildSearchQuery constructs the Elasticsearch query
-func buildSearchQuery(size int, query string) map[string]any {
-	return map[string]any{
-		"query": map[string]any{
-			"match": map[string]any{
-				"content": query,
-			},
-		},
-		"size": size,
-	}
-}
-
 // processSearchResults converts raw search results to Result structs
 func processSearchResults(rawResults []any, log logger.Interface) []Result {
 	var results []Result
@@ -251,7 +251,15 @@ func ExecuteSearch(ctx context.Context, p Params) error {
 		"size", p.ResultSize,
 	)
 
-	query := buildSearchQuery(p.ResultSize, p.Query)
+	query, err := api.BuildSearchQuery(api.SearchRequest{
+		Query: p.Query,
+		Size:  p.ResultSize,
+		Lang:  p.Lang,
+		Group: p.Group,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid search: %w", err)
+	}
 	rawResults, err := p.SearchManager.Search(ctx, p.IndexName, query)
 	if err != nil {
 		p.Logger.Error("Search failed", "error", err)
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -20,6 +21,15 @@ var (
 	generateOutputFile string
 	generateArticleURL string
 	generateSamples    int
+	generateLearnURLs  bool
+	generatePublish    bool
+)
+
+const (
+	// maxLearnPages caps the pages fetched one level below the listing page to learn URL patterns
+	maxLearnPages = 30
+	// learnSamplesPerPattern is how many pages of each URL template are validated
+	learnSamplesPerPattern = 3
 )
 
 // NewGenerateCommand creates a new generate subcommand for sources.
@@ -36,7 +46,18 @@ Example:
   # Analyze both listing and article pages for best results
   gocrawl sources generate https://www.example.com/news \
     --article-url https://www.example.com/news/article-123 \
-    -o new_source.yaml`,
+    -o new_source.yaml
+
+  # Infer title, body, author and date selectors from the structure shared by
+  # five articles linked from the listing page (works with hashed class names)
+  gocrawl sources generate https://www.example.com/news --samples 5
+
+  # Learn which URL templates lead to articles and emit them as allow rules
+  # along with an article card selector for the listing page
+  gocrawl sources generate https://www.example.com --learn-urls
+
+  # Create the source through the gosources API
+  gocrawl sources generate https://www.example.com/news --publish`,
 		Args: cobra.ExactArgs(1),
 		RunE: runGenerate,
 	}
@@ -45,7 +66,11 @@ Example:
 	cmd.Flags().StringVarP(&generateArticleURL, "article-url", "a", "",
 		"Analyze an article page for better body/metadata selectors")
 	cmd.Flags().IntVarP(&generateSamples, "samples", "n", 1,
-		"Number of sample articles to analyze (default: 1, future use)")
+		"Number of sample articles to analyze; with more than one, selectors are inferred across them")
+	cmd.Flags().BoolVar(&generateLearnURLs, "learn-urls", false,
+		"Crawl one level below the page to learn article URL patterns and article card selectors")
+	cmd.Flags().BoolVar(&generatePublish, "publish", false,
+		"Create the source through the gosources API; YAML is only written with --output")
 
 	return cmd
 }
@@ -74,6 +99,10 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate YAML: %w", err)
 	}
 
+	if generatePublish {
+		return publishSource(cmd, sourceURL, finalResult, yamlContent)
+	}
+
 	// Write output
 	if writeErr := writeOutput(yamlContent); writeErr != nil {
 		return writeErr
@@ -85,6 +114,36 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// publishSource creates the discovered source through the gosources API,
+// writing the YAML too when an output file is given.
+func publishSource(cmd *cobra.Command, sourceURL string, result generator.DiscoveryResult, yamlContent string) error {
+	if generateOutputFile != "" {
+		if writeErr := writeOutput(yamlContent); writeErr != nil {
+			return writeErr
+		}
+	}
+
+	source, err := generator.NewAPISource(sourceURL, result)
+	if err != nil {
+		return fmt.Errorf("failed to convert selectors: %w", err)
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	created, err := client.CreateSource(cmd.Context(), source)
+	if err != nil {
+		return fmt.Errorf("failed to publish source: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "\n✅ Created source %s (ID %s) through the sources API\n", created.Name, created.ID)
+	if len(result.URLPatterns) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  The API has no URL rules; add the learned article rules to the source by hand.\n")
+	}
+	fmt.Fprintf(os.Stderr, "⚠️  IMPORTANT: Review and refine these selectors manually!\n")
+	return nil
+}
+
 // prepareOutputDirectory ensures the output directory exists if needed.
 func prepareOutputDirectory() error {
 	if generateOutputFile == "" {
@@ -120,14 +179,140 @@ func discoverSelectors(sourceURL string) (generator.DiscoveryResult, error) {
 	}
 
 	// Discover selectors from main page
-	mainResult := mainDiscovery.DiscoverAll()
+	result := mainDiscovery.DiscoverAll()
 
 	// If article URL provided, fetch and merge
-	if generateArticleURL == "" {
-		return mainResult, nil
+	if generateArticleURL != "" {
+		result, err = discoverAndMergeArticleSelectors(generateArticleURL, result)
+		if err != nil {
+			return generator.DiscoveryResult{}, err
+		}
+	}
+
+	// With several samples, infer selectors from the structure the articles share
+	if generateSamples > 1 {
+		result = inferAndMergeSelectors(mainDiscovery, result)
 	}
 
-	return discoverAndMergeArticleSelectors(generateArticleURL, mainResult)
+	if generateLearnURLs {
+		result = learnURLPatterns(sourceURL, mainDoc, result)
+	}
+
+	return result, nil
+}
+
+// learnURLPatterns clusters the links of the main page by path template,
+// validates sample pages of every template as articles, and adds the article
+// templates and the article card selector to the result.
+func learnURLPatterns(
+	sourceURL string,
+	mainDoc *goquery.Document,
+	result generator.DiscoveryResult,
+) generator.DiscoveryResult {
+	learner, err := generator.NewURLPatternLearner(sourceURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Failed to learn URL patterns: %v\n\n", err)
+		return result
+	}
+	learner.AddListing(mainDoc)
+
+	fetched := 0
+	for _, pattern := range learner.Patterns() {
+		for i := 0; i < len(pattern.URLs) && i < learnSamplesPerPattern; i++ {
+			if fetched == maxLearnPages {
+				break
+			}
+			fetched++
+			pageURL := pattern.URLs[i]
+			doc, fetchErr := fetchDocument(pageURL)
+			if fetchErr != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Failed to fetch %s: %v\n", pageURL, fetchErr)
+				continue
+			}
+			learner.AddPage(pageURL, doc)
+		}
+	}
+
+	result.URLPatterns = learner.ArticlePatterns()
+	result.ArticleCards = learner.ArticleCards(result.URLPatterns)
+	fmt.Fprintf(os.Stderr, "✅ Learned %d article URL pattern(s) from %d page(s)\n\n",
+		len(result.URLPatterns), fetched)
+
+	return result
+}
+
+// inferAndMergeSelectors fetches sample articles linked from the main page and
+// merges the selectors inferred across them into the result.
+func inferAndMergeSelectors(
+	mainDiscovery *generator.SelectorDiscovery,
+	result generator.DiscoveryResult,
+) generator.DiscoveryResult {
+	var sampleURLs []string
+	if generateArticleURL != "" {
+		sampleURLs = append(sampleURLs, generateArticleURL)
+	}
+	for _, articleURL := range mainDiscovery.SampleArticleURLs(generateSamples) {
+		if len(sampleURLs) < generateSamples && !contains(sampleURLs, articleURL) {
+			sampleURLs = append(sampleURLs, articleURL)
+		}
+	}
+
+	docs := make([]*goquery.Document, 0, len(sampleURLs))
+	for _, articleURL := range sampleURLs {
+		fmt.Fprintf(os.Stderr, "🔍 Fetching sample article %s...\n", articleURL)
+		doc, err := fetchDocument(articleURL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Failed to fetch sample article: %v\n", err)
+			continue
+		}
+		docs = append(docs, doc)
+	}
+
+	const minSamples = 2
+	if len(docs) < minSamples {
+		fmt.Fprintf(os.Stderr, "⚠️  Found %d sample article(s); at least %d are needed to infer selectors\n\n",
+			len(docs), minSamples)
+		return result
+	}
+
+	inferred := generator.NewStructuralInference(docs).InferAll()
+	result.Title = preferCandidate(result.Title, inferred.Title)
+	result.Body = preferCandidate(result.Body, inferred.Body)
+	result.Author = preferCandidate(result.Author, inferred.Author)
+	result.PublishedTime = preferCandidate(result.PublishedTime, inferred.PublishedTime)
+	fmt.Fprintf(os.Stderr, "✅ Inferred selectors across %d sample articles\n\n", len(docs))
+
+	return result
+}
+
+// preferCandidate keeps the more confident of two candidates for a field and
+// ranks the selectors of the other among its alternatives.
+func preferCandidate(current, inferred generator.SelectorCandidate) generator.SelectorCandidate {
+	if len(inferred.Selectors) == 0 {
+		return current
+	}
+	best, other := inferred, current
+	if len(current.Selectors) > 0 && current.Confidence > inferred.Confidence {
+		best, other = current, inferred
+	}
+
+	alternatives := append([]generator.RankedSelector{}, best.Alternatives...)
+	if len(other.Selectors) > 0 {
+		alternatives = append(alternatives, generator.RankedSelector{
+			Selector:   strings.Join(other.Selectors, ", "),
+			Confidence: other.Confidence,
+		})
+	}
+	alternatives = append(alternatives, other.Alternatives...)
+	sort.SliceStable(alternatives, func(i, j int) bool {
+		return alternatives[i].Confidence > alternatives[j].Confidence
+	})
+	best.Alternatives = alternatives
+
+	if best.SampleText == "" {
+		best.SampleText = other.SampleText
+	}
+	return best
 }
 
 // discoverAndMergeArticleSelectors fetches article page and merges results.
@@ -241,6 +426,16 @@ func printSummary(w io.Writer, result generator.DiscoveryResult) {
 	printCandidate(w, "image", result.Image)
 	printCandidate(w, "link", result.Link)
 	printCandidate(w, "category", result.Category)
+	printCandidate(w, "article_cards", result.ArticleCards)
+
+	if len(result.URLPatterns) > 0 {
+		fmt.Fprintf(w, "article URL patterns (%d found):\n", len(result.URLPatterns))
+		for _, pattern := range result.URLPatterns {
+			fmt.Fprintf(w, "  - %s (%d/%d sampled pages are articles, %d URLs)\n",
+				pattern.Template, pattern.Valid, pattern.Sampled, len(pattern.URLs))
+		}
+		fmt.Fprintf(w, "\n")
+	}
 
 	if len(result.Exclusions) > 0 {
 		fmt.Fprintf(w, "\nexclude (%d patterns found):\n", len(result.Exclusions))
@@ -263,6 +458,9 @@ func printCandidate(w io.Writer, fieldName string, candidate generator.SelectorC
 	for _, sel := range candidate.Selectors {
 		fmt.Fprintf(w, "  - %s\n", sel)
 	}
+	for _, alt := range candidate.Alternatives {
+		fmt.Fprintf(w, "  alternative (%.0f%%): %s\n", alt.Confidence*confidencePercent, alt.Selector)
+	}
 	if candidate.SampleText != "" {
 		sample := candidate.SampleText
 		const maxSampleDisplayLength = 80
@@ -0,0 +1,174 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jonesrussell/gocrawl/cmd/common"
+	crawlercfg "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/runhistory"
+	internalsources "github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/spf13/cobra"
+)
+
+// Health statuses of a source
+const (
+	healthOK        = "ok"
+	healthAnomalous = "anomalous"
+	healthCancelled = "cancelled"
+	healthNoRuns    = "no runs"
+	healthDisabled  = "disabled"
+)
+
+// NewHealthCommand creates the health subcommand for sources.
+func NewHealthCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "health",
+		Short: "Summarize the health of every source",
+		Long: `Show the latest crawl run of every source next to its rolling baseline.
+A source is anomalous when its latest run indexed far fewer articles, visited
+far fewer pages, failed far more often or extracted far shorter bodies than
+its previous runs, as configured under crawler.runs. A source whose latest run
+was cut short is shown as cancelled, as that run cannot be rated.`,
+		RunE: runHealth,
+	}
+
+	return cmd
+}
+
+// runHealth executes the health command.
+func runHealth(cmd *cobra.Command, _ []string) error {
+	deps, err := common.NewCommandDeps()
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+
+	sourceManager, err := internalsources.LoadSources(deps.Config, deps.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to load sources: %w", err)
+	}
+	sourceConfigs, err := sourceManager.GetSources()
+	if err != nil {
+		return fmt.Errorf("failed to get sources: %w", err)
+	}
+
+	storageResult, err := common.CreateStorage(deps.Config, deps.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	runsCfg := crawlercfg.NewRunsConfig()
+	if crawlerCfg := deps.Config.GetCrawlerConfig(); crawlerCfg != nil {
+		runsCfg = crawlerCfg.Runs
+	}
+	store := runhistory.NewStore(storageResult.Storage, runsCfg.Index)
+
+	rows, err := sourceHealth(cmd.Context(), store, sourceConfigs, runsCfg, time.Now())
+	if err != nil {
+		return err
+	}
+	renderHealth(os.Stdout, rows)
+	return nil
+}
+
+// healthRow is the health of one source.
+type healthRow struct {
+	source   string
+	status   string
+	latest   *runhistory.Run
+	baseline float64
+}
+
+// sourceHealth loads the latest runs of every source and rates the sources.
+func sourceHealth(
+	ctx context.Context,
+	store *runhistory.Store,
+	sourceConfigs []internalsources.Config,
+	cfg crawlercfg.RunsConfig,
+	now time.Time,
+) ([]healthRow, error) {
+	rows := make([]healthRow, 0, len(sourceConfigs))
+	for i := range sourceConfigs {
+		source := &sourceConfigs[i]
+		runs, err := store.Recent(ctx, source.Name, cfg.BaselineRuns+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load runs of %s: %w", source.Name, err)
+		}
+
+		row := healthRow{source: source.Name}
+		switch {
+		case !source.IsEnabled(now):
+			row.status = healthDisabled
+		case len(runs) == 0:
+			row.status = healthNoRuns
+		case runs[0].Cancelled:
+			row.status = healthCancelled
+		case runs[0].Anomalous():
+			row.status = healthAnomalous
+		default:
+			row.status = healthOK
+		}
+		if len(runs) > 0 {
+			row.latest = &runs[0]
+			row.baseline = meanArticles(runs[1:])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// meanArticles is the mean number of articles indexed by completed runs.
+func meanArticles(runs []runhistory.Run) float64 {
+	var sum float64
+	var completed int
+	for i := range runs {
+		if !runs[i].Cancelled {
+			sum += float64(runs[i].ArticlesIndexed)
+			completed++
+		}
+	}
+	if completed == 0 {
+		return 0
+	}
+	return sum / float64(completed)
+}
+
+// renderHealth prints the health of the sources as a table.
+func renderHealth(w io.Writer, rows []healthRow) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{
+		"Source", "Status", "Last Run", "Articles", "Baseline", "Pages", "Error Rate", "Mean Body", "Anomalies",
+	})
+
+	const percent = 100
+	for _, row := range rows {
+		if row.latest == nil {
+			t.AppendRow(table.Row{row.source, row.status, "-", "-", "-", "-", "-", "-", ""})
+			continue
+		}
+		run := row.latest
+		anomalies := make([]string, 0, len(run.Anomalies))
+		for _, anomaly := range run.Anomalies {
+			anomalies = append(anomalies, anomaly.String())
+		}
+		t.AppendRow(table.Row{
+			row.source,
+			row.status,
+			run.FinishedAt.Local().Format(time.DateTime),
+			run.ArticlesIndexed,
+			fmt.Sprintf("%.0f", row.baseline),
+			run.PagesVisited,
+			fmt.Sprintf("%.1f%%", run.ErrorRate*percent),
+			fmt.Sprintf("%.0f", run.MeanBodyLength),
+			strings.Join(anomalies, "; "),
+		})
+	}
+	t.Render()
+}
@@ -0,0 +1,100 @@
+// Package sources provides the sources command implementation.
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/jonesrussell/gocrawl/internal/config/schema"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewLintCommand creates the lint subcommand for sources.
+func NewLintCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint [file|dir...]",
+		Short: "Check sources files for unknown keys and invalid values",
+		Long: `Check YAML sources files against the sources schema. Unknown keys, values of
+the wrong type and sources the loader would skip, such as a source without a
+name, are errors; deprecated keys and suspicious values are warnings. A
+directory stands for the *.yaml and *.yml files in it, as with sources push.
+Without arguments, the sources file of the configuration is checked. The
+command fails when a file has errors.
+
+Examples:
+  gocrawl sources lint
+  gocrawl sources lint sources/ extra.yml`,
+		RunE: runLint,
+		// The issues are already listed; main reports the error once, without usage
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+}
+
+// runLint executes the lint command.
+func runLint(cmd *cobra.Command, args []string) error {
+	paths := args
+	if len(paths) == 0 {
+		sourcesFile := viper.GetString("crawler.sources_file")
+		if sourcesFile == "" {
+			return errors.New("no sources file configured; pass the files or directories to check")
+		}
+		paths = []string{sourcesFile}
+	}
+	files, err := sourcesFiles(paths)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	valid := true
+	for _, file := range files {
+		if filepath.Ext(file) == ".json" {
+			fmt.Fprintf(w, "%s: skipped, sources snapshots are written by gocrawl\n", file)
+			continue
+		}
+		issues, lintErr := schema.LintSourcesFile(file)
+		if lintErr != nil {
+			return fmt.Errorf("failed to check %s: %w", file, lintErr)
+		}
+		schema.WriteIssues(w, file, issues)
+		if schema.HasErrors(issues) {
+			valid = false
+		}
+	}
+	if !valid {
+		return schema.ErrInvalid
+	}
+	fmt.Fprintf(w, "%d file(s) checked\n", len(files))
+	return nil
+}
+
+// sourcesFiles expands directories to the YAML files in them.
+func sourcesFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		var dirFiles []string
+		for _, pattern := range []string{"*.yaml", "*.yml"} {
+			matches, globErr := filepath.Glob(filepath.Join(path, pattern))
+			if globErr != nil {
+				return nil, fmt.Errorf("failed to list %s: %w", path, globErr)
+			}
+			dirFiles = append(dirFiles, matches...)
+		}
+		sort.Strings(dirFiles)
+		files = append(files, dirFiles...)
+	}
+	return files, nil
+}
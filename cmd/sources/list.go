@@ -7,6 +7,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jonesrussell/gocrawl/cmd/common"
@@ -37,9 +40,12 @@ func (r *TableRenderer) RenderTable(sources []*internalsources.Config) error {
 	t.SetStyle(table.StyleLight)
 
 	// Add table headers
-	t.AppendHeader(table.Row{"Name", "URL", "Max Depth", "Rate Limit", "Content Index", "Article Index"})
+	t.AppendHeader(table.Row{
+		"Name", "URL", "Max Depth", "Rate Limit", "Content Index", "Article Index", "Groups", "Status",
+	})
 
 	// Process each source
+	now := time.Now()
 	for _, source := range sources {
 		// Add row to table
 		t.AppendRow(table.Row{
@@ -49,6 +55,8 @@ func (r *TableRenderer) RenderTable(sources []*internalsources.Config) error {
 			source.RateLimit,
 			source.Index,
 			source.ArticleIndex,
+			strings.Join(source.Groups(), ", "),
+			sourceStatus(source, now),
 		})
 	}
 
@@ -57,11 +65,24 @@ func (r *TableRenderer) RenderTable(sources []*internalsources.Config) error {
 	return nil
 }
 
+// sourceStatus describes whether a source is crawled.
+func sourceStatus(source *internalsources.Config, now time.Time) string {
+	if source.IsEnabled(now) {
+		return "enabled"
+	}
+	if source.DisabledUntil != nil {
+		return "disabled until " + source.DisabledUntil.Format(time.RFC3339)
+	}
+	return "disabled"
+}
+
 // Lister handles listing sources
 type Lister struct {
 	sourceManager internalsources.Interface
 	logger        logger.Interface
 	renderer      *TableRenderer
+	// group limits the listing to the sources of a group when set
+	group string
 }
 
 // NewLister creates a new Lister instance
@@ -77,6 +98,11 @@ func NewLister(
 	}
 }
 
+// SetGroup limits the listing to the sources of a group.
+func (l *Lister) SetGroup(group string) {
+	l.group = group
+}
+
 // Start begins the list operation
 func (l *Lister) Start(ctx context.Context) error {
 	l.logger.Info("Listing sources")
@@ -86,6 +112,16 @@ func (l *Lister) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to get sources: %w", err)
 	}
 
+	if l.group != "" {
+		sources = slices.DeleteFunc(sources, func(source *internalsources.Config) bool {
+			return !source.InGroup(l.group)
+		})
+		if len(sources) == 0 {
+			l.logger.Info("No sources in group", "group", l.group)
+			return nil
+		}
+	}
+
 	if len(sources) == 0 {
 		l.logger.Info("No sources configured")
 		return nil
@@ -97,10 +133,13 @@ func (l *Lister) Start(ctx context.Context) error {
 
 // NewListCommand creates a new list command
 func NewListCommand() *cobra.Command {
+	var group string
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all configured sources",
-		Long:  `List all content sources configured in the system.`,
+		Long: `List all content sources configured in the system.
+Use --group to list only the sources whose group ID or tags name the group.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Get dependencies - NEW WAY
 			deps, err := common.NewCommandDeps()
@@ -121,11 +160,13 @@ func NewListCommand() *cobra.Command {
 
 			renderer := NewTableRenderer(deps.Logger)
 			lister := NewLister(sourceManager, deps.Logger, renderer)
+			lister.SetGroup(group)
 
 			// Execute the list command
 			return lister.Start(cmd.Context())
 		},
 	}
+	cmd.Flags().StringVar(&group, "group", "", "Only list the sources of a group")
 
 	return cmd
 }
@@ -0,0 +1,64 @@
+// Package sources provides the sources command implementation.
+package sources
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/cmd/common"
+	"github.com/jonesrussell/gocrawl/internal/sources/loader"
+	"github.com/spf13/cobra"
+)
+
+// defaultSnapshotFile is where snapshot writes when no output is given.
+const defaultSnapshotFile = "sources-snapshot.json"
+
+// NewSnapshotCommand creates the snapshot subcommand, which saves the gosources API sources to a file.
+func NewSnapshotCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save the gosources API sources to a snapshot file",
+		Long: `Save the sources of the gosources API, with the time they were fetched and a
+checksum, to a JSON snapshot. Any command can then run from the snapshot with
+--sources-file, for example while the gosources service is down.
+
+Example:
+  gocrawl sources snapshot -o sources-snapshot.json
+  gocrawl crawl "Sudbury Star" --sources-file sources-snapshot.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			deps, err := common.NewCommandDeps()
+			if err != nil {
+				return fmt.Errorf("failed to get dependencies: %w", err)
+			}
+			client, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+
+			apiSources, err := client.ListSources(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list sources: %w", err)
+			}
+			snapshot, err := loader.NewSnapshot(client.BaseURL(), apiSources, time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to create snapshot: %w", err)
+			}
+			if writeErr := loader.WriteSnapshot(output, snapshot); writeErr != nil {
+				return writeErr
+			}
+
+			deps.Logger.Info("Sources snapshot written",
+				"path", output,
+				"sources", len(apiSources),
+				"checksum", snapshot.Checksum,
+			)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", defaultSnapshotFile, "Snapshot file to write")
+
+	return cmd
+}
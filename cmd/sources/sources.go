@@ -50,6 +50,13 @@ func NewSourcesCommand() *cobra.Command {
 		NewListCommand(),
 		NewGenerateCommand(),
 		NewValidateCommand(),
+		NewEnableCommand(),
+		NewDisableCommand(),
+		NewHealthCommand(),
+		NewPushCommand(),
+		NewPullCommand(),
+		NewSnapshotCommand(),
+		NewLintCommand(),
 	)
 
 	return cmd
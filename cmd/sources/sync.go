@@ -0,0 +1,162 @@
+// Package sources provides the sources command implementation.
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jonesrussell/gocrawl/cmd/common"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
+	"github.com/spf13/cobra"
+)
+
+// syncOptions are the flags shared by push and pull.
+type syncOptions struct {
+	dryRun bool
+	force  bool
+}
+
+// addFlags registers the sync flags on a command.
+func (o *syncOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "Show the changes without applying them")
+	cmd.Flags().BoolVar(&o.force, "force", false, "Overwrite sources that changed on both sides")
+}
+
+// NewPushCommand creates the push subcommand, which uploads a sources directory to the gosources API.
+func NewPushCommand() *cobra.Command {
+	var opts syncOptions
+
+	cmd := &cobra.Command{
+		Use:   "push <dir>",
+		Short: "Upload a sources directory to the gosources API",
+		Long: `Create or update the sources of the YAML files in a directory through the
+gosources API. The changes are shown before they are applied. A source whose
+API copy was updated after it was pulled is skipped unless --force is given.
+
+Example:
+  gocrawl sources push ./sources --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+			dir, err := sources.ReadSourcesDir(args[0])
+			if err != nil {
+				return err
+			}
+			remote, err := client.ListSources(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list sources: %w", err)
+			}
+
+			changes := sources.PlanPush(dir, remote)
+			printSyncPlan(cmd.OutOrStdout(), changes, opts.force)
+			if opts.dryRun {
+				return nil
+			}
+			return sources.Push(cmd.Context(), client, dir, changes, opts.force)
+		},
+	}
+	opts.addFlags(cmd)
+
+	return cmd
+}
+
+// NewPullCommand creates the pull subcommand, which downloads the gosources API sources to a directory.
+func NewPullCommand() *cobra.Command {
+	var opts syncOptions
+
+	cmd := &cobra.Command{
+		Use:   "pull <dir>",
+		Short: "Download the gosources API sources to a directory",
+		Long: `Write the sources of the gosources API to YAML files in a directory, one file
+per new source. The changes are shown before they are applied. A source with
+local edits that were not pushed is skipped unless --force is given.
+
+Example:
+  gocrawl sources pull ./sources`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+			dir, err := sources.ReadSourcesDir(args[0])
+			if err != nil {
+				return err
+			}
+			remote, err := client.ListSources(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list sources: %w", err)
+			}
+
+			changes := sources.PlanPull(dir, remote)
+			printSyncPlan(cmd.OutOrStdout(), changes, opts.force)
+			if opts.dryRun {
+				return nil
+			}
+			return sources.Pull(dir, changes, opts.force)
+		},
+	}
+	opts.addFlags(cmd)
+
+	return cmd
+}
+
+// newAPIClient creates a gosources API client with the URL, credentials and
+// retry settings of the crawler configuration.
+func newAPIClient() (*apiclient.Client, error) {
+	deps, err := common.NewCommandDeps()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	crawlerCfg := deps.Config.GetCrawlerConfig()
+	if crawlerCfg == nil || crawlerCfg.SourcesAPIURL == "" {
+		return nil, errors.New("sources_api_url is required in crawler configuration")
+	}
+	return apiclient.NewClient(
+		apiclient.WithBaseURL(crawlerCfg.SourcesAPIURL),
+		apiclient.WithConfig(crawlerCfg.SourcesAPI),
+	), nil
+}
+
+// printSyncPlan prints the changes of a push or pull, field by field.
+func printSyncPlan(w io.Writer, changes []sources.SyncChange, force bool) {
+	counts := make(map[sources.SyncAction]int)
+	for i := range changes {
+		change := &changes[i]
+		counts[change.Action]++
+		switch change.Action {
+		case sources.SyncCreate:
+			fmt.Fprintf(w, "+ create %s\n", change.Name)
+		case sources.SyncUpdate:
+			fmt.Fprintf(w, "~ update %s\n", change.Name)
+		case sources.SyncConflict:
+			resolution := "skipped, use --force to overwrite"
+			if force {
+				resolution = "overwriting"
+			}
+			fmt.Fprintf(w, "! conflict %s: %s (%s)\n", change.Name, change.Reason, resolution)
+		case sources.SyncUnchanged:
+			continue
+		}
+		for _, field := range change.Fields {
+			fmt.Fprintf(w, "    %s: %s -> %s\n", field.Field, orUnset(field.From), orUnset(field.To))
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d to create, %d to update, %d unchanged, %d conflicting\n",
+		counts[sources.SyncCreate], counts[sources.SyncUpdate],
+		counts[sources.SyncUnchanged], counts[sources.SyncConflict])
+}
+
+// orUnset shows an unset field value.
+func orUnset(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	return value
+}
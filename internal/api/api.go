@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jonesrussell/gocrawl/internal/api/middleware"
 	"github.com/jonesrussell/gocrawl/internal/config"
+	"github.com/jonesrussell/gocrawl/internal/config/server"
 	"github.com/jonesrussell/gocrawl/internal/logger"
 )
 
@@ -61,7 +62,7 @@ func SetupRouter(
 	// Define protected routes
 	protected := router.Group("")
 	protected.Use(security.Middleware())
-	protected.POST("/search", handleSearch(searchManager))
+	protected.POST("/search", security.RequireScope(server.ScopeSearchRead), handleSearch(searchManager))
 
 	return router, security
 }
@@ -78,13 +79,17 @@ func loggingMiddleware(log logger.Interface) gin.HandlerFunc {
 		latency := time.Since(start)
 		statusCode := c.Writer.Status()
 
-		log.Info("HTTP Request",
+		fields := []any{
 			"method", c.Request.Method,
 			"path", path,
 			"query", query,
 			"status", statusCode,
 			"latency", latency,
-		)
+		}
+		if keyID := c.GetString(middleware.KeyIDContextKey); keyID != "" {
+			fields = append(fields, "key_id", keyID)
+		}
+		log.Info("HTTP Request", fields...)
 	}
 }
 
@@ -112,13 +117,12 @@ func handleSearch(searchManager SearchManager) gin.HandlerFunc {
 		}
 
 		// Create search query
-		query := map[string]any{
-			"query": map[string]any{
-				"match": map[string]any{
-					"content": req.Query,
-				},
-			},
-			"size": req.Size,
+		query, err := BuildSearchQuery(req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
 		}
 
 		// Perform search
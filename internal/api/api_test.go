@@ -0,0 +1,121 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/api"
+	"github.com/jonesrussell/gocrawl/internal/api/auth"
+	"github.com/jonesrussell/gocrawl/internal/api/middleware"
+	"github.com/jonesrussell/gocrawl/internal/config"
+	"github.com/jonesrussell/gocrawl/internal/config/server"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// searchManager returns no results.
+type searchManager struct{}
+
+func (searchManager) Search(context.Context, string, map[string]any) ([]any, error) { return nil, nil }
+func (searchManager) Count(context.Context, string, map[string]any) (int64, error)  { return 0, nil }
+func (searchManager) Aggregate(context.Context, string, map[string]any) (map[string]any, error) {
+	return map[string]any{}, nil
+}
+func (searchManager) Close() error { return nil }
+
+// fakeClock is a time provider the test moves forward.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// search posts a search request with an API key.
+func search(t *testing.T, handler http.Handler, apiKey string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(`{"query":"budget"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", apiKey)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestSearchRequiresScope(t *testing.T) {
+	t.Parallel()
+
+	reader, readerCfg, err := auth.GenerateKey(server.APIKeyConfig{
+		ID:        "reader",
+		Scopes:    []string{server.ScopeSearchRead},
+		RateLimit: 2,
+	})
+	require.NoError(t, err)
+	writer, writerCfg, err := auth.GenerateKey(server.APIKeyConfig{ID: "writer", Scopes: []string{server.ScopeJobsWrite}})
+	require.NoError(t, err)
+
+	cfg := &config.Config{Server: &server.Config{
+		Address:         "127.0.0.1:0",
+		SecurityEnabled: true,
+		APIKey:          "ops:secret",
+		APIKeys:         []server.APIKeyConfig{readerCfg, writerCfg},
+	}}
+	router, _ := api.SetupRouter(logger.NewNoOp(), searchManager{}, cfg)
+
+	assert.Equal(t, http.StatusUnauthorized, search(t, router, "reader:guess"))
+	assert.Equal(t, http.StatusForbidden, search(t, router, writer))
+	assert.Equal(t, http.StatusOK, search(t, router, "ops:secret"), "admin allows every scope")
+
+	// The reader key has its own limit of 2 requests per minute
+	assert.Equal(t, http.StatusOK, search(t, router, reader))
+	assert.Equal(t, http.StatusOK, search(t, router, reader))
+	assert.Equal(t, http.StatusTooManyRequests, search(t, router, reader))
+}
+
+func TestKeyRateLimitWindowIsFixed(t *testing.T) {
+	t.Parallel()
+
+	reader, readerCfg, err := auth.GenerateKey(server.APIKeyConfig{
+		ID:        "reader",
+		Scopes:    []string{server.ScopeSearchRead},
+		RateLimit: 2,
+	})
+	require.NoError(t, err)
+
+	cfg := &config.Config{Server: &server.Config{
+		Address:         "127.0.0.1:0",
+		SecurityEnabled: true,
+		APIKeys:         []server.APIKeyConfig{readerCfg},
+	}}
+	router, security := api.SetupRouter(logger.NewNoOp(), searchManager{}, cfg)
+	clock := &fakeClock{now: time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)}
+	security.(*middleware.SecurityMiddleware).SetTimeProvider(clock)
+
+	assert.Equal(t, http.StatusOK, search(t, router, reader))
+	clock.advance(30 * time.Second)
+	assert.Equal(t, http.StatusOK, search(t, router, reader))
+
+	// A client that keeps sending requests is still let through once the window is over
+	for range 3 {
+		clock.advance(9 * time.Second)
+		assert.Equal(t, http.StatusTooManyRequests, search(t, router, reader))
+	}
+	clock.advance(3 * time.Second)
+	assert.Equal(t, http.StatusOK, search(t, router, reader))
+}
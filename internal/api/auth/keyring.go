@@ -0,0 +1,227 @@
+// Package auth authenticates API requests against a set of hashed API keys,
+// loaded from the server configuration and an optional key file that is
+// reloaded when it changes, so keys can be rotated without a restart.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jonesrussell/gocrawl/internal/config/server"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// fileReloadDelay lets a burst of writes to the key file settle before it is reloaded.
+const fileReloadDelay = 200 * time.Millisecond
+
+// secretBytes is the number of random bytes of a generated key.
+const secretBytes = 32
+
+// defaultKeyID names the single configured API key when it has no ID.
+const defaultKeyID = "default"
+
+var (
+	// ErrMissingKey indicates a request without an API key
+	ErrMissingKey = errors.New("missing API key")
+	// ErrInvalidKey indicates an API key that matches no configured key
+	ErrInvalidKey = errors.New("invalid API key")
+	// ErrExpiredKey indicates an API key past its expiry date
+	ErrExpiredKey = errors.New("API key expired")
+)
+
+// KeyFile is the format of an API key file.
+type KeyFile struct {
+	// Keys are the API keys of the file
+	Keys []server.APIKeyConfig `yaml:"keys"`
+}
+
+// Keyring holds the accepted API keys. It is safe for concurrent use.
+type Keyring struct {
+	mu         sync.RWMutex
+	configured []server.APIKeyConfig
+	keys       []server.APIKeyConfig
+	file       string
+}
+
+// NewKeyring creates a keyring from the server configuration: the single API
+// key, which is allowed every scope, and the configured keys. Call Reload to
+// add the keys of the key file.
+func NewKeyring(cfg *server.Config) *Keyring {
+	var configured []server.APIKeyConfig
+	if cfg.APIKey != "" {
+		id, _, found := strings.Cut(cfg.APIKey, ":")
+		if !found || id == "" {
+			id = defaultKeyID
+		}
+		configured = append(configured, server.APIKeyConfig{
+			ID:     id,
+			Hash:   server.HashAPIKey(cfg.APIKey),
+			Scopes: []string{server.ScopeAdmin},
+		})
+	}
+	configured = append(configured, cfg.APIKeys...)
+
+	return &Keyring{configured: configured, keys: configured, file: cfg.APIKeysFile}
+}
+
+// Reload reads the key file and replaces the keys of the previous read. On
+// error the keys are left as they are.
+func (k *Keyring) Reload() error {
+	keys := append([]server.APIKeyConfig(nil), k.configured...)
+	if k.file != "" {
+		fileKeys, err := LoadKeyFile(k.file)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, fileKeys...)
+	}
+	if err := server.ValidateAPIKeys(keys); err != nil {
+		return fmt.Errorf("invalid API keys: %w", err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys
+	return nil
+}
+
+// Len returns the number of keys.
+func (k *Keyring) Len() int {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return len(k.keys)
+}
+
+// Authenticate returns the key matching a presented API key. Every key is
+// compared in constant time, so the response time does not tell which keys
+// exist.
+func (k *Keyring) Authenticate(presented string, now time.Time) (*server.APIKeyConfig, error) {
+	if presented == "" {
+		return nil, ErrMissingKey
+	}
+	hash := []byte(server.HashAPIKey(presented))
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	var match *server.APIKeyConfig
+	for i := range k.keys {
+		if subtle.ConstantTimeCompare(hash, []byte(k.keys[i].Hash)) == 1 {
+			match = &k.keys[i]
+		}
+	}
+	if match == nil {
+		return nil, ErrInvalidKey
+	}
+	if !match.ExpiresAt.IsZero() && !now.Before(match.ExpiresAt) {
+		return nil, fmt.Errorf("%w: %s", ErrExpiredKey, match.ID)
+	}
+	key := *match
+	return &key, nil
+}
+
+// Watch reloads the key file whenever it changes, until the context is done.
+// A file that fails to load leaves the keys as they are. Without a key file,
+// Watch does nothing.
+func (k *Keyring) Watch(ctx context.Context, log logger.Interface) error {
+	if k.file == "" {
+		return nil
+	}
+	path, err := filepath.Abs(k.file)
+	if err != nil {
+		return fmt.Errorf("failed to resolve API key file path: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	// Watch the directory: editors often replace the file rather than write to it
+	if addErr := watcher.Add(filepath.Dir(path)); addErr != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch API key file: %w", addErr)
+	}
+
+	// A file mounted from a Kubernetes ConfigMap or Secret is a symlink whose
+	// target is swapped rather than written to, so re-resolve it on every event
+	target, _ := filepath.EvalSymlinks(path)
+
+	go func() {
+		defer watcher.Close()
+
+		var reload <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				resolved, resolveErr := filepath.EvalSymlinks(path)
+				swapped := resolveErr == nil && resolved != target
+				if swapped {
+					target = resolved
+				}
+				written := (event.Name == path || event.Name == target) &&
+					event.Op&(fsnotify.Write|fsnotify.Create) != 0
+				if swapped || written {
+					reload = time.After(fileReloadDelay)
+				}
+			case watchErr, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error("Failed to watch API key file", "path", path, "error", watchErr)
+			case <-reload:
+				reload = nil
+				if reloadErr := k.Reload(); reloadErr != nil {
+					log.Error("Failed to reload API key file", "path", path, "error", reloadErr)
+					continue
+				}
+				log.Info("API keys reloaded", "path", path, "keys", k.Len())
+			}
+		}
+	}()
+
+	return nil
+}
+
+// LoadKeyFile reads the API keys of a key file.
+func LoadKeyFile(path string) ([]server.APIKeyConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API key file: %w", err)
+	}
+	var file KeyFile
+	if unmarshalErr := yaml.Unmarshal(data, &file); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse API key file %s: %w", path, unmarshalErr)
+	}
+	return file.Keys, nil
+}
+
+// GenerateKey returns a new random API key in id:key form, with the ID of
+// cfg, and cfg with the hash of the key set.
+func GenerateKey(cfg server.APIKeyConfig) (string, server.APIKeyConfig, error) {
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", server.APIKeyConfig{}, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := cfg.ID + ":" + base64.RawURLEncoding.EncodeToString(secret)
+	cfg.Hash = server.HashAPIKey(key)
+	if err := cfg.Validate(); err != nil {
+		return "", server.APIKeyConfig{}, err
+	}
+	return key, cfg, nil
+}
@@ -0,0 +1,143 @@
+package auth_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/api/auth"
+	"github.com/jonesrussell/gocrawl/internal/config/server"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// writeKeyFile writes an API key file holding keys.
+func writeKeyFile(t *testing.T, path string, keys ...server.APIKeyConfig) {
+	t.Helper()
+	data, err := yaml.Marshal(auth.KeyFile{Keys: keys})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestKeyringAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	reader, readerCfg, err := auth.GenerateKey(server.APIKeyConfig{ID: "reader", Scopes: []string{server.ScopeSearchRead}})
+	require.NoError(t, err)
+	expired, expiredCfg, err := auth.GenerateKey(server.APIKeyConfig{
+		ID:        "expired",
+		Scopes:    []string{server.ScopeSearchRead},
+		ExpiresAt: now.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, readerCfg.Hash, reader)
+
+	keyring := auth.NewKeyring(&server.Config{
+		SecurityEnabled: true,
+		APIKey:          "ops:secret",
+		APIKeys:         []server.APIKeyConfig{readerCfg, expiredCfg},
+	})
+	require.NoError(t, keyring.Reload())
+
+	key, err := keyring.Authenticate(reader, now)
+	require.NoError(t, err)
+	assert.Equal(t, "reader", key.ID)
+	assert.True(t, key.Allows(server.ScopeSearchRead))
+	assert.False(t, key.Allows(server.ScopeJobsWrite))
+
+	key, err = keyring.Authenticate("ops:secret", now)
+	require.NoError(t, err)
+	assert.Equal(t, "ops", key.ID)
+	assert.True(t, key.Allows(server.ScopeJobsWrite), "the single configured key is allowed every scope")
+
+	_, err = keyring.Authenticate(expired, now)
+	require.ErrorIs(t, err, auth.ErrExpiredKey)
+	_, err = keyring.Authenticate("reader:guess", now)
+	require.ErrorIs(t, err, auth.ErrInvalidKey)
+	_, err = keyring.Authenticate("", now)
+	require.ErrorIs(t, err, auth.ErrMissingKey)
+}
+
+func TestKeyringRotation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	oldKey, oldCfg, err := auth.GenerateKey(server.APIKeyConfig{ID: "old", Scopes: []string{server.ScopeSearchRead}})
+	require.NoError(t, err)
+	newKey, newCfg, err := auth.GenerateKey(server.APIKeyConfig{ID: "new", Scopes: []string{server.ScopeSearchRead}})
+	require.NoError(t, err)
+	writeKeyFile(t, path, oldCfg)
+
+	keyring := auth.NewKeyring(&server.Config{SecurityEnabled: true, APIKeysFile: path})
+	require.NoError(t, keyring.Reload())
+	_, err = keyring.Authenticate(oldKey, now)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	require.NoError(t, keyring.Watch(ctx, logger.NewNoOp()))
+
+	writeKeyFile(t, path, newCfg)
+	assert.Eventually(t, func() bool {
+		_, authErr := keyring.Authenticate(newKey, now)
+		return authErr == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	_, err = keyring.Authenticate(oldKey, now)
+	require.ErrorIs(t, err, auth.ErrInvalidKey)
+
+	// A broken file leaves the keys as they are
+	require.NoError(t, os.WriteFile(path, []byte("keys:\n  - id: new\n    hash: plain\n"), 0o600))
+	require.Error(t, keyring.Reload())
+	_, err = keyring.Authenticate(newKey, now)
+	require.NoError(t, err)
+}
+
+// mountKeyFile updates a key file laid out as Kubernetes mounts a Secret: the
+// file is a symlink through ..data, and ..data is swapped to a new directory.
+func mountKeyFile(t *testing.T, dir, version string, keys ...server.APIKeyConfig) {
+	t.Helper()
+	versionDir := filepath.Join(dir, version)
+	require.NoError(t, os.Mkdir(versionDir, 0o700))
+	writeKeyFile(t, filepath.Join(versionDir, "keys.yaml"), keys...)
+
+	tmpLink := filepath.Join(dir, "..data_tmp")
+	require.NoError(t, os.Symlink(version, tmpLink))
+	require.NoError(t, os.Rename(tmpLink, filepath.Join(dir, "..data")))
+}
+
+func TestKeyringWatchFollowsSymlinkSwap(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	dir := t.TempDir()
+	oldKey, oldCfg, err := auth.GenerateKey(server.APIKeyConfig{ID: "old", Scopes: []string{server.ScopeSearchRead}})
+	require.NoError(t, err)
+	newKey, newCfg, err := auth.GenerateKey(server.APIKeyConfig{ID: "new", Scopes: []string{server.ScopeSearchRead}})
+	require.NoError(t, err)
+
+	mountKeyFile(t, dir, "..2026_10_01", oldCfg)
+	path := filepath.Join(dir, "keys.yaml")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "keys.yaml"), path))
+
+	keyring := auth.NewKeyring(&server.Config{SecurityEnabled: true, APIKeysFile: path})
+	require.NoError(t, keyring.Reload())
+	_, err = keyring.Authenticate(oldKey, now)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	require.NoError(t, keyring.Watch(ctx, logger.NewNoOp()))
+
+	// Nothing is written to keys.yaml itself; only its target changes
+	mountKeyFile(t, dir, "..2026_10_02", newCfg)
+	assert.Eventually(t, func() bool {
+		_, authErr := keyring.Authenticate(newKey, now)
+		return authErr == nil
+	}, 5*time.Second, 20*time.Millisecond)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/gocrawl/internal/api/auth"
 	"github.com/jonesrussell/gocrawl/internal/config/server"
 	"github.com/jonesrussell/gocrawl/internal/logger"
 	"github.com/jonesrussell/gocrawl/internal/metrics"
@@ -24,6 +25,9 @@ type SecurityMiddlewareInterface interface {
 
 	// WaitCleanup waits for the cleanup goroutine to finish.
 	WaitCleanup()
+
+	// WatchKeys reloads the API key file whenever it changes.
+	WatchKeys(ctx context.Context) error
 }
 
 // TimeProvider is an interface for getting the current time
@@ -43,12 +47,23 @@ const (
 	DefaultRateLimitWindow = 5 * time.Second
 	// DefaultRateLimit is the default number of requests allowed per window
 	DefaultRateLimit = 2
+	// KeyRateLimitWindow is the window of the per-key rate limits
+	KeyRateLimitWindow = time.Minute
+)
+
+// Context keys set on authenticated requests
+const (
+	// KeyIDContextKey holds the ID of the API key of the request
+	KeyIDContextKey = "api_key_id"
+	// apiKeyContextKey holds the server.APIKeyConfig of the request
+	apiKeyContextKey = "api_key"
 )
 
 // SecurityMiddleware implements security measures for the API
 type SecurityMiddleware struct {
 	config          *server.Config
 	logger          logger.Interface
+	keyring         *auth.Keyring
 	rateLimiter     map[string]rateLimitInfo
 	mu              sync.RWMutex
 	timeProvider    TimeProvider
@@ -59,8 +74,9 @@ type SecurityMiddleware struct {
 
 // rateLimitInfo holds information about rate limiting for a client
 type rateLimitInfo struct {
-	count      int
-	lastAccess time.Time
+	count       int
+	windowStart time.Time
+	window      time.Duration
 }
 
 // Ensure SecurityMiddleware implements SecurityMiddlewareInterface
@@ -80,9 +96,18 @@ func NewSecurityMiddleware(cfg *server.Config, log logger.Interface) *SecurityMi
 		rateLimitWindow = 1 * time.Second
 	}
 
+	keyring := auth.NewKeyring(cfg)
+	if cfg.SecurityEnabled {
+		// A key file that fails to load leaves the configured keys in place
+		if err := keyring.Reload(); err != nil {
+			log.Error("Failed to load API keys", "error", err)
+		}
+	}
+
 	return &SecurityMiddleware{
 		config:          cfg,
 		logger:          log,
+		keyring:         keyring,
 		rateLimiter:     make(map[string]rateLimitInfo),
 		timeProvider:    &realTimeProvider{},
 		rateLimitWindow: rateLimitWindow,
@@ -113,37 +138,46 @@ func (m *SecurityMiddleware) SetMetrics(mtrcs *metrics.Metrics) {
 
 // checkRateLimit checks if the client has exceeded the rate limit
 func (m *SecurityMiddleware) checkRateLimit(clientIP string) bool {
+	return m.checkLimit(clientIP, m.maxRequests, m.rateLimitWindow)
+}
+
+// checkLimit checks if a client, identified by its IP or its API key, has
+// exceeded a limit of requests per window. Windows are fixed: the count
+// resets a full window after the first request of the window, however busy
+// the client kept.
+func (m *SecurityMiddleware) checkLimit(client string, limit int, window time.Duration) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	now := m.timeProvider.Now()
-	info, exists := m.rateLimiter[clientIP]
+	info, exists := m.rateLimiter[client]
 
 	if !exists {
-		m.rateLimiter[clientIP] = rateLimitInfo{
-			count:      1,
-			lastAccess: now,
+		m.rateLimiter[client] = rateLimitInfo{
+			count:       1,
+			windowStart: now,
+			window:      window,
 		}
 		return true
 	}
 
 	// Check if the window has expired
-	if now.Sub(info.lastAccess) > m.rateLimitWindow {
+	if now.Sub(info.windowStart) >= window {
 		info.count = 1
-		info.lastAccess = now
-		m.rateLimiter[clientIP] = info
+		info.windowStart = now
+		info.window = window
+		m.rateLimiter[client] = info
 		return true
 	}
 
 	// Check if the client has exceeded the limit
-	if info.count >= m.maxRequests {
+	if info.count >= limit {
 		return false
 	}
 
 	// Increment the count
 	info.count++
-	info.lastAccess = now
-	m.rateLimiter[clientIP] = info
+	m.rateLimiter[client] = info
 	return true
 }
 
@@ -175,33 +209,72 @@ func (m *SecurityMiddleware) handleCORS(c *gin.Context) {
 	}
 }
 
-// handleAPIKey checks if the API key is valid
+// handleAPIKey checks if the API key is valid and records it on the request
 func (m *SecurityMiddleware) handleAPIKey(c *gin.Context) error {
 	if !m.config.SecurityEnabled {
 		return nil
 	}
 
-	apiKey := c.GetHeader("X-API-Key")
-	if apiKey == "" {
-		return errors.New("missing API key")
-	}
-
-	if apiKey != m.config.APIKey {
-		return errors.New("invalid API key")
+	key, err := m.keyring.Authenticate(c.GetHeader("X-API-Key"), m.timeProvider.Now())
+	if err != nil {
+		return err
 	}
 
+	c.Set(KeyIDContextKey, key.ID)
+	c.Set(apiKeyContextKey, key)
 	return nil
 }
 
-// handleRateLimit checks if the request is within rate limits
+// requestKey returns the API key of an authenticated request, or nil
+func requestKey(c *gin.Context) *server.APIKeyConfig {
+	value, _ := c.Get(apiKeyContextKey)
+	key, _ := value.(*server.APIKeyConfig)
+	return key
+}
+
+// handleRateLimit checks if the request is within rate limits. Requests made
+// with an API key that has its own rate limit count against the key; others
+// against the client IP.
 func (m *SecurityMiddleware) handleRateLimit(c *gin.Context) error {
-	clientIP := c.ClientIP()
-	if !m.checkRateLimit(clientIP) {
+	var allowed bool
+	if key := requestKey(c); key != nil && key.RateLimit > 0 {
+		allowed = m.checkLimit("key:"+key.ID, key.RateLimit, KeyRateLimitWindow)
+	} else {
+		allowed = m.checkRateLimit(c.ClientIP())
+	}
+	if !allowed {
 		return errors.New("rate limit exceeded")
 	}
 	return nil
 }
 
+// RequireScope returns a middleware that rejects requests whose API key does
+// not have a scope. It runs after Middleware; with security disabled, every
+// request is allowed.
+func (m *SecurityMiddleware) RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.config.SecurityEnabled {
+			c.Next()
+			return
+		}
+
+		if key := requestKey(c); key == nil || !key.Allows(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key lacks scope " + scope})
+			return
+		}
+		c.Next()
+	}
+}
+
+// WatchKeys reloads the API key file whenever it changes, until the context
+// is done, so keys can be rotated without a restart
+func (m *SecurityMiddleware) WatchKeys(ctx context.Context) error {
+	if !m.config.SecurityEnabled {
+		return nil
+	}
+	return m.keyring.Watch(ctx, m.logger)
+}
+
 // Middleware returns the security middleware function
 func (m *SecurityMiddleware) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -236,13 +309,13 @@ func (m *SecurityMiddleware) Cleanup(ctx context.Context) {
 			m.logger.Info("Cleanup context cancelled, stopping cleanup routine")
 			return
 		case <-ticker.C:
-			expiryTime := m.timeProvider.Now().Add(-m.rateLimitWindow)
+			now := m.timeProvider.Now()
 
 			m.mu.Lock()
 			// Clean up old requests
-			for ip, info := range m.rateLimiter {
-				if info.lastAccess.Before(expiryTime) {
-					delete(m.rateLimiter, ip)
+			for client, info := range m.rateLimiter {
+				if !now.Before(info.windowStart.Add(info.window)) {
+					delete(m.rateLimiter, client)
 				}
 			}
 			m.mu.Unlock()
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/jonesrussell/gocrawl/internal/language"
+)
+
+// searchFields are the full-text fields queried by the search endpoint.
+var searchFields = []string{"title^2", "body", "content"}
+
+// geoFields are the geo_point fields a geo filter matches: the location of the
+// source's city and the locations of the places mentioned in the text.
+var geoFields = []string{"location", "place_locations"}
+
+// distancePattern matches Elasticsearch distances such as "25km" or "10.5mi".
+var distancePattern = regexp.MustCompile(`^\d+(\.\d+)?(mm|cm|m|km|in|ft|yd|mi|miles|nmi|NM)?$`)
+
+// BuildSearchQuery returns the Elasticsearch query of a search request. Geo
+// filters match documents whose source city or any mentioned place lies in the area.
+func BuildSearchQuery(req SearchRequest) (map[string]any, error) {
+	filters, err := geoFilters(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Group != "" {
+		filters = append(filters, map[string]any{
+			"term": map[string]any{"groups": req.Group},
+		})
+	}
+
+	query, err := language.MatchQuery(req.Query, req.Lang, searchFields...)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) > 0 {
+		query = map[string]any{
+			"bool": map[string]any{
+				"must":   query,
+				"filter": filters,
+			},
+		}
+	}
+
+	return map[string]any{
+		"query": query,
+		"size":  req.Size,
+	}, nil
+}
+
+// geoFilters returns the filter clauses of the geo_distance and geo_bounding_box
+// parameters of a request.
+func geoFilters(req SearchRequest) ([]any, error) {
+	var filters []any
+
+	if req.GeoDistance != nil {
+		center := domain.GeoPoint{Lat: req.GeoDistance.Lat, Lon: req.GeoDistance.Lon}
+		if err := center.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid geo_distance: %w", err)
+		}
+		if !distancePattern.MatchString(req.GeoDistance.Distance) {
+			return nil, fmt.Errorf("invalid geo_distance: distance %q must be a number with a unit, e.g. 25km",
+				req.GeoDistance.Distance)
+		}
+		filters = append(filters, anyGeoField(func(field string) map[string]any {
+			return map[string]any{
+				"geo_distance": map[string]any{
+					"distance":        req.GeoDistance.Distance,
+					"ignore_unmapped": true,
+					field:             center,
+				},
+			}
+		}))
+	}
+
+	if box := req.GeoBoundingBox; box != nil {
+		if err := box.TopLeft.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid geo_bounding_box top_left: %w", err)
+		}
+		if err := box.BottomRight.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid geo_bounding_box bottom_right: %w", err)
+		}
+		if box.TopLeft.Lat < box.BottomRight.Lat {
+			return nil, errors.New("invalid geo_bounding_box: top_left must be north of bottom_right")
+		}
+		filters = append(filters, anyGeoField(func(field string) map[string]any {
+			return map[string]any{
+				"geo_bounding_box": map[string]any{
+					"ignore_unmapped": true,
+					field: map[string]any{
+						"top_left":     box.TopLeft,
+						"bottom_right": box.BottomRight,
+					},
+				},
+			}
+		}))
+	}
+
+	return filters, nil
+}
+
+// anyGeoField returns a clause matching when the clause built for any geo field matches.
+func anyGeoField(clause func(field string) map[string]any) map[string]any {
+	should := make([]any, 0, len(geoFields))
+	for _, field := range geoFields {
+		should = append(should, clause(field))
+	}
+	return map[string]any{
+		"bool": map[string]any{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}
+}
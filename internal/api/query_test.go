@@ -0,0 +1,110 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/gocrawl/internal/api"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSearchQueryWithoutFilters(t *testing.T) {
+	t.Parallel()
+
+	query, err := api.BuildSearchQuery(api.SearchRequest{Query: "budget", Lang: "en", Size: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 5, query["size"])
+
+	clause, ok := query["query"].(map[string]any)
+	require.True(t, ok)
+	assert.Contains(t, clause, "multi_match")
+}
+
+func TestBuildSearchQueryWithGeoFilters(t *testing.T) {
+	t.Parallel()
+
+	query, err := api.BuildSearchQuery(api.SearchRequest{
+		Query:       "wildfire",
+		Lang:        "en",
+		GeoDistance: &api.GeoDistanceFilter{Lat: 46.49, Lon: -80.99, Distance: "50km"},
+		GeoBoundingBox: &api.GeoBoundingBoxFilter{
+			TopLeft:     domain.GeoPoint{Lat: 50, Lon: -90},
+			BottomRight: domain.GeoPoint{Lat: 45, Lon: -78},
+		},
+	})
+	require.NoError(t, err)
+
+	boolQuery := query["query"].(map[string]any)["bool"].(map[string]any)
+	assert.Contains(t, boolQuery["must"], "multi_match")
+
+	filters := boolQuery["filter"].([]any)
+	require.Len(t, filters, 2)
+	distance := filters[0].(map[string]any)["bool"].(map[string]any)["should"].([]any)
+	assert.Equal(t, map[string]any{
+		"geo_distance": map[string]any{
+			"distance":        "50km",
+			"ignore_unmapped": true,
+			"location":        domain.GeoPoint{Lat: 46.49, Lon: -80.99},
+		},
+	}, distance[0])
+	assert.Contains(t, distance[1].(map[string]any)["geo_distance"], "place_locations")
+
+	// Indices without one of the geo fields, e.g. older article indices, must not fail the search
+	box := filters[1].(map[string]any)["bool"].(map[string]any)["should"].([]any)
+	require.Len(t, box, 2)
+	for i, field := range []string{"location", "place_locations"} {
+		distanceClause := distance[i].(map[string]any)["geo_distance"].(map[string]any)
+		assert.Equal(t, true, distanceClause["ignore_unmapped"])
+
+		boxClause := box[i].(map[string]any)["geo_bounding_box"].(map[string]any)
+		assert.Equal(t, true, boxClause["ignore_unmapped"])
+		assert.Contains(t, boxClause, field)
+	}
+}
+
+func TestBuildSearchQueryRejectsInvalidFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]api.SearchRequest{
+		"latitude out of range": {
+			Query:       "fire",
+			GeoDistance: &api.GeoDistanceFilter{Lat: 91, Lon: 0, Distance: "10km"},
+		},
+		"distance without number": {
+			Query:       "fire",
+			GeoDistance: &api.GeoDistanceFilter{Lat: 46, Lon: -81, Distance: "near"},
+		},
+		"unsupported language": {
+			Query: "fire",
+			Lang:  "de",
+		},
+		"inverted box": {
+			Query: "fire",
+			GeoBoundingBox: &api.GeoBoundingBoxFilter{
+				TopLeft:     domain.GeoPoint{Lat: 45, Lon: -90},
+				BottomRight: domain.GeoPoint{Lat: 50, Lon: -78},
+			},
+		},
+	}
+
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := api.BuildSearchQuery(req)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestBuildSearchQueryWithGroup(t *testing.T) {
+	t.Parallel()
+
+	query, err := api.BuildSearchQuery(api.SearchRequest{Query: "council", Lang: "en", Group: "ontario-news"})
+	require.NoError(t, err)
+
+	boolQuery := query["query"].(map[string]any)["bool"].(map[string]any)
+	assert.Equal(t, []any{
+		map[string]any{"term": map[string]any{"groups": "ontario-news"}},
+	}, boolQuery["filter"])
+}
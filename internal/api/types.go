@@ -1,11 +1,35 @@
 // Package api implements the HTTP API for the search service.
 package api
 
+import "github.com/jonesrussell/gocrawl/internal/domain"
+
 // SearchRequest represents the structure of the search request
 type SearchRequest struct {
 	Query string `json:"query"`
 	Index string `json:"index"`
 	Size  int    `json:"size"`
+	// Lang selects the language analyzers to search with, e.g. "en" or "fr".
+	// When empty the language of the query is detected.
+	Lang string `json:"lang"`
+	// Group restricts results to the documents of a source group
+	Group string `json:"group,omitempty"`
+	// GeoDistance restricts results to documents within a distance of a point
+	GeoDistance *GeoDistanceFilter `json:"geo_distance,omitempty"`
+	// GeoBoundingBox restricts results to documents inside a box
+	GeoBoundingBox *GeoBoundingBoxFilter `json:"geo_bounding_box,omitempty"`
+}
+
+// GeoDistanceFilter is a point and the distance around it, e.g. "25km".
+type GeoDistanceFilter struct {
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	Distance string  `json:"distance"`
+}
+
+// GeoBoundingBoxFilter is a box given by its north-west and south-east corners.
+type GeoBoundingBoxFilter struct {
+	TopLeft     domain.GeoPoint `json:"top_left"`
+	BottomRight domain.GeoPoint `json:"bottom_right"`
 }
 
 // SearchResponse represents the structure of the search response
@@ -0,0 +1,177 @@
+// Package dateparse turns the dates found on news pages into times. Besides
+// machine-readable formats it understands relative expressions such as
+// "3 hours ago" or "hier à 14h" (anchored on the fetch time), AP-style
+// abbreviations such as "Oct. 5, 2026 at 4:15 p.m. EDT", named timezones and
+// month names in English, French, Spanish, German, Portuguese, Italian and Dutch.
+package dateparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	// Embed the timezone database so per-source timezones work in minimal containers
+	_ "time/tzdata"
+)
+
+// DefaultLocale is used when a source does not configure a locale.
+const DefaultLocale = "en"
+
+// layouts are the machine-readable formats tried before free-text parsing.
+// Layouts without a zone are interpreted in the parser's timezone.
+var layouts = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05.000Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC1123,
+	time.RFC1123Z,
+	time.ANSIC,
+	time.UnixDate,
+	time.RubyDate,
+	time.RFC822,
+	time.RFC822Z,
+	time.RFC850,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"02 Jan 2006 15:04:05 MST",
+}
+
+var (
+	unixPattern = regexp.MustCompile(`^\d{10}(\d{3})?$`)
+	meridiemDot = regexp.MustCompile(`\b([ap])\.\s?m\.?`)
+	noon        = regexp.MustCompile(`\bnoon\b`)
+	midnight    = regexp.MustCompile(`\bmidnight\b`)
+)
+
+// Parser parses dates for one source. The zero value and a nil *Parser parse
+// English dates in UTC relative to the current time.
+type Parser struct {
+	locale   string
+	location *time.Location
+	anchor   time.Time
+}
+
+// New creates a parser for a locale (e.g. "en-US", "fr-CA") and an IANA
+// timezone (e.g. "America/Toronto"). Empty values select DefaultLocale and UTC.
+func New(locale, timezone string) (*Parser, error) {
+	location := time.UTC
+	if timezone != "" {
+		loaded, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+		location = loaded
+	}
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	return &Parser{locale: strings.ToLower(locale), location: location}, nil
+}
+
+// At returns a copy of the parser that resolves relative expressions against
+// the given time, normally the time the page was fetched.
+func (p *Parser) At(anchor time.Time) *Parser {
+	c := Parser{}
+	if p != nil {
+		c = *p
+	}
+	c.anchor = anchor
+	return &c
+}
+
+// Parse parses a date. It returns the zero time if the value is not a date.
+func Parse(value string) time.Time {
+	var p *Parser
+	return p.Parse(value)
+}
+
+// Parse parses a date. It returns the zero time if the value is not a date.
+func (p *Parser) Parse(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+
+	location := p.loc()
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t
+		}
+	}
+
+	if unixPattern.MatchString(value) {
+		if seconds, err := strconv.ParseInt(value[:10], 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+	}
+
+	text := normalize(value)
+	if t, ok := p.parseRelative(text); ok {
+		return t
+	}
+	if t, ok := p.parseAbsolute(text); ok {
+		return t
+	}
+	return time.Time{}
+}
+
+// loc returns the parser's timezone.
+func (p *Parser) loc() *time.Location {
+	if p == nil || p.location == nil {
+		return time.UTC
+	}
+	return p.location
+}
+
+// now returns the anchor for relative expressions in the parser's timezone.
+func (p *Parser) now() time.Time {
+	if p == nil || p.anchor.IsZero() {
+		return time.Now().In(p.loc())
+	}
+	return p.anchor.In(p.loc())
+}
+
+// dayFirst reports whether ambiguous numeric dates such as 05/10/2026 put the day first.
+func (p *Parser) dayFirst() bool {
+	locale := DefaultLocale
+	if p != nil && p.locale != "" {
+		locale = p.locale
+	}
+	locale = strings.ReplaceAll(locale, "_", "-")
+	if !strings.HasPrefix(locale, "en") {
+		return true
+	}
+	switch locale {
+	case "en-gb", "en-au", "en-nz", "en-ie", "en-in", "en-za":
+		return true
+	default:
+		return false
+	}
+}
+
+// normalize lowercases a value and rewrites notation variants to a single form.
+func normalize(value string) string {
+	text := strings.ToLower(value)
+	text = meridiemDot.ReplaceAllString(text, "${1}m")
+	text = noon.ReplaceAllString(text, "12:00 pm")
+	text = midnight.ReplaceAllString(text, "12:00 am")
+	text = strings.NewReplacer(
+		"\u00a0", " ",
+		"’", "'",
+		"–", "-",
+		"—", "-",
+		"avant-hier", "avanthier",
+		"l'altro ieri", "altroieri",
+	).Replace(text)
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,111 @@
+package dateparse_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/common/dateparse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fetchedAt is the anchor for relative expressions: Wednesday 14 October 2026, 18:00 UTC.
+var fetchedAt = time.Date(2026, time.October, 14, 18, 0, 0, 0, time.UTC)
+
+func TestParseRelative(t *testing.T) {
+	t.Parallel()
+
+	p, err := dateparse.New("", "")
+	require.NoError(t, err)
+	p = p.At(fetchedAt)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"3 hours ago", fetchedAt.Add(-3 * time.Hour)},
+		{"Updated 45 mins ago", fetchedAt.Add(-45 * time.Minute)},
+		{"an hour ago", fetchedAt.Add(-time.Hour)},
+		{"2d ago", fetchedAt.AddDate(0, 0, -2)},
+		{"1 month ago", fetchedAt.AddDate(0, -1, 0)},
+		{"just now", fetchedAt},
+		{"Just now.", fetchedAt},
+		{"À l'instant", fetchedAt},
+		{"il y a 2 jours", fetchedAt.AddDate(0, 0, -2)},
+		{"hace 5 minutos", fetchedAt.Add(-5 * time.Minute)},
+		{"vor 3 Stunden", fetchedAt.Add(-3 * time.Hour)},
+		{"há 1 semana", fetchedAt.AddDate(0, 0, -7)},
+		{"2 giorni fa", fetchedAt.AddDate(0, 0, -2)},
+		{"yesterday at 4:15 p.m.", time.Date(2026, time.October, 13, 16, 15, 0, 0, time.UTC)},
+		{"hier à 14h", time.Date(2026, time.October, 13, 14, 0, 0, 0, time.UTC)},
+		{"avant-hier à 9h30", time.Date(2026, time.October, 12, 9, 30, 0, 0, time.UTC)},
+		{"Today", time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			assert.True(t, tt.want.Equal(p.Parse(tt.input)), "got %s", p.Parse(tt.input))
+		})
+	}
+}
+
+func TestParseAbsolute(t *testing.T) {
+	t.Parallel()
+
+	toronto, err := time.LoadLocation("America/Toronto")
+	require.NoError(t, err)
+
+	p, err := dateparse.New("en-US", "America/Toronto")
+	require.NoError(t, err)
+	p = p.At(fetchedAt)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2026-10-05T14:00:00Z", time.Date(2026, time.October, 5, 14, 0, 0, 0, time.UTC)},
+		{"2026-10-05 14:00", time.Date(2026, time.October, 5, 14, 0, 0, 0, toronto)},
+		{"Updated Oct. 5, 2026 at 4:15 p.m. EDT", time.Date(2026, time.October, 5, 20, 15, 0, 0, time.UTC)},
+		{"Sept. 30, 2026 10 a.m. PT", time.Date(2026, time.September, 30, 17, 0, 0, 0, time.UTC)},
+		{"Monday, October 5th, 2026", time.Date(2026, time.October, 5, 0, 0, 0, 0, toronto)},
+		{"5 de octubre de 2026", time.Date(2026, time.October, 5, 0, 0, 0, 0, toronto)},
+		{"mar., 6 de octubre de 2026 12:30", time.Date(2026, time.October, 6, 12, 30, 0, 0, toronto)},
+		{"le 1er octobre 2026 à 8 h 05", time.Date(2026, time.October, 1, 8, 5, 0, 0, toronto)},
+		{"5. Oktober 2026, 16.15 Uhr", time.Date(2026, time.October, 5, 16, 15, 0, 0, toronto)},
+		{"10/05/2026", time.Date(2026, time.October, 5, 0, 0, 0, 0, toronto)},
+		{"Oct 3 (UTC+2)", time.Date(2026, time.October, 2, 22, 0, 0, 0, time.UTC)},
+		{"Dec. 30", time.Date(2025, time.December, 30, 0, 0, 0, 0, toronto)},
+		{"1791995400", time.Date(2026, time.October, 14, 16, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			assert.True(t, tt.want.Equal(p.Parse(tt.input)), "got %s", p.Parse(tt.input))
+		})
+	}
+}
+
+func TestParseLocaleOrder(t *testing.T) {
+	t.Parallel()
+
+	p, err := dateparse.New("fr-CA", "")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.May, 10, 0, 0, 0, 0, time.UTC), p.Parse("10/05/2026"))
+	assert.Equal(t, time.Date(2026, time.October, 31, 0, 0, 0, 0, time.UTC), p.Parse("10/31/2026"))
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"", "By Jane Reporter", "5 min read", "February 30, 2026", "Posted in May",
+		"Agoraphobia explained", "Watch the council meeting right now",
+	} {
+		assert.True(t, dateparse.Parse(input).IsZero(), input)
+	}
+
+	_, err := dateparse.New("en", "Mars/Olympus_Mons")
+	assert.Error(t, err)
+}
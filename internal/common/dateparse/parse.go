@@ -0,0 +1,322 @@
+package dateparse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// amount matches the number in a relative expression; articles stand for 1.
+	amount = `(\d+|an|a|one|une|un|una|uno|einem|einer|eine|ein|uma|um|een)`
+	// unitWord matches the unit in a relative expression.
+	unitWord = `([\p{L}]+)\.?`
+	// maxFutureSkew is how far in the future a date without a year may be before it is moved back a year.
+	maxFutureSkew = 48 * time.Hour
+	// maxClockSkew is how far in the future a bare clock time may be before it is moved back a day.
+	maxClockSkew = time.Hour
+	minYear      = 1900
+	maxYear      = 2100
+	hoursPerDay  = 24
+	halfDayHours = 12
+)
+
+var (
+	agoSuffix = regexp.MustCompile(`(?:^|\s)` + amount + `\s*` + unitWord + `\s+(?:ago|fa|geleden)(?:\s|$)`)
+	agoPrefix = regexp.MustCompile(`(?:^|\s)(?:il y a|hace|vor|há|ha)\s+` + amount + `\s*` + unitWord)
+
+	clockUhr      = regexp.MustCompile(`(\d{1,2})(?:[.:](\d{2}))?\s*uhr`)
+	clockColon    = regexp.MustCompile(`(\d{1,2}):(\d{2})(?::(\d{2}))?(?:\s*(am|pm))?`)
+	clockH        = regexp.MustCompile(`(\d{1,2})\s?h\s?(\d{2})?(?:\s|$)`)
+	clockMeridiem = regexp.MustCompile(`(\d{1,2})\s*(am|pm)(?:\s|$)`)
+
+	numericDate = regexp.MustCompile(`(\d{1,4})([/.\-])(\d{1,2})[/.\-](\d{2,4})`)
+	utcOffset   = regexp.MustCompile(`(?:utc|gmt)\s*([+-])(\d{1,2})(?::?(\d{2}))?`)
+
+	ordinalSuffixes = map[string]bool{
+		"st": true, "nd": true, "rd": true, "th": true, "er": true, "re": true, "e": true,
+		"º": true, "ª": true, "o": true, "a": true,
+	}
+)
+
+// clock is a time of day found in a value.
+type clock struct {
+	hour, minute, second int
+}
+
+// parseRelative parses expressions relative to the anchor.
+func (p *Parser) parseRelative(text string) (time.Time, bool) {
+	now := p.now()
+
+	if justNow[strings.Join(tokenize(text), " ")] {
+		return now, true
+	}
+
+	for _, pattern := range []*regexp.Regexp{agoSuffix, agoPrefix} {
+		if match := pattern.FindStringSubmatch(text); match != nil {
+			if t, ok := subtract(now, match[1], match[2]); ok {
+				return t, true
+			}
+		}
+	}
+
+	for _, token := range tokenize(text) {
+		days, ok := dayOffsets[token]
+		if !ok {
+			continue
+		}
+		day := now.AddDate(0, 0, -days)
+		c, _, _ := findClock(text)
+		return time.Date(day.Year(), day.Month(), day.Day(), c.hour, c.minute, c.second, 0, now.Location()), true
+	}
+
+	return time.Time{}, false
+}
+
+// subtract moves back from the anchor by an amount of units.
+func subtract(now time.Time, amountText, unitText string) (time.Time, bool) {
+	u, ok := units[unitText]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	n := 1
+	if !articles[amountText] {
+		parsed, err := strconv.Atoi(amountText)
+		if err != nil {
+			return time.Time{}, false
+		}
+		n = parsed
+	}
+
+	t := now.AddDate(-n*u.years, -n*u.months, -n*u.days)
+	return t.Add(-time.Duration(n) * u.duration), true
+}
+
+// parseAbsolute parses dates written out with month names or numbers.
+func (p *Parser) parseAbsolute(text string) (time.Time, bool) {
+	now := p.now()
+
+	location, text := p.findZone(text)
+	c, hasClock, text := findClock(text)
+
+	year, month, day, found := p.findDate(text)
+	if !found {
+		if !hasClock {
+			return time.Time{}, false
+		}
+		// A bare time of day refers to the most recent such time
+		local := now.In(location)
+		t := time.Date(local.Year(), local.Month(), local.Day(), c.hour, c.minute, c.second, 0, location)
+		if t.Sub(now) > maxClockSkew {
+			t = t.AddDate(0, 0, -1)
+		}
+		return t, true
+	}
+
+	guessYear := year == 0
+	if guessYear {
+		year = now.In(location).Year()
+	}
+
+	t := time.Date(year, month, day, c.hour, c.minute, c.second, 0, location)
+	if t.Day() != day {
+		// Rejects dates such as February 30
+		return time.Time{}, false
+	}
+	if guessYear && t.Sub(now) > maxFutureSkew {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return t, true
+}
+
+// findZone removes a timezone from the text and returns its location,
+// falling back to the parser's timezone.
+func (p *Parser) findZone(text string) (*time.Location, string) {
+	if match := utcOffset.FindStringSubmatchIndex(text); match != nil {
+		sign := text[match[2]:match[3]]
+		hours, _ := strconv.Atoi(text[match[4]:match[5]])
+		minutes := 0
+		if match[6] >= 0 {
+			minutes, _ = strconv.Atoi(text[match[6]:match[7]])
+		}
+		offset := hours*3600 + minutes*60
+		if sign == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(strings.ToUpper(strings.TrimSpace(text[match[0]:match[1]])), offset),
+			text[:match[0]] + " " + text[match[1]:]
+	}
+
+	tokens := strings.Fields(text)
+	if len(tokens) == 0 {
+		return p.loc(), text
+	}
+
+	// Zone names follow the time, so only the last word is considered
+	last := strings.Trim(tokens[len(tokens)-1], "().,")
+	if offset, ok := zoneOffsets[last]; ok {
+		return time.FixedZone(strings.ToUpper(last), offset), strings.Join(tokens[:len(tokens)-1], " ")
+	}
+	if name, ok := zoneLocations[last]; ok {
+		if location, err := time.LoadLocation(name); err == nil {
+			return location, strings.Join(tokens[:len(tokens)-1], " ")
+		}
+	}
+	return p.loc(), text
+}
+
+// findClock finds a time of day and returns the text without it.
+func findClock(text string) (clock, bool, string) {
+	for _, pattern := range []*regexp.Regexp{clockUhr, clockColon, clockH, clockMeridiem} {
+		match := pattern.FindStringSubmatchIndex(text)
+		if match == nil {
+			continue
+		}
+
+		groups := make([]string, len(match)/2)
+		for i := range groups {
+			if match[2*i] >= 0 {
+				groups[i] = text[match[2*i]:match[2*i+1]]
+			}
+		}
+
+		c, ok := clockFromGroups(pattern, groups)
+		if !ok {
+			continue
+		}
+		return c, true, text[:match[0]] + " " + text[match[1]:]
+	}
+	return clock{}, false, text
+}
+
+// clockFromGroups converts the submatches of a clock pattern.
+func clockFromGroups(pattern *regexp.Regexp, groups []string) (clock, bool) {
+	var c clock
+	var meridiem string
+	c.hour, _ = strconv.Atoi(groups[1])
+	hour := c.hour
+
+	switch pattern {
+	case clockUhr, clockH:
+		c.minute, _ = strconv.Atoi(groups[2])
+	case clockColon:
+		c.minute, _ = strconv.Atoi(groups[2])
+		c.second, _ = strconv.Atoi(groups[3])
+		meridiem = groups[4]
+	case clockMeridiem:
+		meridiem = groups[2]
+	}
+
+	switch meridiem {
+	case "am":
+		if c.hour == halfDayHours {
+			c.hour = 0
+		}
+	case "pm":
+		if c.hour < halfDayHours {
+			c.hour += halfDayHours
+		}
+	}
+
+	if meridiem != "" && (hour == 0 || hour > halfDayHours) {
+		return clock{}, false
+	}
+	if c.hour >= hoursPerDay || c.minute >= 60 || c.second >= 60 {
+		return clock{}, false
+	}
+	return c, true
+}
+
+// findDate finds a calendar date. The year is 0 when the text has none.
+func (p *Parser) findDate(text string) (int, time.Month, int, bool) {
+	if match := numericDate.FindStringSubmatch(text); match != nil {
+		if year, month, day, ok := p.numericDate(match[1], match[2], match[3], match[4]); ok {
+			return year, month, day, true
+		}
+	}
+
+	var (
+		month      time.Month
+		monthIsAbb bool
+		year, day  int
+	)
+	for _, token := range tokenize(text) {
+		if m, ok := months[token]; ok && (month == 0 || monthIsAbb) {
+			month, monthIsAbb = m, false
+			continue
+		}
+		if m, ok := monthAbbreviations[token]; ok && month == 0 {
+			month, monthIsAbb = m, true
+			continue
+		}
+
+		n, ok := number(token)
+		switch {
+		case !ok:
+		case n >= minYear && n <= maxYear && year == 0:
+			year = n
+		case n >= 1 && n <= 31 && day == 0:
+			day = n
+		}
+	}
+
+	if month == 0 || day == 0 {
+		return 0, 0, 0, false
+	}
+	return year, month, day, true
+}
+
+// numericDate interprets a date written with numbers only.
+func (p *Parser) numericDate(first, separator, second, third string) (int, time.Month, int, bool) {
+	a, _ := strconv.Atoi(first)
+	b, _ := strconv.Atoi(second)
+	c, _ := strconv.Atoi(third)
+
+	var year, month, day int
+	switch {
+	case len(first) == 4:
+		year, month, day = a, b, c
+	case separator == "." || p.dayFirst() || a > 12:
+		day, month, year = a, b, c
+	default:
+		month, day, year = a, b, c
+	}
+	if month > 12 && day <= 12 {
+		// e.g. 10/31/2026 in a day-first locale
+		day, month = month, day
+	}
+
+	if len(third) == 2 && len(first) != 4 {
+		year += 2000
+	}
+	if month < 1 || month > 12 || day < 1 || day > 31 || year < minYear || year > maxYear {
+		return 0, 0, 0, false
+	}
+	return year, time.Month(month), day, true
+}
+
+// number parses a day or year token, accepting ordinals such as 5th or 1er.
+func number(token string) (int, bool) {
+	end := strings.IndexFunc(token, func(r rune) bool { return !unicode.IsDigit(r) })
+	if end == 0 {
+		return 0, false
+	}
+	if end > 0 && !ordinalSuffixes[token[end:]] {
+		return 0, false
+	}
+	if end < 0 {
+		end = len(token)
+	}
+	n, err := strconv.Atoi(token[:end])
+	return n, err == nil
+}
+
+// tokenize splits text into words and numbers.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+}
@@ -0,0 +1,147 @@
+package dateparse
+
+import "time"
+
+// months maps full month names in the supported languages to months.
+var months = map[string]time.Month{
+	// English
+	"january": time.January, "february": time.February, "march": time.March, "april": time.April,
+	"may": time.May, "june": time.June, "july": time.July, "august": time.August,
+	"september": time.September, "october": time.October, "november": time.November, "december": time.December,
+	// French
+	"janvier": time.January, "février": time.February, "fevrier": time.February, "mars": time.March,
+	"avril": time.April, "mai": time.May, "juin": time.June, "juillet": time.July, "août": time.August,
+	"aout": time.August, "septembre": time.September, "octobre": time.October, "novembre": time.November,
+	"décembre": time.December, "decembre": time.December,
+	// Spanish
+	"enero": time.January, "febrero": time.February, "marzo": time.March, "abril": time.April,
+	"mayo": time.May, "junio": time.June, "julio": time.July, "agosto": time.August,
+	"septiembre": time.September, "setiembre": time.September, "octubre": time.October,
+	"noviembre": time.November, "diciembre": time.December,
+	// German
+	"januar": time.January, "jänner": time.January, "februar": time.February, "märz": time.March,
+	"maerz": time.March, "juni": time.June, "juli": time.July, "oktober": time.October, "dezember": time.December,
+	// Portuguese
+	"janeiro": time.January, "fevereiro": time.February, "março": time.March, "marco": time.March,
+	"maio": time.May, "junho": time.June, "julho": time.July, "setembro": time.September,
+	"outubro": time.October, "novembro": time.November, "dezembro": time.December,
+	// Italian
+	"gennaio": time.January, "febbraio": time.February, "aprile": time.April, "maggio": time.May,
+	"giugno": time.June, "luglio": time.July, "settembre": time.September, "ottobre": time.October,
+	"dicembre": time.December,
+	// Dutch
+	"januari": time.January, "februari": time.February, "maart": time.March, "mei": time.May,
+	"augustus": time.August,
+}
+
+// monthAbbreviations maps month abbreviations, including AP style ("Sept."), to months.
+// Full names take precedence because some abbreviations are also weekday abbreviations.
+var monthAbbreviations = map[string]time.Month{
+	"jan": time.January, "janv": time.January, "ene": time.January, "gen": time.January,
+	"feb": time.February, "févr": time.February, "fevr": time.February, "fév": time.February,
+	"fev": time.February, "febr": time.February,
+	"mar": time.March, "mär": time.March, "mrt": time.March,
+	"apr": time.April, "avr": time.April, "abr": time.April,
+	"jun": time.June, "giu": time.June,
+	"jul": time.July, "juil": time.July, "lug": time.July,
+	"aug": time.August, "ago": time.August,
+	"sep": time.September, "sept": time.September, "set": time.September,
+	"oct": time.October, "okt": time.October, "out": time.October, "ott": time.October,
+	"nov": time.November,
+	"dec": time.December, "déc": time.December, "dez": time.December, "dic": time.December,
+	"mag": time.May,
+}
+
+// unit is a calendar step used by relative expressions.
+type unit struct {
+	years, months, days int
+	duration            time.Duration
+}
+
+var (
+	unitSecond = unit{duration: time.Second}
+	unitMinute = unit{duration: time.Minute}
+	unitHour   = unit{duration: time.Hour}
+	unitDay    = unit{days: 1}
+	unitWeek   = unit{days: 7}
+	unitMonth  = unit{months: 1}
+	unitYear   = unit{years: 1}
+)
+
+// units maps unit words and abbreviations in the supported languages to units.
+var units = map[string]unit{
+	// seconds
+	"s": unitSecond, "sec": unitSecond, "secs": unitSecond, "second": unitSecond, "seconds": unitSecond,
+	"seconde": unitSecond, "secondes": unitSecond, "segundo": unitSecond, "segundos": unitSecond,
+	"sekunde": unitSecond, "sekunden": unitSecond, "secondo": unitSecond, "secondi": unitSecond,
+	"seconden": unitSecond,
+	// minutes
+	"m": unitMinute, "min": unitMinute, "mins": unitMinute, "minute": unitMinute, "minutes": unitMinute,
+	"minuto": unitMinute, "minutos": unitMinute, "minuten": unitMinute, "minuti": unitMinute,
+	// hours
+	"h": unitHour, "hr": unitHour, "hrs": unitHour, "hour": unitHour, "hours": unitHour,
+	"heure": unitHour, "heures": unitHour, "hora": unitHour, "horas": unitHour, "stunde": unitHour,
+	"stunden": unitHour, "std": unitHour, "ora": unitHour, "ore": unitHour, "uur": unitHour, "uren": unitHour,
+	// days
+	"d": unitDay, "day": unitDay, "days": unitDay, "jour": unitDay, "jours": unitDay, "día": unitDay,
+	"días": unitDay, "dia": unitDay, "dias": unitDay, "tag": unitDay, "tagen": unitDay, "tage": unitDay,
+	"giorno": unitDay, "giorni": unitDay, "dag": unitDay, "dagen": unitDay,
+	// weeks
+	"w": unitWeek, "wk": unitWeek, "wks": unitWeek, "week": unitWeek, "weeks": unitWeek,
+	"semaine": unitWeek, "semaines": unitWeek, "semana": unitWeek, "semanas": unitWeek,
+	"woche": unitWeek, "wochen": unitWeek, "settimana": unitWeek, "settimane": unitWeek, "weken": unitWeek,
+	// months
+	"mo": unitMonth, "mos": unitMonth, "month": unitMonth, "months": unitMonth, "mois": unitMonth,
+	"mes": unitMonth, "meses": unitMonth, "monat": unitMonth, "monate": unitMonth, "monaten": unitMonth,
+	"mese": unitMonth, "mesi": unitMonth, "maand": unitMonth, "maanden": unitMonth,
+	// years
+	"y": unitYear, "yr": unitYear, "yrs": unitYear, "year": unitYear, "years": unitYear, "an": unitYear,
+	"ans": unitYear, "année": unitYear, "années": unitYear, "año": unitYear, "años": unitYear,
+	"ano": unitYear, "anos": unitYear, "jahr": unitYear, "jahre": unitYear, "jahren": unitYear,
+	"anno": unitYear, "anni": unitYear, "jaar": unitYear, "jaren": unitYear,
+}
+
+// articles are the words for "a"/"one" that stand in for the amount 1.
+var articles = map[string]bool{
+	"a": true, "an": true, "one": true, "un": true, "une": true, "una": true, "uno": true,
+	"ein": true, "eine": true, "einem": true, "einer": true, "um": true, "uma": true, "een": true,
+}
+
+// dayOffsets maps words such as "yesterday" to a number of days before the anchor.
+var dayOffsets = map[string]int{
+	"today": 0, "aujourd'hui": 0, "hoy": 0, "heute": 0, "hoje": 0, "oggi": 0, "vandaag": 0,
+	"yesterday": 1, "hier": 1, "ayer": 1, "gestern": 1, "ontem": 1, "ieri": 1, "gisteren": 1,
+	"avanthier": 2, "anteayer": 2, "vorgestern": 2, "anteontem": 2, "eergisteren": 2, "altroieri": 2,
+}
+
+// justNow are expressions meaning "at the anchor time". They only match a whole value.
+var justNow = map[string]bool{
+	"just now": true, "moments ago": true, "a moment ago": true, "right now": true,
+	"à l'instant": true, "a l'instant": true, "ahora mismo": true, "hace un momento": true,
+	"gerade eben": true, "soeben": true, "agora mesmo": true, "agora": true,
+	"poco fa": true, "adesso": true, "zojuist": true, "net nu": true,
+}
+
+// zoneOffsets maps timezone abbreviations to UTC offsets in seconds.
+var zoneOffsets = map[string]int{
+	"utc": 0, "gmt": 0, "z": 0, "wet": 0,
+	"est": -5 * 3600, "edt": -4 * 3600,
+	"cst": -6 * 3600, "cdt": -5 * 3600,
+	"mst": -7 * 3600, "mdt": -6 * 3600,
+	"pst": -8 * 3600, "pdt": -7 * 3600,
+	"ast": -4 * 3600, "adt": -3 * 3600,
+	"nst": -(3*3600 + 1800), "ndt": -(2*3600 + 1800),
+	"akst": -9 * 3600, "akdt": -8 * 3600,
+	"hst": -10 * 3600,
+	"bst": 1 * 3600, "west": 1 * 3600, "cet": 1 * 3600, "cest": 2 * 3600,
+	"eet": 2 * 3600, "eest": 3 * 3600,
+	"aest": 10 * 3600, "aedt": 11 * 3600,
+}
+
+// zoneLocations maps generic timezone names, whose offset depends on the date, to locations.
+var zoneLocations = map[string]string{
+	"et": "America/New_York",
+	"ct": "America/Chicago",
+	"mt": "America/Denver",
+	"pt": "America/Los_Angeles",
+}
@@ -0,0 +1,285 @@
+// Package urlnorm canonicalizes URLs so that the same document reached through
+// tracking parameters, AMP variants, fragments or default ports maps to a single
+// URL. Keys additionally fold http onto https so both schemes share a document ID.
+package urlnorm
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+)
+
+// trackingParams are query parameters that never change the content of a page.
+var trackingParams = map[string]bool{
+	"fbclid":     true,
+	"gclid":      true,
+	"dclid":      true,
+	"msclkid":    true,
+	"igshid":     true,
+	"mc_cid":     true,
+	"mc_eid":     true,
+	"_ga":        true,
+	"_gl":        true,
+	"ocid":       true,
+	"cmpid":      true,
+	"ncid":       true,
+	"ref":        true,
+	"ref_src":    true,
+	"ref_url":    true,
+	"amp":        true,
+	"outputtype": true,
+}
+
+// trackingPrefixes are query parameter prefixes that never change the content of a page.
+var trackingPrefixes = []string{"utm_", "itm_", "pk_", "mtm_", "hsa_"}
+
+// defaultPorts maps schemes to the ports that can be omitted.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Canonicalizer normalizes URLs and applies per-source rewrite rules.
+type Canonicalizer struct {
+	rewrites []rewrite
+}
+
+// rewrite is a compiled URL rewrite rule.
+type rewrite struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// New creates a canonicalizer with the given rewrite rules.
+func New(rewrites configtypes.URLRewrites) (*Canonicalizer, error) {
+	c := &Canonicalizer{rewrites: make([]rewrite, 0, len(rewrites))}
+	for _, r := range rewrites {
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid url rewrite pattern %q: %w", r.Pattern, err)
+		}
+		c.rewrites = append(c.rewrites, rewrite{pattern: pattern, replacement: r.Replacement})
+	}
+	return c, nil
+}
+
+// Canonicalize normalizes a URL without source-specific rewrites.
+func Canonicalize(rawURL string) (string, error) {
+	return (&Canonicalizer{}).Canonicalize(rawURL)
+}
+
+// Canonicalize normalizes an absolute URL:
+//   - the scheme and host are lowercased
+//   - default ports, credentials and fragments are dropped
+//   - tracking parameters are removed and the remaining parameters are sorted
+//   - AMP variants and trailing slashes are collapsed onto the regular article path
+//
+// Rewrite rules are applied to the normalized URL, which is normalized again afterwards.
+func (c *Canonicalizer) Canonicalize(rawURL string) (string, error) {
+	canonical, err := normalize(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	if c == nil || len(c.rewrites) == 0 {
+		return canonical, nil
+	}
+
+	for _, r := range c.rewrites {
+		canonical = r.pattern.ReplaceAllString(canonical, r.replacement)
+	}
+	return normalize(canonical)
+}
+
+// Key returns the deduplication key of a URL: its canonical form with the scheme
+// folded onto https, so the http and https copies of a page share one key.
+func (c *Canonicalizer) Key(rawURL string) (string, error) {
+	canonical, err := c.Canonicalize(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return Key(canonical), nil
+}
+
+// Key folds the scheme of a canonical URL onto https. Document IDs are derived
+// from the key while the stored canonical URL keeps the scheme the site uses.
+func Key(canonicalURL string) string {
+	if rest, ok := strings.CutPrefix(canonicalURL, "http://"); ok {
+		return "https://" + rest
+	}
+	return canonicalURL
+}
+
+// SameSite reports whether two URLs point at the same host, ignoring scheme, port and a leading "www.".
+func SameSite(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil || ua.Hostname() == "" {
+		return false
+	}
+	return bareHost(ua.Hostname()) == bareHost(ub.Hostname())
+}
+
+// normalize applies the canonicalization rules to a URL.
+func normalize(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid url %q: %w", rawURL, err)
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return "", fmt.Errorf("url %q is not absolute", rawURL)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	u.Scheme = scheme
+
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "amp.")
+	if port := u.Port(); port != "" && port != defaultPorts[scheme] {
+		host += ":" + port
+	}
+	u.Host = host
+
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.User = nil
+	u.Path = normalizePath(u.Path)
+	u.RawPath = ""
+	u.RawQuery = normalizeQuery(u.Query())
+
+	return u.String(), nil
+}
+
+// normalizePath collapses duplicate slashes, AMP path variants and trailing slashes.
+func normalizePath(p string) string {
+	if p == "" {
+		return "/"
+	}
+
+	cleaned := path.Clean("/" + p)
+	segments := strings.Split(strings.Trim(cleaned, "/"), "/")
+
+	kept := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		lower := strings.ToLower(segment)
+		if lower == "amp" && (i == 0 || i == len(segments)-1) {
+			continue
+		}
+		if strings.HasSuffix(lower, ".amp.html") {
+			segment = segment[:len(segment)-len(".amp.html")] + ".html"
+		} else if strings.HasSuffix(lower, ".amp") {
+			segment = segment[:len(segment)-len(".amp")]
+		}
+		if segment != "" {
+			kept = append(kept, segment)
+		}
+	}
+
+	return "/" + strings.Join(kept, "/")
+}
+
+// normalizeQuery removes tracking parameters and sorts the rest.
+func normalizeQuery(values url.Values) string {
+	for key := range values {
+		if isTrackingParam(key) {
+			delete(values, key)
+		}
+	}
+	if len(values) == 0 {
+		return ""
+	}
+
+	// Encode sorts by key; sort repeated values too so their order does not matter
+	for key := range values {
+		sort.Strings(values[key])
+	}
+	return values.Encode()
+}
+
+// isTrackingParam reports whether a query parameter is used only for tracking.
+func isTrackingParam(key string) bool {
+	lower := strings.ToLower(key)
+	if trackingParams[lower] {
+		return true
+	}
+	for _, prefix := range trackingPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// bareHost strips a leading "www." from a host.
+func bareHost(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
+
+// Resolve returns the canonical URL of a fetched page. The first declared URL
+// (e.g. from <link rel=canonical> or og:url) that is on the same site as the
+// request URL is preferred; declared URLs may be relative. If nothing can be
+// canonicalized the request URL is returned unchanged.
+func (c *Canonicalizer) Resolve(requestURL string, declared ...string) string {
+	base, baseErr := url.Parse(requestURL)
+	for _, candidate := range declared {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" || baseErr != nil {
+			continue
+		}
+		ref, err := url.Parse(candidate)
+		if err != nil {
+			continue
+		}
+		absolute := base.ResolveReference(ref).String()
+		if !SameSite(requestURL, absolute) {
+			continue
+		}
+		if canonical, canonErr := c.Canonicalize(absolute); canonErr == nil {
+			return canonical
+		}
+	}
+
+	if canonical, err := c.Canonicalize(requestURL); err == nil {
+		return canonical
+	}
+	return requestURL
+}
+
+// Cache holds one canonicalizer per source so rewrite rules are compiled once.
+// An entry is rebuilt when the source's rules change, e.g. after sources are reloaded.
+type Cache struct {
+	canonicalizers sync.Map
+}
+
+// cacheEntry is a compiled canonicalizer and the rules it was compiled from.
+type cacheEntry struct {
+	rewrites      configtypes.URLRewrites
+	canonicalizer *Canonicalizer
+}
+
+// ForSource returns the canonicalizer for a source. Sources with invalid
+// rewrite rules fall back to plain normalization.
+func (c *Cache) ForSource(name string, rewrites configtypes.URLRewrites) *Canonicalizer {
+	if len(rewrites) == 0 {
+		return &Canonicalizer{}
+	}
+	if cached, ok := c.canonicalizers.Load(name); ok {
+		if entry, isEntry := cached.(*cacheEntry); isEntry && slices.Equal(entry.rewrites, rewrites) {
+			return entry.canonicalizer
+		}
+	}
+
+	canonicalizer, err := New(rewrites)
+	if err != nil {
+		canonicalizer = &Canonicalizer{}
+	}
+	c.canonicalizers.Store(name, &cacheEntry{rewrites: slices.Clone(rewrites), canonicalizer: canonicalizer})
+	return canonicalizer
+}
@@ -0,0 +1,114 @@
+package urlnorm_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/gocrawl/internal/common/urlnorm"
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "tracking parameters",
+			input: "https://example.com/news/story?utm_source=twitter&id=7&fbclid=abc",
+			want:  "https://example.com/news/story?id=7",
+		},
+		{
+			name:  "sorted parameters",
+			input: "https://example.com/search?b=2&a=1",
+			want:  "https://example.com/search?a=1&b=2",
+		},
+		{
+			name:  "trailing slash and fragment",
+			input: "https://example.com/news/story/#comments",
+			want:  "https://example.com/news/story",
+		},
+		{
+			name:  "scheme, host case and default port",
+			input: "HTTP://Example.COM:80/news/story",
+			want:  "http://example.com/news/story",
+		},
+		{
+			name:  "non-default port kept",
+			input: "https://example.com:8443/news",
+			want:  "https://example.com:8443/news",
+		},
+		{
+			name:  "amp path suffix",
+			input: "https://example.com/news/story/amp",
+			want:  "https://example.com/news/story",
+		},
+		{
+			name:  "amp host and file suffix",
+			input: "https://amp.example.com/news/story.amp.html?amp=1",
+			want:  "https://example.com/news/story.html",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := urlnorm.Canonicalize(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := urlnorm.Canonicalize("/relative/path")
+	assert.Error(t, err)
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	var c *urlnorm.Canonicalizer
+	httpKey, err := c.Key("http://example.com/news/story?utm_source=rss")
+	require.NoError(t, err)
+	httpsKey, err := c.Key("https://example.com/news/story/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/news/story", httpKey)
+	assert.Equal(t, httpsKey, httpKey, "http and https copies share a key")
+
+	canonical := c.Resolve("http://example.com/news/story")
+	assert.Equal(t, "http://example.com/news/story", canonical, "the canonical URL keeps its scheme")
+	assert.Equal(t, httpKey, urlnorm.Key(canonical))
+}
+
+func TestCanonicalizerRewrites(t *testing.T) {
+	t.Parallel()
+
+	c, err := urlnorm.New(configtypes.URLRewrites{
+		{Pattern: `/print/(\d+)$`, Replacement: "/article/$1"},
+	})
+	require.NoError(t, err)
+
+	got, err := c.Canonicalize("https://example.com/print/42/?utm_medium=email")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/article/42", got)
+
+	_, err = urlnorm.New(configtypes.URLRewrites{{Pattern: "("}})
+	assert.Error(t, err)
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	var c *urlnorm.Canonicalizer
+	request := "https://www.example.com/news/story?utm_source=rss"
+
+	assert.Equal(t, "https://www.example.com/news/story-123",
+		c.Resolve(request, "/news/story-123/"), "relative same-site canonical")
+	assert.Equal(t, "https://www.example.com/news/story",
+		c.Resolve(request, "https://syndicator.com/copy/story"), "cross-site canonical is ignored")
+	assert.Equal(t, "https://example.com/news/story-9",
+		c.Resolve(request, "", "https://example.com/news/story-9"), "first usable candidate wins")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/jonesrussell/gocrawl/internal/config/logging"
 	"github.com/jonesrussell/gocrawl/internal/config/server"
 	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
@@ -204,23 +205,29 @@ func LoadConfig() (*Config, error) {
 		cfg.Server.Address = DefaultServerAddress
 	}
 
-	cfg.Server.ReadTimeout = viper.GetDuration("server.readTimeout")
+	cfg.Server.ReadTimeout = viper.GetDuration(serverKey("read_timeout", "readTimeout"))
 	if cfg.Server.ReadTimeout == 0 {
 		cfg.Server.ReadTimeout = DefaultServerReadTimeout
 	}
 
-	cfg.Server.WriteTimeout = viper.GetDuration("server.writeTimeout")
+	cfg.Server.WriteTimeout = viper.GetDuration(serverKey("write_timeout", "writeTimeout"))
 	if cfg.Server.WriteTimeout == 0 {
 		cfg.Server.WriteTimeout = DefaultServerWriteTimeout
 	}
 
-	cfg.Server.IdleTimeout = viper.GetDuration("server.idleTimeout")
+	cfg.Server.IdleTimeout = viper.GetDuration(serverKey("idle_timeout", "idleTimeout"))
 	if cfg.Server.IdleTimeout == 0 {
 		cfg.Server.IdleTimeout = DefaultServerIdleTimeout
 	}
 
-	cfg.Server.SecurityEnabled = viper.GetBool("server.security.enabled")
-	cfg.Server.APIKey = viper.GetString("server.security.apiKey")
+	cfg.Server.SecurityEnabled = viper.GetBool(serverKey("security_enabled", "security.enabled"))
+	cfg.Server.APIKey = viper.GetString(serverKey("api_key", "security.apiKey"))
+	cfg.Server.APIKeysFile = viper.GetString("server.api_keys_file")
+	apiKeys, err := decodeAPIKeys(viper.Get("server.api_keys"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.Server.APIKeys = apiKeys
 
 	// Set the logger
 	cfg.logger = tempLogger
@@ -272,3 +279,36 @@ func (c *Config) GetCommand() string {
 func (c *Config) GetConfigFile() string {
 	return viper.ConfigFileUsed()
 }
+
+// serverKey returns the server key to read: the key named after the yaml tag
+// of server.Config, or its deprecated spelling when only that one is set.
+func serverKey(key, deprecated string) string {
+	if !viper.IsSet("server."+key) && viper.IsSet("server."+deprecated) {
+		return "server." + deprecated
+	}
+	return "server." + key
+}
+
+// decodeAPIKeys decodes the server.api_keys list, whose expiry dates are
+// RFC 3339 timestamps.
+func decodeAPIKeys(raw any) ([]server.APIKeyConfig, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	var keys []server.APIKeyConfig
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &keys,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeHookFunc(time.RFC3339),
+			mapstructure.StringToSliceHookFunc(","),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decoder: %w", err)
+	}
+	if decodeErr := decoder.Decode(raw); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode server.api_keys: %w", decodeErr)
+	}
+	return keys, nil
+}
@@ -37,6 +37,9 @@ type Config struct {
 	MaxConcurrency int `yaml:"max_concurrency"`
 	// RequestTimeout is the timeout for each request
 	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// MaxBodySize is the maximum size in bytes of a response body that is read (0 means unlimited).
+	// The crawler applies it, like RequestTimeout, only when a source's crawler block sets it.
+	MaxBodySize int `yaml:"max_body_size"`
 	// UserAgent is the user agent to use for requests
 	UserAgent string `yaml:"user_agent"`
 	// RespectRobotsTxt indicates whether to respect robots.txt
@@ -51,6 +54,12 @@ type Config struct {
 	RandomDelay time.Duration `yaml:"random_delay"`
 	// SourcesAPIURL is the URL of the gosources API service
 	SourcesAPIURL string `yaml:"sources_api_url"`
+	// SourcesAPI contains the settings of the sources API client
+	SourcesAPI SourcesAPIConfig `yaml:"sources_api"`
+	// SourcesFile is a YAML sources file or sources snapshot used instead of the sources API
+	SourcesFile string `yaml:"sources_file"`
+	// SourcesCache contains the settings of the cached sources API response
+	SourcesCache SourcesCacheConfig `yaml:"sources_cache"`
 	// Debug enables debug logging
 	Debug bool `yaml:"debug"`
 	// TLS contains TLS configuration
@@ -67,6 +76,20 @@ type Config struct {
 	ValidateURLs bool `yaml:"validate_urls"`
 	// CleanupInterval is the interval for cleaning up resources
 	CleanupInterval time.Duration `yaml:"cleanup_interval"`
+	// Dedup contains near-duplicate article detection settings
+	Dedup DedupConfig `yaml:"dedup"`
+	// Links contains link graph capture settings
+	Links LinksConfig `yaml:"links"`
+	// Enrichment contains the settings of the article enrichers
+	Enrichment EnrichmentConfig `yaml:"enrichment"`
+	// Monitor contains selector regression monitoring settings
+	Monitor MonitorConfig `yaml:"monitor"`
+	// Alerts contains where monitoring alerts are sent
+	Alerts AlertsConfig `yaml:"alerts"`
+	// Runs contains crawl run history and anomaly detection settings
+	Runs RunsConfig `yaml:"runs"`
+	// Groups contains the settings of source groups by group name
+	Groups map[string]GroupConfig `yaml:"groups"`
 }
 
 // Validate validates the crawler configuration.
@@ -80,12 +103,44 @@ func (c *Config) Validate() error {
 	if c.RequestTimeout < 0 {
 		return errors.New("request_timeout must be non-negative")
 	}
+	if c.MaxBodySize < 0 {
+		return errors.New("max_body_size must be non-negative")
+	}
 	if c.Delay < 0 {
 		return errors.New("delay must be non-negative")
 	}
 	if c.RandomDelay < 0 {
 		return errors.New("random_delay must be non-negative")
 	}
+	if err := c.Dedup.Validate(); err != nil {
+		return err
+	}
+	if err := c.Links.Validate(); err != nil {
+		return err
+	}
+	if err := c.Enrichment.Validate(); err != nil {
+		return err
+	}
+	if err := c.Monitor.Validate(); err != nil {
+		return err
+	}
+	if err := c.Alerts.Validate(); err != nil {
+		return err
+	}
+	if err := c.Runs.Validate(); err != nil {
+		return err
+	}
+	if err := c.SourcesAPI.Validate(); err != nil {
+		return err
+	}
+	if err := c.SourcesCache.Validate(); err != nil {
+		return err
+	}
+	for name, group := range c.Groups {
+		if err := group.Validate(); err != nil {
+			return fmt.Errorf("invalid group %s: %w", name, err)
+		}
+	}
 	return c.TLS.Validate()
 }
 
@@ -95,6 +150,7 @@ func New(opts ...Option) *Config {
 		MaxDepth:          DefaultMaxDepth,
 		MaxConcurrency:    DefaultParallelism,
 		RequestTimeout:    DefaultTimeout,
+		MaxBodySize:       DefaultMaxBodySize,
 		Delay:             DefaultRateLimit,
 		RandomDelay:       DefaultRandomDelay,
 		UserAgent:         DefaultUserAgent,
@@ -115,6 +171,14 @@ func New(opts ...Option) *Config {
 		MaxRedirects:    DefaultMaxRedirects,
 		ValidateURLs:    true,
 		CleanupInterval: DefaultCleanupInterval,
+		Dedup:           NewDedupConfig(),
+		Links:           NewLinksConfig(),
+		Enrichment:      NewEnrichmentConfig(),
+		Monitor:         NewMonitorConfig(),
+		Alerts:          NewAlertsConfig(),
+		Runs:            NewRunsConfig(),
+		SourcesAPI:      NewSourcesAPIConfig(),
+		SourcesCache:    NewSourcesCacheConfig(),
 	}
 
 	for _, opt := range opts {
@@ -270,6 +334,9 @@ func LoadFromViper(v *viper.Viper) *Config {
 
 	cfg.MaxDepth = v.GetInt("crawler.max_depth")
 	cfg.RequestTimeout = v.GetDuration("crawler.request_timeout")
+	if v.IsSet("crawler.max_body_size") {
+		cfg.MaxBodySize = v.GetInt("crawler.max_body_size")
+	}
 	cfg.UserAgent = v.GetString("crawler.user_agent")
 	cfg.RespectRobotsTxt = v.GetBool("crawler.respect_robots_txt")
 	cfg.AllowedDomains = v.GetStringSlice("crawler.allowed_domains")
@@ -277,6 +344,7 @@ func LoadFromViper(v *viper.Viper) *Config {
 	cfg.Delay = v.GetDuration("crawler.delay")
 	cfg.RandomDelay = v.GetDuration("crawler.random_delay")
 	cfg.SourcesAPIURL = v.GetString("crawler.sources_api_url")
+	cfg.SourcesFile = v.GetString("crawler.sources_file")
 	cfg.Debug = v.GetBool("crawler.debug")
 	// Only set MaxRetries if it's actually set and > 0 in Viper
 	// This prevents overwriting the default with 0 when defaults haven't been loaded yet
@@ -296,6 +364,133 @@ func LoadFromViper(v *viper.Viper) *Config {
 		cfg.CleanupInterval = cleanupInterval
 	}
 
+	// Load dedup configuration, keeping defaults for unset values
+	cfg.Dedup.Enabled = v.GetBool("crawler.dedup.enabled")
+	if mode := v.GetString("crawler.dedup.mode"); mode != "" {
+		cfg.Dedup.Mode = mode
+	}
+	if window := v.GetDuration("crawler.dedup.window"); window > 0 {
+		cfg.Dedup.Window = window
+	}
+	if v.IsSet("crawler.dedup.max_distance") {
+		cfg.Dedup.MaxDistance = v.GetInt("crawler.dedup.max_distance")
+	}
+	if v.IsSet("crawler.dedup.min_words") {
+		cfg.Dedup.MinWords = v.GetInt("crawler.dedup.min_words")
+	}
+	if index := v.GetString("crawler.dedup.index"); index != "" {
+		cfg.Dedup.Index = index
+	}
+
+	// Load link graph configuration, keeping defaults for unset values
+	cfg.Links.Enabled = v.GetBool("crawler.links.enabled")
+	if index := v.GetString("crawler.links.index"); index != "" {
+		cfg.Links.Index = index
+	}
+	if batchSize := v.GetInt("crawler.links.batch_size"); batchSize > 0 {
+		cfg.Links.BatchSize = batchSize
+	}
+
+	// Load enrichment configuration, keeping defaults for unset values
+	cfg.Enrichment.Gazetteer = v.GetString("crawler.enrichment.gazetteer")
+	if maxKeyphrases := v.GetInt("crawler.enrichment.max_keyphrases"); maxKeyphrases > 0 {
+		cfg.Enrichment.MaxKeyphrases = maxKeyphrases
+	}
+	if wordsPerMinute := v.GetInt("crawler.enrichment.words_per_minute"); wordsPerMinute > 0 {
+		cfg.Enrichment.WordsPerMinute = wordsPerMinute
+	}
+
+	// Load selector monitor configuration, keeping defaults for unset values
+	cfg.Monitor.Enabled = v.GetBool("crawler.monitor.enabled")
+	if interval := v.GetDuration("crawler.monitor.interval"); interval > 0 {
+		cfg.Monitor.Interval = interval
+	}
+	if index := v.GetString("crawler.monitor.index"); index != "" {
+		cfg.Monitor.Index = index
+	}
+	if samples := v.GetInt("crawler.monitor.samples"); samples > 0 {
+		cfg.Monitor.Samples = samples
+	}
+	if v.IsSet("crawler.monitor.title_threshold") {
+		cfg.Monitor.TitleThreshold = v.GetFloat64("crawler.monitor.title_threshold")
+	}
+	if v.IsSet("crawler.monitor.body_threshold") {
+		cfg.Monitor.BodyThreshold = v.GetFloat64("crawler.monitor.body_threshold")
+	}
+
+	// Load alert settings, keeping defaults for unset values
+	if sinks := v.GetStringSlice("crawler.alerts.sinks"); len(sinks) > 0 {
+		cfg.Alerts.Sinks = sinks
+	}
+	cfg.Alerts.WebhookURL = v.GetString("crawler.alerts.webhook_url")
+
+	// Load run history configuration, keeping defaults for unset values
+	if v.IsSet("crawler.runs.enabled") {
+		cfg.Runs.Enabled = v.GetBool("crawler.runs.enabled")
+	}
+	if index := v.GetString("crawler.runs.index"); index != "" {
+		cfg.Runs.Index = index
+	}
+	if baselineRuns := v.GetInt("crawler.runs.baseline_runs"); baselineRuns > 0 {
+		cfg.Runs.BaselineRuns = baselineRuns
+	}
+	if minBaselineRuns := v.GetInt("crawler.runs.min_baseline_runs"); minBaselineRuns > 0 {
+		cfg.Runs.MinBaselineRuns = minBaselineRuns
+	}
+	if threshold := v.GetFloat64("crawler.runs.threshold"); threshold > 0 {
+		cfg.Runs.Threshold = threshold
+	}
+	if v.IsSet("crawler.runs.min_change") {
+		cfg.Runs.MinChange = v.GetFloat64("crawler.runs.min_change")
+	}
+
+	// Load sources API client configuration, keeping defaults for unset values
+	cfg.SourcesAPI.Token = v.GetString("crawler.sources_api.token")
+	cfg.SourcesAPI.APIKey = v.GetString("crawler.sources_api.api_key")
+	if timeout := v.GetDuration("crawler.sources_api.timeout"); timeout > 0 {
+		cfg.SourcesAPI.Timeout = timeout
+	}
+	if v.IsSet("crawler.sources_api.max_retries") {
+		cfg.SourcesAPI.MaxRetries = v.GetInt("crawler.sources_api.max_retries")
+	}
+	if initialWait := v.GetDuration("crawler.sources_api.retry_initial_wait"); initialWait > 0 {
+		cfg.SourcesAPI.RetryInitialWait = initialWait
+	}
+	if maxWait := v.GetDuration("crawler.sources_api.retry_max_wait"); maxWait > 0 {
+		cfg.SourcesAPI.RetryMaxWait = maxWait
+	}
+	if v.IsSet("crawler.sources_api.breaker_threshold") {
+		cfg.SourcesAPI.BreakerThreshold = v.GetInt("crawler.sources_api.breaker_threshold")
+	}
+	if cooldown := v.GetDuration("crawler.sources_api.breaker_cooldown"); cooldown > 0 {
+		cfg.SourcesAPI.BreakerCooldown = cooldown
+	}
+	cfg.SourcesAPI.PageSize = v.GetInt("crawler.sources_api.page_size")
+
+	// Load sources cache configuration, keeping defaults for unset values
+	if v.IsSet("crawler.sources_cache.enabled") {
+		cfg.SourcesCache.Enabled = v.GetBool("crawler.sources_cache.enabled")
+	}
+	if path := v.GetString("crawler.sources_cache.path"); path != "" {
+		cfg.SourcesCache.Path = path
+	}
+	if v.IsSet("crawler.sources_cache.max_staleness") {
+		cfg.SourcesCache.MaxStaleness = v.GetDuration("crawler.sources_cache.max_staleness")
+	}
+
+	// Load source group settings
+	for name := range v.GetStringMap("crawler.groups") {
+		if cfg.Groups == nil {
+			cfg.Groups = make(map[string]GroupConfig)
+		}
+		key := "crawler.groups." + name
+		cfg.Groups[name] = GroupConfig{
+			Time:         v.GetStringSlice(key + ".time"),
+			MinRateLimit: v.GetDuration(key + ".min_rate_limit"),
+			RateLimit:    v.GetDuration(key + ".rate_limit"),
+		}
+	}
+
 	// Load TLS configuration
 	cfg.TLS.InsecureSkipVerify = v.GetBool("crawler.tls.insecure_skip_verify")
 	if v.IsSet("crawler.tls.min_version") {
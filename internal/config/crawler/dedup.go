@@ -0,0 +1,79 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Dedup modes
+const (
+	// DedupModeSkip drops near-duplicate articles instead of indexing them.
+	DedupModeSkip = "skip"
+	// DedupModeLink indexes near-duplicates with a duplicate_of reference to the canonical article.
+	DedupModeLink = "link"
+)
+
+// Default dedup values
+const (
+	// DefaultDedupWindow is how far back stored fingerprints are compared
+	DefaultDedupWindow = 7 * 24 * time.Hour
+	// DefaultDedupMaxDistance is the largest SimHash Hamming distance treated as a duplicate
+	DefaultDedupMaxDistance = 3
+	// DefaultDedupMinWords is the minimum body length, in words, for an article to be fingerprinted
+	DefaultDedupMinWords = 50
+	// MaxDedupDistance is the largest supported distance; fingerprints are split into
+	// MaxDedupDistance+1 bands so that candidates can be found by exact band matches
+	MaxDedupDistance = 7
+	// DefaultDedupIndex matches every article index, so copies on other sources are found
+	DefaultDedupIndex = "*articles*"
+)
+
+// DedupConfig holds near-duplicate article detection settings.
+type DedupConfig struct {
+	// Enabled turns on near-duplicate detection
+	Enabled bool `yaml:"enabled"`
+	// Mode is either "skip" or "link"
+	Mode string `yaml:"mode"`
+	// Window is how far back stored fingerprints are compared
+	Window time.Duration `yaml:"window"`
+	// MaxDistance is the largest SimHash Hamming distance treated as a duplicate
+	MaxDistance int `yaml:"max_distance"`
+	// MinWords is the minimum body length, in words, for an article to be fingerprinted
+	MinWords int `yaml:"min_words"`
+	// Index is the index, alias or pattern searched for earlier copies; it must
+	// cover the article indices of every source for syndicated copies to be found
+	Index string `yaml:"index"`
+}
+
+// NewDedupConfig returns the default dedup configuration.
+func NewDedupConfig() DedupConfig {
+	return DedupConfig{
+		Enabled:     false,
+		Mode:        DedupModeLink,
+		Window:      DefaultDedupWindow,
+		MaxDistance: DefaultDedupMaxDistance,
+		MinWords:    DefaultDedupMinWords,
+		Index:       DefaultDedupIndex,
+	}
+}
+
+// Validate validates the dedup configuration.
+func (c *DedupConfig) Validate() error {
+	if c.Mode != DedupModeSkip && c.Mode != DedupModeLink {
+		return fmt.Errorf("dedup mode must be %q or %q, got %q", DedupModeSkip, DedupModeLink, c.Mode)
+	}
+	if c.Window < 0 {
+		return errors.New("dedup window must be non-negative")
+	}
+	if c.MaxDistance < 0 || c.MaxDistance > MaxDedupDistance {
+		return fmt.Errorf("dedup max_distance must be between 0 and %d", MaxDedupDistance)
+	}
+	if c.MinWords < 0 {
+		return errors.New("dedup min_words must be non-negative")
+	}
+	if c.Index == "" {
+		return errors.New("dedup index is required")
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package crawler
+
+import "errors"
+
+// Default enrichment values
+const (
+	// DefaultMaxKeyphrases is the number of keyphrases kept per article
+	DefaultMaxKeyphrases = 10
+	// DefaultWordsPerMinute is the reading speed used to estimate reading time
+	DefaultWordsPerMinute = 230
+)
+
+// EnrichmentConfig holds the settings of the article enrichers. Which enrichers
+// run is chosen per source with its enrichers list.
+type EnrichmentConfig struct {
+	// Gazetteer is the path of a YAML file listing places and organizations to tag.
+	// The city of each source is always included as a place.
+	Gazetteer string `yaml:"gazetteer"`
+	// MaxKeyphrases is the number of keyphrases kept per article
+	MaxKeyphrases int `yaml:"max_keyphrases"`
+	// WordsPerMinute is the reading speed used to estimate reading time
+	WordsPerMinute int `yaml:"words_per_minute"`
+}
+
+// NewEnrichmentConfig returns the default enrichment configuration.
+func NewEnrichmentConfig() EnrichmentConfig {
+	return EnrichmentConfig{
+		MaxKeyphrases:  DefaultMaxKeyphrases,
+		WordsPerMinute: DefaultWordsPerMinute,
+	}
+}
+
+// Validate validates the enrichment configuration.
+func (c *EnrichmentConfig) Validate() error {
+	if c.MaxKeyphrases < 1 {
+		return errors.New("enrichment max_keyphrases must be positive")
+	}
+	if c.WordsPerMinute < 1 {
+		return errors.New("enrichment words_per_minute must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GroupConfig holds the settings shared by the sources of a group. A source
+// belongs to the group named by its group ID and to those named by its tags.
+type GroupConfig struct {
+	// Time lists the times of day (HH:MM) at which the scheduler crawls every source of the group
+	Time []string `yaml:"time" mapstructure:"time"`
+	// MinRateLimit is the least delay between requests that each source of the group
+	// uses. It is a per-source minimum, not a budget shared by the group: sources
+	// configured with a longer delay keep it, and sources crawled at the same time
+	// each make requests at up to this rate.
+	MinRateLimit time.Duration `yaml:"min_rate_limit" mapstructure:"min_rate_limit"`
+	// RateLimit is the least delay between requests of all sources of the group
+	// together. The group shares one request budget, so sources crawled at the same
+	// time split it. Zero leaves the group without a shared budget.
+	RateLimit time.Duration `yaml:"rate_limit" mapstructure:"rate_limit"`
+}
+
+// Validate validates the group configuration.
+func (c *GroupConfig) Validate() error {
+	for _, t := range c.Time {
+		if _, err := time.Parse("15:04", t); err != nil {
+			return fmt.Errorf("invalid time %q: must be HH:MM", t)
+		}
+	}
+	if c.MinRateLimit < 0 {
+		return errors.New("min_rate_limit must be non-negative")
+	}
+	if c.RateLimit < 0 {
+		return errors.New("rate_limit must be non-negative")
+	}
+	return nil
+}
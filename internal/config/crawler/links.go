@@ -0,0 +1,41 @@
+package crawler
+
+import "errors"
+
+// Default link graph values
+const (
+	// DefaultLinksIndex is the index holding the link graph
+	DefaultLinksIndex = "links"
+	// DefaultLinksBatchSize is the number of links buffered before they are bulk indexed
+	DefaultLinksBatchSize = 500
+)
+
+// LinksConfig holds link graph capture settings.
+type LinksConfig struct {
+	// Enabled turns on recording of the links found on crawled pages
+	Enabled bool `yaml:"enabled"`
+	// Index is the index the links are stored in
+	Index string `yaml:"index"`
+	// BatchSize is the number of links buffered before they are bulk indexed
+	BatchSize int `yaml:"batch_size"`
+}
+
+// NewLinksConfig returns the default link graph configuration.
+func NewLinksConfig() LinksConfig {
+	return LinksConfig{
+		Enabled:   false,
+		Index:     DefaultLinksIndex,
+		BatchSize: DefaultLinksBatchSize,
+	}
+}
+
+// Validate validates the link graph configuration.
+func (c *LinksConfig) Validate() error {
+	if c.Index == "" {
+		return errors.New("links index must not be empty")
+	}
+	if c.BatchSize < 1 {
+		return errors.New("links batch_size must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Alert sinks
+const (
+	// AlertSinkLog writes alerts to the log.
+	AlertSinkLog = "log"
+	// AlertSinkWebhook posts alerts as JSON to a webhook URL.
+	AlertSinkWebhook = "webhook"
+	// AlertSinkEvents publishes alerts as error events on the crawler event bus.
+	AlertSinkEvents = "events"
+)
+
+// Default selector monitor values
+const (
+	// DefaultMonitorInterval is how often the selectors of every source are checked
+	DefaultMonitorInterval = 6 * time.Hour
+	// DefaultMonitorIndex is the index holding the selector success rates over time
+	DefaultMonitorIndex = "selector_health"
+	// DefaultMonitorSamples is the number of recently indexed articles checked per source
+	DefaultMonitorSamples = 5
+	// DefaultMonitorThreshold is the title and body success rate, in percent, below which an alert is raised
+	DefaultMonitorThreshold = 80.0
+	// maxPercent is the largest success rate
+	maxPercent = 100.0
+)
+
+// AlertsConfig holds where alerts are sent.
+type AlertsConfig struct {
+	// Sinks lists the alert destinations: log, webhook and events
+	Sinks []string `yaml:"sinks"`
+	// WebhookURL receives alerts as JSON when the webhook sink is enabled
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+// NewAlertsConfig returns the default alerts configuration.
+func NewAlertsConfig() AlertsConfig {
+	return AlertsConfig{Sinks: []string{AlertSinkLog}}
+}
+
+// Validate validates the alerts configuration.
+func (c *AlertsConfig) Validate() error {
+	for _, sink := range c.Sinks {
+		if sink != AlertSinkLog && sink != AlertSinkWebhook && sink != AlertSinkEvents {
+			return fmt.Errorf("alert sink must be %q, %q or %q, got %q",
+				AlertSinkLog, AlertSinkWebhook, AlertSinkEvents, sink)
+		}
+	}
+	if slices.Contains(c.Sinks, AlertSinkWebhook) && c.WebhookURL == "" {
+		return errors.New("alerts webhook_url is required by the webhook sink")
+	}
+	return nil
+}
+
+// MonitorConfig holds selector regression monitoring settings.
+type MonitorConfig struct {
+	// Enabled turns on the scheduled selector checks
+	Enabled bool `yaml:"enabled"`
+	// Interval is how often the selectors of every source are checked
+	Interval time.Duration `yaml:"interval"`
+	// Index is the index the success rates are stored in
+	Index string `yaml:"index"`
+	// Samples is the number of recently indexed articles checked per source
+	Samples int `yaml:"samples"`
+	// TitleThreshold is the title success rate, in percent, below which an alert is raised
+	TitleThreshold float64 `yaml:"title_threshold"`
+	// BodyThreshold is the body success rate, in percent, below which an alert is raised
+	BodyThreshold float64 `yaml:"body_threshold"`
+}
+
+// NewMonitorConfig returns the default selector monitor configuration.
+func NewMonitorConfig() MonitorConfig {
+	return MonitorConfig{
+		Enabled:        false,
+		Interval:       DefaultMonitorInterval,
+		Index:          DefaultMonitorIndex,
+		Samples:        DefaultMonitorSamples,
+		TitleThreshold: DefaultMonitorThreshold,
+		BodyThreshold:  DefaultMonitorThreshold,
+	}
+}
+
+// Validate validates the selector monitor configuration.
+func (c *MonitorConfig) Validate() error {
+	if c.Interval <= 0 {
+		return errors.New("monitor interval must be positive")
+	}
+	if c.Index == "" {
+		return errors.New("monitor index must not be empty")
+	}
+	if c.Samples < 1 {
+		return errors.New("monitor samples must be positive")
+	}
+	if c.TitleThreshold < 0 || c.TitleThreshold > maxPercent ||
+		c.BodyThreshold < 0 || c.BodyThreshold > maxPercent {
+		return errors.New("monitor thresholds must be between 0 and 100")
+	}
+	return nil
+}
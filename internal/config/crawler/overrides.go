@@ -0,0 +1,70 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithOverrides returns a copy of the configuration with the per-source
+// overrides applied. Overrides are keyed like the crawler section of the
+// configuration file, e.g. {"user_agent": "...", "tls": {"min_version": 772}};
+// parallelism is accepted as an alias of max_concurrency. Unknown keys are
+// rejected and the merged configuration is validated.
+func (c *Config) WithOverrides(overrides map[string]any) (*Config, error) {
+	merged := *c
+	if len(overrides) == 0 {
+		return &merged, nil
+	}
+	merged.Groups = maps.Clone(c.Groups)
+
+	data, err := yaml.Marshal(normalizeOverrides(overrides))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode crawler overrides: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if decodeErr := decoder.Decode(&merged); decodeErr != nil {
+		return nil, fmt.Errorf("invalid crawler overrides: %w", decodeErr)
+	}
+	if validateErr := merged.Validate(); validateErr != nil {
+		return nil, fmt.Errorf("invalid crawler overrides: %w", validateErr)
+	}
+	return &merged, nil
+}
+
+// OverrideKeys returns the dotted keys of the settings set by overrides,
+// e.g. "user_agent" and "tls.min_version", sorted.
+func OverrideKeys(overrides map[string]any) []string {
+	var keys []string
+	var walk func(prefix string, values map[string]any)
+	walk = func(prefix string, values map[string]any) {
+		for key, value := range values {
+			if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+				walk(prefix+key+".", nested)
+				continue
+			}
+			keys = append(keys, prefix+key)
+		}
+	}
+	walk("", normalizeOverrides(overrides))
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeOverrides renames the parallelism alias to max_concurrency.
+func normalizeOverrides(overrides map[string]any) map[string]any {
+	parallelism, ok := overrides["parallelism"]
+	if !ok {
+		return overrides
+	}
+	normalized := maps.Clone(overrides)
+	delete(normalized, "parallelism")
+	if _, set := normalized["max_concurrency"]; !set {
+		normalized["max_concurrency"] = parallelism
+	}
+	return normalized
+}
@@ -0,0 +1,65 @@
+package crawler
+
+import "errors"
+
+// Default run history values
+const (
+	// DefaultRunsIndex is the index holding the history of crawl runs
+	DefaultRunsIndex = "crawl_runs"
+	// DefaultBaselineRuns is the number of previous runs a run is compared against
+	DefaultBaselineRuns = 10
+	// DefaultMinBaselineRuns is the number of previous runs needed before runs are checked
+	DefaultMinBaselineRuns = 3
+	// DefaultAnomalyThreshold is how many standard deviations from the baseline make a run anomalous
+	DefaultAnomalyThreshold = 3.0
+	// DefaultAnomalyMinChange is the smallest change, relative to the baseline mean, that is flagged
+	DefaultAnomalyMinChange = 0.5
+)
+
+// RunsConfig holds crawl run history and anomaly detection settings.
+type RunsConfig struct {
+	// Enabled turns on recording of crawl runs
+	Enabled bool `yaml:"enabled"`
+	// Index is the index the runs are stored in
+	Index string `yaml:"index"`
+	// BaselineRuns is the number of previous runs a run is compared against
+	BaselineRuns int `yaml:"baseline_runs"`
+	// MinBaselineRuns is the number of previous runs needed before runs are checked
+	MinBaselineRuns int `yaml:"min_baseline_runs"`
+	// Threshold is how many standard deviations from the baseline make a run anomalous
+	Threshold float64 `yaml:"threshold"`
+	// MinChange is the smallest change, relative to the baseline mean, that is flagged
+	MinChange float64 `yaml:"min_change"`
+}
+
+// NewRunsConfig returns the default run history configuration.
+func NewRunsConfig() RunsConfig {
+	return RunsConfig{
+		Enabled:         true,
+		Index:           DefaultRunsIndex,
+		BaselineRuns:    DefaultBaselineRuns,
+		MinBaselineRuns: DefaultMinBaselineRuns,
+		Threshold:       DefaultAnomalyThreshold,
+		MinChange:       DefaultAnomalyMinChange,
+	}
+}
+
+// Validate validates the run history configuration.
+func (c *RunsConfig) Validate() error {
+	if c.Index == "" {
+		return errors.New("runs index must not be empty")
+	}
+	if c.BaselineRuns < 1 {
+		return errors.New("runs baseline_runs must be positive")
+	}
+	if c.MinBaselineRuns < 1 || c.MinBaselineRuns > c.BaselineRuns {
+		return errors.New("runs min_baseline_runs must be between 1 and baseline_runs")
+	}
+	if c.Threshold <= 0 {
+		return errors.New("runs threshold must be positive")
+	}
+	if c.MinChange < 0 {
+		return errors.New("runs min_change must be non-negative")
+	}
+	return nil
+}
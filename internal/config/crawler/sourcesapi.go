@@ -0,0 +1,82 @@
+package crawler
+
+import (
+	"errors"
+	"time"
+)
+
+// Default sources API client values
+const (
+	// DefaultSourcesAPITimeout is the timeout of a single sources API request
+	DefaultSourcesAPITimeout = 30 * time.Second
+	// DefaultSourcesAPIMaxRetries is how often a failed sources API request is retried
+	DefaultSourcesAPIMaxRetries = 3
+	// DefaultSourcesAPIRetryInitialWait is the wait before the first retry
+	DefaultSourcesAPIRetryInitialWait = 500 * time.Millisecond
+	// DefaultSourcesAPIRetryMaxWait caps the wait between retries
+	DefaultSourcesAPIRetryMaxWait = 10 * time.Second
+	// DefaultSourcesAPIBreakerThreshold is how many failed requests in a row open the circuit
+	DefaultSourcesAPIBreakerThreshold = 5
+	// DefaultSourcesAPIBreakerCooldown is how long an open circuit rejects requests
+	DefaultSourcesAPIBreakerCooldown = 30 * time.Second
+)
+
+// SourcesAPIConfig holds the settings of the gosources API client.
+type SourcesAPIConfig struct {
+	// Token is sent as a bearer token
+	Token string `yaml:"token"`
+	// APIKey is sent in the X-API-Key header
+	APIKey string `yaml:"api_key"`
+	// Timeout is the timeout of a single request
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxRetries is how often a request that failed to connect or got a 5xx response is retried
+	MaxRetries int `yaml:"max_retries"`
+	// RetryInitialWait is the wait before the first retry; later waits double
+	RetryInitialWait time.Duration `yaml:"retry_initial_wait"`
+	// RetryMaxWait caps the wait between retries
+	RetryMaxWait time.Duration `yaml:"retry_max_wait"`
+	// BreakerThreshold is how many failed requests in a row open the circuit; 0 disables the breaker
+	BreakerThreshold int `yaml:"breaker_threshold"`
+	// BreakerCooldown is how long an open circuit rejects requests before one is let through
+	BreakerCooldown time.Duration `yaml:"breaker_cooldown"`
+	// PageSize is the number of sources listed per request; 0 lists them all at once
+	PageSize int `yaml:"page_size"`
+}
+
+// NewSourcesAPIConfig returns the default sources API client configuration.
+func NewSourcesAPIConfig() SourcesAPIConfig {
+	return SourcesAPIConfig{
+		Timeout:          DefaultSourcesAPITimeout,
+		MaxRetries:       DefaultSourcesAPIMaxRetries,
+		RetryInitialWait: DefaultSourcesAPIRetryInitialWait,
+		RetryMaxWait:     DefaultSourcesAPIRetryMaxWait,
+		BreakerThreshold: DefaultSourcesAPIBreakerThreshold,
+		BreakerCooldown:  DefaultSourcesAPIBreakerCooldown,
+	}
+}
+
+// Validate validates the sources API client configuration.
+func (c *SourcesAPIConfig) Validate() error {
+	if c.Token != "" && c.APIKey != "" {
+		return errors.New("sources_api token and api_key are mutually exclusive")
+	}
+	if c.Timeout <= 0 {
+		return errors.New("sources_api timeout must be positive")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("sources_api max_retries must be non-negative")
+	}
+	if c.RetryInitialWait <= 0 || c.RetryMaxWait < c.RetryInitialWait {
+		return errors.New("sources_api retry_initial_wait must be positive and at most retry_max_wait")
+	}
+	if c.BreakerThreshold < 0 {
+		return errors.New("sources_api breaker_threshold must be non-negative")
+	}
+	if c.BreakerThreshold > 0 && c.BreakerCooldown <= 0 {
+		return errors.New("sources_api breaker_cooldown must be positive")
+	}
+	if c.PageSize < 0 {
+		return errors.New("sources_api page_size must be non-negative")
+	}
+	return nil
+}
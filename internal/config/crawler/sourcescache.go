@@ -0,0 +1,48 @@
+package crawler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultSourcesCacheMaxStaleness is how old cached sources may be and still be used
+const DefaultSourcesCacheMaxStaleness = 72 * time.Hour
+
+// SourcesCacheConfig holds the settings of the on-disk copy of the sources API
+// response that is used while the API is unreachable.
+type SourcesCacheConfig struct {
+	// Enabled turns on caching of the sources API response
+	Enabled bool `yaml:"enabled"`
+	// Path is the file the response is cached in
+	Path string `yaml:"path"`
+	// MaxStaleness is how old cached sources may be and still be used; 0 means no limit
+	MaxStaleness time.Duration `yaml:"max_staleness"`
+}
+
+// NewSourcesCacheConfig returns the default sources cache configuration. The
+// cache lives in the user cache directory, or the working directory when there
+// is none.
+func NewSourcesCacheConfig() SourcesCacheConfig {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = "."
+	}
+	return SourcesCacheConfig{
+		Enabled:      true,
+		Path:         filepath.Join(dir, "gocrawl", "sources.json"),
+		MaxStaleness: DefaultSourcesCacheMaxStaleness,
+	}
+}
+
+// Validate validates the sources cache configuration.
+func (c *SourcesCacheConfig) Validate() error {
+	if c.Enabled && c.Path == "" {
+		return errors.New("sources_cache path must not be empty")
+	}
+	if c.MaxStaleness < 0 {
+		return errors.New("sources_cache max_staleness must be non-negative")
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Origins of configuration values, from lowest to highest precedence
+const (
+	// OriginDefault is a built-in default
+	OriginDefault = "default"
+	// OriginFile is the base configuration file
+	OriginFile = "file"
+	// OriginProfile is the file of the selected profile
+	OriginProfile = "profile"
+	// OriginEnv is an environment variable
+	OriginEnv = "env"
+	// OriginFlag is a command-line flag
+	OriginFlag = "flag"
+	// OriginSource is the crawler block of a source
+	OriginSource = "source"
+)
+
+// keyAliases are configuration keys that can also be set under another name.
+var keyAliases = map[string]string{
+	"crawler.max_concurrency": "crawler.parallelism",
+}
+
+var (
+	envBindingsMu sync.Mutex
+	// envBindings holds the environment variables explicitly bound to keys
+	envBindings = make(map[string][]string)
+)
+
+// BindEnv binds environment variables to a key in Viper and records the
+// binding, so that Origins can tell when a value came from one of them.
+func BindEnv(key string, envVars ...string) error {
+	if err := viper.BindEnv(append([]string{key}, envVars...)...); err != nil {
+		return err
+	}
+	envBindingsMu.Lock()
+	defer envBindingsMu.Unlock()
+	envBindings[key] = append(envBindings[key], envVars...)
+	return nil
+}
+
+// Setting is a flattened configuration value.
+type Setting struct {
+	// Key is the dotted configuration key, e.g. crawler.tls.min_version
+	Key string
+	// Value is the formatted value
+	Value string
+	// Origin is where the value comes from, e.g. "file" or "env CRAWLER_MAX_DEPTH"
+	Origin string
+}
+
+// Origins tells which layer of the configuration set each value.
+type Origins struct {
+	file        map[string]bool
+	profile     map[string]bool
+	profileName string
+	flags       map[string]string
+	source      map[string]bool
+	sourceName  string
+}
+
+// NewOrigins reads the base configuration file and the profile file, either
+// of which may be empty, to track which keys they set.
+func NewOrigins(configFile, profileName, profileFile string) (*Origins, error) {
+	file, err := fileKeys(configFile)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := fileKeys(profileFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Origins{
+		file:        file,
+		profile:     profile,
+		profileName: profileName,
+		flags:       make(map[string]string),
+		source:      make(map[string]bool),
+	}, nil
+}
+
+// SetFlag records that a command-line flag set a key.
+func (o *Origins) SetFlag(key, flag string) {
+	o.flags[key] = flag
+}
+
+// SetSource records the keys set by a source, e.g. crawler.user_agent from
+// its crawler block or crawler.max_depth from its own max_depth.
+func (o *Origins) SetSource(name string, keys []string) {
+	o.sourceName = name
+	for _, key := range keys {
+		o.source[key] = true
+	}
+}
+
+// Origin returns where the value of a key comes from. Layers are checked in
+// order of precedence: source, flag, environment, profile, file and default.
+func (o *Origins) Origin(key string) string {
+	// An item of a list comes from wherever the list is set
+	key, _, _ = strings.Cut(key, "[")
+	keys := []string{key}
+	if alias, ok := keyAliases[key]; ok {
+		keys = append(keys, alias)
+	}
+
+	for _, k := range keys {
+		if o.source[k] {
+			return OriginSource + " " + o.sourceName
+		}
+	}
+	for _, k := range keys {
+		if flag, ok := o.flags[k]; ok {
+			return OriginFlag + " --" + flag
+		}
+	}
+	for _, k := range keys {
+		if envVar := setEnvVar(k); envVar != "" {
+			return OriginEnv + " " + envVar
+		}
+	}
+	for _, k := range keys {
+		if o.profile[k] {
+			return OriginProfile + " " + o.profileName
+		}
+	}
+	for _, k := range keys {
+		if o.file[k] {
+			return OriginFile
+		}
+	}
+	return OriginDefault
+}
+
+// setEnvVar returns the environment variable that sets a key, if any: one
+// bound with BindEnv or the one read automatically, e.g. CRAWLER_MAX_DEPTH.
+func setEnvVar(key string) string {
+	envBindingsMu.Lock()
+	envVars := append([]string(nil), envBindings[key]...)
+	envBindingsMu.Unlock()
+	envVars = append(envVars, strings.ToUpper(strings.ReplaceAll(key, ".", "_")))
+
+	for _, envVar := range envVars {
+		if value, ok := os.LookupEnv(envVar); ok && value != "" {
+			return envVar
+		}
+	}
+	return ""
+}
+
+// fileKeys returns the keys set in a configuration file, including the keys
+// of sections, since a section can set a map as a whole.
+func fileKeys(path string) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	if path == "" {
+		return keys, nil
+	}
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	for _, key := range v.AllKeys() {
+		keys[key] = true
+		for i := strings.LastIndex(key, "."); i > 0; i = strings.LastIndex(key[:i], ".") {
+			keys[key[:i]] = true
+		}
+	}
+	return keys, nil
+}
+
+// Settings flattens a configuration into dotted keys named after its yaml
+// tags, prefixed with prefix, e.g. Settings("crawler", cfg.Crawler, origins).
+// Secrets are masked. The origins parameter is optional and can be nil.
+func Settings(prefix string, cfg any, origins *Origins) []Setting {
+	var settings []Setting
+	flattenSetting(prefix, reflect.ValueOf(cfg), &settings)
+	for i := range settings {
+		if isSecret(settings[i].Key) && settings[i].Value != "" {
+			settings[i].Value = "********"
+		}
+		if origins != nil {
+			settings[i].Origin = origins.Origin(settings[i].Key)
+		}
+	}
+	return settings
+}
+
+// flattenSetting adds the values of v to settings, one per leaf.
+func flattenSetting(key string, v reflect.Value, settings *[]Setting) {
+	if !v.IsValid() {
+		*settings = append(*settings, Setting{Key: key})
+		return
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			*settings = append(*settings, Setting{Key: key})
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Duration:
+		*settings = append(*settings, Setting{Key: key, Value: value.String()})
+		return
+	case time.Time:
+		*settings = append(*settings, Setting{Key: key, Value: value.Format(time.RFC3339)})
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if !field.IsExported() || name == "" || name == "-" {
+				continue
+			}
+			flattenSetting(joinKey(key, name), v.Field(i), settings)
+		}
+	case reflect.Map:
+		if v.Len() == 0 {
+			*settings = append(*settings, Setting{Key: key})
+			return
+		}
+		names := make([]string, 0, v.Len())
+		values := make(map[string]reflect.Value, v.Len())
+		for _, k := range v.MapKeys() {
+			name := fmt.Sprint(k.Interface())
+			names = append(names, name)
+			values[name] = v.MapIndex(k)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			flattenSetting(joinKey(key, name), values[name], settings)
+		}
+	case reflect.Slice, reflect.Array:
+		if elem := v.Type().Elem(); v.Len() > 0 && elem.Kind() == reflect.Struct && elem != reflect.TypeFor[time.Time]() {
+			// Lists of sections, e.g. server.api_keys, are flattened per item
+			for i := range v.Len() {
+				flattenSetting(fmt.Sprintf("%s[%d]", key, i), v.Index(i), settings)
+			}
+			return
+		}
+		items := make([]string, 0, v.Len())
+		for i := range v.Len() {
+			items = append(items, fmt.Sprint(v.Index(i).Interface()))
+		}
+		*settings = append(*settings, Setting{Key: key, Value: "[" + strings.Join(items, ", ") + "]"})
+	default:
+		*settings = append(*settings, Setting{Key: key, Value: fmt.Sprint(v.Interface())})
+	}
+}
+
+// joinKey adds a name to a dotted key.
+func joinKey(key, name string) string {
+	if key == "" {
+		return name
+	}
+	return key + "." + name
+}
+
+// isSecret reports whether a key holds a credential.
+func isSecret(key string) bool {
+	name := strings.ToLower(key[strings.LastIndex(key, ".")+1:])
+	return name == "password" || name == "token" || name == "api_key" || name == "webhook_url"
+}
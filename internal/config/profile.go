@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ErrProfileNotFound indicates a configuration profile without a profile file
+var ErrProfileNotFound = errors.New("configuration profile not found")
+
+// ProfileFile returns the file of a configuration profile: the base
+// configuration file name with the profile added before its extension, e.g.
+// config.prod.yaml for config.yaml. Without a base file, the profile is looked
+// up as config.<profile>.yaml in the working directory.
+func ProfileFile(configFile, profile string) string {
+	if configFile == "" {
+		return "config." + profile + ".yaml"
+	}
+	ext := filepath.Ext(configFile)
+	return strings.TrimSuffix(configFile, ext) + "." + profile + ext
+}
+
+// MergeProfile merges a configuration profile over the configuration read by
+// v. Values set in the profile replace those of the base file; everything else
+// is kept. It returns the path of the profile file.
+func MergeProfile(v *viper.Viper, profile string) (string, error) {
+	path := ProfileFile(v.ConfigFileUsed(), profile)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("%w: %s (%s)", ErrProfileNotFound, profile, path)
+	}
+
+	profileViper := viper.New()
+	profileViper.SetConfigFile(path)
+	if err := profileViper.ReadInConfig(); err != nil {
+		return "", fmt.Errorf("failed to read profile %s: %w", profile, err)
+	}
+	if err := v.MergeConfigMap(profileViper.AllSettings()); err != nil {
+		return "", fmt.Errorf("failed to merge profile %s: %w", profile, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,366 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/sources/loader"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is how serious a lint issue is.
+type Severity string
+
+const (
+	// SeverityError is an issue that makes a value be ignored or rejected
+	SeverityError Severity = "error"
+	// SeverityWarning is a deprecated key or a suspicious value
+	SeverityWarning Severity = "warning"
+)
+
+// maxSuggestionDistance is the largest edit distance of a suggested key.
+const maxSuggestionDistance = 2
+
+// ErrInvalid indicates files with lint errors.
+var ErrInvalid = errors.New("files have errors")
+
+// Issue is a problem found in a file.
+type Issue struct {
+	// Line and Column locate the key or value, starting at 1
+	Line   int
+	Column int
+	// Key is the dotted key of the value, e.g. crawler.max_depth or sources[2].url
+	Key string
+	// Severity tells errors from warnings
+	Severity Severity
+	// Message describes the problem
+	Message string
+}
+
+// String formats the issue as line:column: severity: key: message.
+func (i Issue) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s: %s", i.Line, i.Column, i.Severity, i.Key, i.Message)
+}
+
+// WriteIssues prints the issues of a file, one per line prefixed with the
+// file path, as compilers do.
+func WriteIssues(w io.Writer, path string, issues []Issue) {
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s:%s\n", path, issue)
+	}
+}
+
+// HasErrors reports whether any of the issues is an error.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint checks a YAML document against a schema. It reports unknown keys,
+// values of the wrong type, deprecated keys and values out of their sensible
+// range, in the order they appear in the document.
+func Lint(data []byte, s *Schema) ([]Issue, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	l := &linter{}
+	if len(document.Content) > 0 {
+		l.walk(document.Content[0], s, "")
+	}
+	sortIssues(l.issues)
+	return l.issues, nil
+}
+
+// LintConfigFile checks a configuration file against the configuration schema.
+func LintConfigFile(path string) ([]Issue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return Lint(data, Config())
+}
+
+// LintSourcesFile checks a sources file against the sources schema. Sources
+// that the loader would skip, such as a source without a name or with an
+// unknown extraction mode, are reported as errors too.
+func LintSourcesFile(path string) ([]Issue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sources file: %w", err)
+	}
+	issues, err := Lint(data, Sources())
+	if err != nil {
+		return nil, err
+	}
+
+	var document struct {
+		Sources []yaml.Node `yaml:"sources"`
+	}
+	if unmarshalErr := yaml.Unmarshal(data, &document); unmarshalErr != nil {
+		// The type error is already reported by Lint
+		return issues, nil //nolint:nilerr // reported as an issue
+	}
+	if len(document.Sources) == 0 {
+		return append(issues, Issue{Line: 1, Column: 1, Key: "sources", Severity: SeverityError,
+			Message: loader.ErrNoSources.Error()}), nil
+	}
+	for i := range document.Sources {
+		node := &document.Sources[i]
+		var src map[string]any
+		if node.Kind != yaml.MappingNode || node.Decode(&src) != nil {
+			continue
+		}
+		if _, validateErr := loader.ValidateSource(src); validateErr != nil {
+			issues = append(issues, Issue{
+				Line: node.Line, Column: node.Column, Key: fmt.Sprintf("sources[%d]", i),
+				Severity: SeverityError, Message: "skipped by the loader: " + validateErr.Error(),
+			})
+		}
+	}
+	sortIssues(issues)
+	return issues, nil
+}
+
+// linter collects the issues found while walking a document.
+type linter struct {
+	issues []Issue
+}
+
+// add records an issue at a node.
+func (l *linter) add(node *yaml.Node, key string, severity Severity, format string, args ...any) {
+	l.issues = append(l.issues, Issue{
+		Line:     node.Line,
+		Column:   node.Column,
+		Key:      key,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// walk checks a node and its children against a schema.
+func (l *linter) walk(node *yaml.Node, s *Schema, key string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if s == nil || node.Tag == "!!null" {
+		return
+	}
+
+	switch s.Type {
+	case TypeObject:
+		l.walkObject(node, s, key)
+	case TypeArray:
+		if node.Kind != yaml.SequenceNode {
+			l.add(node, key, SeverityError, "expected a list, got %s", describe(node))
+			return
+		}
+		for i, item := range node.Content {
+			l.walk(item, s.Items, fmt.Sprintf("%s[%d]", key, i))
+		}
+	case "":
+		// Any value is accepted
+	default:
+		if node.Kind != yaml.ScalarNode {
+			l.add(node, key, SeverityError, "expected %s, got %s", expected(s), describe(node))
+			return
+		}
+		l.checkScalar(node, s, key)
+	}
+}
+
+// walkObject checks the keys of a mapping.
+func (l *linter) walkObject(node *yaml.Node, s *Schema, key string) {
+	if node.Kind != yaml.MappingNode {
+		l.add(node, key, SeverityError, "expected a mapping, got %s", describe(node))
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if keyNode.Value == "<<" {
+			// Merge keys are checked where their anchor is defined
+			continue
+		}
+		childKey := joinKey(key, keyNode.Value)
+		property := s.Property(keyNode.Value)
+		if property == nil {
+			if suggestion := suggest(keyNode.Value, s); suggestion != "" {
+				l.add(keyNode, childKey, SeverityError, "unknown key, did you mean %s?", suggestion)
+			} else {
+				l.add(keyNode, childKey, SeverityError, "unknown key")
+			}
+			continue
+		}
+		if property.Deprecated {
+			l.add(keyNode, childKey, SeverityWarning, "deprecated key: %s",
+				strings.TrimPrefix(property.Description, "Deprecated: "))
+		}
+		l.walk(valueNode, property, childKey)
+	}
+}
+
+// checkScalar checks the type, allowed values and range of a scalar. Quoted
+// numbers and booleans are accepted, as Viper converts them.
+func (l *linter) checkScalar(node *yaml.Node, s *Schema, key string) {
+	value := node.Value
+	var number float64
+	var isNumber bool
+
+	switch s.Type {
+	case TypeInteger:
+		n, err := strconv.ParseInt(value, 0, 64)
+		if err != nil {
+			l.add(node, key, SeverityError, "expected an integer, got %q", value)
+			return
+		}
+		number, isNumber = float64(n), true
+	case TypeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			l.add(node, key, SeverityError, "expected a number, got %q", value)
+			return
+		}
+		number, isNumber = n, true
+	case TypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil && node.Tag != "!!bool" {
+			l.add(node, key, SeverityError, "expected true or false, got %q", value)
+			return
+		}
+	case TypeString:
+		if !l.checkFormat(node, s, key) {
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 && !containsFold(s.Enum, value) {
+		l.add(node, key, SeverityError, "invalid value %q, must be one of %s", value, strings.Join(s.Enum, ", "))
+		return
+	}
+	if !isNumber {
+		return
+	}
+	if s.Minimum != nil && number < *s.Minimum {
+		l.add(node, key, SeverityWarning, "suspicious value %s, expected at least %v", value, *s.Minimum)
+	}
+	if s.Maximum != nil && number > *s.Maximum {
+		l.add(node, key, SeverityWarning, "suspicious value %s, expected at most %v", value, *s.Maximum)
+	}
+}
+
+// checkFormat checks a string against its format and reports whether it is valid.
+func (l *linter) checkFormat(node *yaml.Node, s *Schema, key string) bool {
+	switch s.Format {
+	case FormatDuration:
+		if _, err := time.ParseDuration(node.Value); err == nil {
+			return true
+		}
+		if _, err := strconv.ParseInt(node.Value, 10, 64); err == nil {
+			return true
+		}
+		l.add(node, key, SeverityError, "expected a duration such as 30s or 5m, got %q", node.Value)
+		return false
+	case FormatDateTime:
+		if _, err := time.Parse(time.RFC3339, node.Value); err != nil {
+			l.add(node, key, SeverityError, "expected an RFC 3339 time, got %q", node.Value)
+			return false
+		}
+	}
+	return true
+}
+
+// expected describes the type a schema expects.
+func expected(s *Schema) string {
+	switch {
+	case s.Format == FormatDuration:
+		return "a duration"
+	case s.Type == TypeInteger:
+		return "an integer"
+	case s.Type == TypeBoolean:
+		return "true or false"
+	default:
+		return "a " + s.Type
+	}
+}
+
+// describe names the kind of a node for messages.
+func describe(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return strconv.Quote(node.Value)
+	}
+}
+
+// suggest returns the property of a schema closest to an unknown key.
+func suggest(key string, s *Schema) string {
+	best, bestDistance := "", maxSuggestionDistance+1
+	for name := range s.Properties {
+		if distance := editDistance(strings.ToLower(key), strings.ToLower(name)); distance < bestDistance ||
+			(distance == bestDistance && name < best) {
+			best, bestDistance = name, distance
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// containsFold reports whether values contains value, ignoring case.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinKey adds a name to a dotted key.
+func joinKey(key, name string) string {
+	if key == "" {
+		return name
+	}
+	return key + "." + name
+}
+
+// sortIssues orders issues by their position in the file.
+func sortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+}
@@ -0,0 +1,326 @@
+// Package schema generates JSON Schemas of the configuration file and of the
+// sources file from the Go types they are loaded into, and lints YAML files
+// against them: Viper and mapstructure silently ignore keys they do not know.
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/config"
+	"github.com/jonesrussell/gocrawl/internal/config/crawler"
+	serverconfig "github.com/jonesrussell/gocrawl/internal/config/server"
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/constants"
+	"github.com/jonesrussell/gocrawl/internal/sources/loader"
+)
+
+// Draft is the JSON Schema version of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSON Schema types
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+// JSON Schema formats of string values
+const (
+	// FormatDuration is a Go duration such as 30s; a number of nanoseconds is accepted too
+	FormatDuration = "duration"
+	// FormatDateTime is an RFC 3339 timestamp
+	FormatDateTime = "date-time"
+)
+
+var (
+	durationType = reflect.TypeFor[time.Duration]()
+	timeType     = reflect.TypeFor[time.Time]()
+)
+
+// Schema is a JSON Schema. An object without AdditionalProperties is closed:
+// keys other than its properties are unknown.
+type Schema struct {
+	// Schema is the JSON Schema version, set on the root schema
+	Schema string `json:"$schema,omitempty"`
+	// Title names the schema
+	Title string `json:"title,omitempty"`
+	// Description explains the value; deprecated values say what replaces them
+	Description string `json:"description,omitempty"`
+	// Type is the JSON type of the value; empty allows any value
+	Type string `json:"type,omitempty"`
+	// Format refines the string type, e.g. FormatDuration
+	Format string `json:"format,omitempty"`
+	// Enum lists the allowed values
+	Enum []string `json:"enum,omitempty"`
+	// Minimum is the smallest sensible number
+	Minimum *float64 `json:"minimum,omitempty"`
+	// Maximum is the largest sensible number
+	Maximum *float64 `json:"maximum,omitempty"`
+	// Properties are the keys of an object
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// AdditionalProperties is the schema of the other keys of an object
+	AdditionalProperties *Schema `json:"-"`
+	// Items is the schema of the items of an array
+	Items *Schema `json:"items,omitempty"`
+	// Deprecated marks a key that is still read but should be replaced
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+// MarshalJSON encodes the schema, writing additionalProperties: false for
+// closed objects.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	out := struct {
+		*plain
+		AdditionalProperties any `json:"additionalProperties,omitempty"`
+	}{plain: (*plain)(s)}
+	switch {
+	case s.AdditionalProperties != nil:
+		out.AdditionalProperties = s.AdditionalProperties
+	case s.Type == TypeObject:
+		out.AdditionalProperties = false
+	}
+	return json.Marshal(out)
+}
+
+// Property returns the schema of a key of an object, matching the key
+// case-insensitively as Viper does, or nil when the key is unknown.
+func (s *Schema) Property(key string) *Schema {
+	if property, ok := s.Properties[key]; ok {
+		return property
+	}
+	for name, property := range s.Properties {
+		if strings.EqualFold(name, key) {
+			return property
+		}
+	}
+	return s.AdditionalProperties
+}
+
+// lookup returns the schema at a dotted path below s, or nil.
+func (s *Schema) lookup(path string) *Schema {
+	for name := range strings.SplitSeq(path, ".") {
+		if s == nil {
+			return nil
+		}
+		if name == "[]" {
+			s = s.Items
+			continue
+		}
+		s = s.Properties[name]
+	}
+	return s
+}
+
+// FromType generates the schema of a Go type. Struct fields are named after
+// the given struct tag, e.g. yaml or mapstructure, falling back to the yaml
+// tag and then to the lowercased field name.
+func FromType(t reflect.Type, tag string) *Schema {
+	switch t {
+	case durationType:
+		return &Schema{Type: TypeString, Format: FormatDuration}
+	case timeType:
+		return &Schema{Type: TypeString, Format: FormatDateTime}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return FromType(t.Elem(), tag)
+	case reflect.Struct:
+		s := &Schema{Type: TypeObject, Properties: make(map[string]*Schema)}
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := fieldName(field, tag)
+			if name == "-" {
+				continue
+			}
+			s.Properties[name] = FromType(field.Type, tag)
+		}
+		return s
+	case reflect.Map:
+		return &Schema{Type: TypeObject, AdditionalProperties: FromType(t.Elem(), tag)}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: TypeArray, Items: FromType(t.Elem(), tag)}
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeNumber}
+	case reflect.String:
+		return &Schema{Type: TypeString}
+	default:
+		// Interfaces accept any value
+		return &Schema{}
+	}
+}
+
+// fieldName returns the key of a struct field.
+func fieldName(field reflect.StructField, tag string) string {
+	for _, key := range []string{tag, "yaml"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(key), ","); name != "" {
+			return name
+		}
+	}
+	return strings.ToLower(field.Name)
+}
+
+// Config returns the schema of the configuration file.
+func Config() *Schema {
+	s := FromType(reflect.TypeFor[config.Config](), "yaml")
+	s.Schema = Draft
+	s.Title = "gocrawl configuration"
+	// The command is set from the command line, not the file
+	delete(s.Properties, "command")
+	s.Properties["crawler"] = crawlerSchema()
+
+	logger := s.Properties["logger"]
+	logger.Properties["development"] = &Schema{Type: TypeBoolean, Description: "Human-friendly log output"}
+	logger.Properties["enable_color"] = &Schema{Type: TypeBoolean, Description: "Colored console output"}
+	logger.Properties["output_paths"] = &Schema{Type: TypeArray, Items: &Schema{Type: TypeString}}
+
+	server := s.Properties["server"]
+	for old, key := range map[string]string{
+		"readTimeout":  "read_timeout",
+		"writeTimeout": "write_timeout",
+		"idleTimeout":  "idle_timeout",
+	} {
+		server.Properties[old] = deprecated(&Schema{Type: TypeString, Format: FormatDuration}, "use "+key)
+	}
+	server.Properties["security"] = deprecated(&Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"enabled": {Type: TypeBoolean},
+			"apiKey":  {Type: TypeString},
+		},
+	}, "use security_enabled and api_key")
+	server.Properties["port"].Minimum, server.Properties["port"].Maximum = bound(0), bound(65535)
+	server.lookup("api_keys.[].scopes.[]").Enum = serverconfig.Scopes
+	server.lookup("api_keys.[].rate_limit").Minimum = bound(0)
+
+	elasticsearch := s.Properties["elasticsearch"]
+	elasticsearch.Properties["address"] = deprecated(&Schema{Type: TypeString}, "use addresses")
+	elasticsearch.Properties["index_prefix"] = deprecated(&Schema{Type: TypeString}, "use index_name")
+	setBounds(elasticsearch, map[string][2]*float64{
+		"bulk_size":         {bound(1), nil},
+		"retry.max_retries": {bound(0), nil},
+	})
+
+	return s
+}
+
+// crawlerSchema returns the schema of the crawler section, which is also the
+// schema of the crawler block of a source.
+func crawlerSchema() *Schema {
+	s := FromType(reflect.TypeFor[crawler.Config](), "yaml")
+	s.Properties["parallelism"] = &Schema{Type: TypeInteger, Description: "Alias of max_concurrency"}
+	s.Properties["source_file"] = deprecated(&Schema{Type: TypeString},
+		"no longer read: use sources_file for a sources file, or sources_api_url")
+
+	s.lookup("dedup.mode").Enum = []string{crawler.DedupModeSkip, crawler.DedupModeLink}
+	s.lookup("alerts.sinks.[]").Enum = []string{crawler.AlertSinkLog, crawler.AlertSinkWebhook, crawler.AlertSinkEvents}
+	const (
+		tls13   = 0x0304
+		percent = 100
+	)
+	setBounds(s, map[string][2]*float64{
+		"max_concurrency":               {bound(1), nil},
+		"parallelism":                   {bound(1), nil},
+		"max_depth":                     {bound(0), nil},
+		"max_retries":                   {bound(0), nil},
+		"max_redirects":                 {bound(0), nil},
+		"max_body_size":                 {bound(0), nil},
+		"tls.min_version":               {bound(0), bound(tls13)},
+		"tls.max_version":               {bound(0), bound(tls13)},
+		"dedup.max_distance":            {bound(0), bound(crawler.MaxDedupDistance)},
+		"dedup.min_words":               {bound(0), nil},
+		"links.batch_size":              {bound(1), nil},
+		"enrichment.max_keyphrases":     {bound(1), nil},
+		"enrichment.words_per_minute":   {bound(1), nil},
+		"monitor.samples":               {bound(1), nil},
+		"monitor.title_threshold":       {bound(0), bound(percent)},
+		"monitor.body_threshold":        {bound(0), bound(percent)},
+		"runs.baseline_runs":            {bound(1), nil},
+		"runs.min_baseline_runs":        {bound(1), nil},
+		"sources_api.max_retries":       {bound(0), nil},
+		"sources_api.breaker_threshold": {bound(0), nil},
+		"sources_api.page_size":         {bound(0), nil},
+	})
+	return s
+}
+
+// Sources returns the schema of a sources file.
+func Sources() *Schema {
+	source := FromType(reflect.TypeFor[loader.Config](), "mapstructure")
+	source.Properties["crawler"] = crawlerSchema()
+	source.Properties["created_at"] = &Schema{Type: TypeString, Format: FormatDateTime,
+		Description: "When the API copy was created, recorded by sources pull"}
+	source.Properties["updated_at"] = &Schema{Type: TypeString, Format: FormatDateTime,
+		Description: "When the API copy was last updated, recorded by sources pull"}
+	source.Properties["sync_hash"] = &Schema{Type: TypeString,
+		Description: "Hash of the settings as last pushed or pulled, recorded by sources push and pull"}
+	source.Properties["index"] = deprecated(source.Properties["index"], "use page_index")
+
+	source.Properties["extraction"].Enum = []string{
+		configtypes.ExtractionSelectors, configtypes.ExtractionAuto, configtypes.ExtractionReadability,
+	}
+	source.Properties["structured_data"].Enum = []string{
+		configtypes.StructuredDataFallback, configtypes.StructuredDataPrefer, configtypes.StructuredDataIgnore,
+	}
+	source.Properties["rules"].Items.Properties["action"].Enum = []string{
+		constants.ActionAllow, constants.ActionDisallow,
+	}
+	source.Properties["enrichers"].Items.Enum = []string{
+		configtypes.EnricherKeyphrases, configtypes.EnricherGazetteer, configtypes.EnricherReadingTime,
+	}
+	const (
+		maxLatitude  = 90
+		maxLongitude = 180
+	)
+	setBounds(source, map[string][2]*float64{
+		"max_depth": {bound(0), nil},
+		"max_docs":  {bound(0), nil},
+		"latitude":  {bound(-maxLatitude), bound(maxLatitude)},
+		"longitude": {bound(-maxLongitude), bound(maxLongitude)},
+	})
+
+	return &Schema{
+		Schema: Draft,
+		Title:  "gocrawl sources",
+		Type:   TypeObject,
+		Properties: map[string]*Schema{
+			"sources": {Type: TypeArray, Items: source},
+		},
+	}
+}
+
+// deprecated marks a schema as deprecated, saying what replaces it.
+func deprecated(s *Schema, replacement string) *Schema {
+	s.Deprecated = true
+	s.Description = "Deprecated: " + replacement
+	return s
+}
+
+// setBounds sets the sensible minimum and maximum of numbers by dotted path.
+func setBounds(s *Schema, bounds map[string][2]*float64) {
+	for path, minMax := range bounds {
+		if property := s.lookup(path); property != nil {
+			property.Minimum, property.Maximum = minMax[0], minMax[1]
+		}
+	}
+}
+
+// bound returns a pointer to a bound.
+func bound(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,113 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonesrussell/gocrawl/internal/config/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("example config is valid", func(t *testing.T) {
+		t.Parallel()
+		issues, err := schema.LintConfigFile(filepath.Join("..", "..", "..", "config.example.yaml"))
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("reports issues with their position", func(t *testing.T) {
+		t.Parallel()
+		data := []byte(`server:
+  security:
+    enabled: true
+crawler:
+  source_file: sources.yml
+  parallelism: 0
+  user_agnt: gocrawl
+  max_depth: deep
+  request_timeout: soon
+  dedup:
+    mode: drop
+`)
+		issues, err := schema.Lint(data, schema.Config())
+		require.NoError(t, err)
+		require.Len(t, issues, 7)
+
+		assert.Equal(t, "2:3: warning: server.security: deprecated key: use security_enabled and api_key",
+			issues[0].String())
+		assert.Equal(t, "crawler.source_file", issues[1].Key)
+		assert.Equal(t, schema.SeverityWarning, issues[1].Severity)
+		assert.Equal(t, "6:16: warning: crawler.parallelism: suspicious value 0, expected at least 1",
+			issues[2].String())
+		assert.Equal(t, "7:3: error: crawler.user_agnt: unknown key, did you mean user_agent?",
+			issues[3].String())
+		assert.Equal(t, "crawler.max_depth", issues[4].Key)
+		assert.Equal(t, "crawler.request_timeout", issues[5].Key)
+		assert.Equal(t, "crawler.dedup.mode", issues[6].Key)
+		assert.True(t, schema.HasErrors(issues))
+	})
+
+	t.Run("accepts values Viper converts", func(t *testing.T) {
+		t.Parallel()
+		data := []byte(`app:
+  debug: "true"
+crawler:
+  max_depth: "3"
+  delay: 1000000000
+  groups:
+    news:
+      min_rate_limit: 2s
+      rate_limit: 500ms
+`)
+		issues, err := schema.Lint(data, schema.Config())
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+}
+
+func TestLintSourcesFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sources.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`sources:
+  - name: Example
+    url: https://example.com
+    index: example_pages
+    crawler:
+      max_concurrency: 4
+  - url: https://unnamed.example.com
+    extraction: smart
+`), 0o600))
+
+	issues, err := schema.LintSourcesFile(path)
+	require.NoError(t, err)
+	require.Len(t, issues, 3)
+
+	assert.Equal(t, "sources[0].index", issues[0].Key)
+	assert.Equal(t, schema.SeverityWarning, issues[0].Severity)
+	assert.Equal(t, "7:5: error: sources[1]: skipped by the loader: missing required field: name",
+		issues[1].String())
+	assert.Equal(t, "sources[1].extraction", issues[2].Key)
+	assert.Equal(t, schema.SeverityError, issues[2].Severity)
+}
+
+func TestSchemaJSON(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(schema.Sources())
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	source := decoded["properties"].(map[string]any)["sources"].(map[string]any)["items"].(map[string]any)
+	assert.Equal(t, false, source["additionalProperties"])
+	properties := source["properties"].(map[string]any)
+	assert.Contains(t, properties, "crawler")
+	assert.Equal(t, true, properties["index"].(map[string]any)["deprecated"])
+}
@@ -2,8 +2,11 @@
 package server
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
@@ -11,6 +14,88 @@ import (
 // APIKeyParts is the number of parts in an API key (id:key)
 const APIKeyParts = 2
 
+// API key scopes
+const (
+	// ScopeSearchRead allows searching the indexed content
+	ScopeSearchRead = "search:read"
+	// ScopeJobsWrite allows creating and changing crawl jobs
+	ScopeJobsWrite = "jobs:write"
+	// ScopeAdmin allows everything
+	ScopeAdmin = "admin"
+)
+
+// APIKeyHashPrefix prefixes the hex-encoded SHA-256 hash of an API key.
+const APIKeyHashPrefix = "sha256:"
+
+// Scopes lists the known API key scopes.
+var Scopes = []string{ScopeSearchRead, ScopeJobsWrite, ScopeAdmin}
+
+// APIKeyConfig is an API key accepted by the server. Only the hash of the key
+// is stored; the key itself is shown once, when it is generated.
+type APIKeyConfig struct {
+	// ID names the key in request logs
+	ID string `yaml:"id" mapstructure:"id"`
+	// Hash is the SHA-256 hash of the key, as returned by HashAPIKey
+	Hash string `yaml:"hash" mapstructure:"hash"`
+	// Scopes are what the key allows, e.g. search:read
+	Scopes []string `yaml:"scopes" mapstructure:"scopes"`
+	// RateLimit is the number of requests allowed per minute; 0 applies the
+	// per-client limit instead
+	RateLimit int `yaml:"rate_limit,omitempty" mapstructure:"rate_limit"`
+	// ExpiresAt is when the key stops being accepted; zero never expires
+	ExpiresAt time.Time `yaml:"expires_at,omitempty" mapstructure:"expires_at"`
+}
+
+// HashAPIKey returns the hash of an API key as stored in APIKeyConfig.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return APIKeyHashPrefix + hex.EncodeToString(sum[:])
+}
+
+// Validate checks if the API key configuration is valid.
+func (k *APIKeyConfig) Validate() error {
+	if k.ID == "" {
+		return errors.New("API key ID cannot be empty")
+	}
+	digest, ok := strings.CutPrefix(k.Hash, APIKeyHashPrefix)
+	if decoded, err := hex.DecodeString(digest); !ok || err != nil || len(decoded) != sha256.Size {
+		return fmt.Errorf("API key %s: hash must be %s followed by 64 hex digits", k.ID, APIKeyHashPrefix)
+	}
+	if len(k.Scopes) == 0 {
+		return fmt.Errorf("API key %s: no scopes", k.ID)
+	}
+	for _, scope := range k.Scopes {
+		if !slices.Contains(Scopes, scope) {
+			return fmt.Errorf("API key %s: unknown scope %q, must be one of %s",
+				k.ID, scope, strings.Join(Scopes, ", "))
+		}
+	}
+	if k.RateLimit < 0 {
+		return fmt.Errorf("API key %s: rate_limit cannot be negative", k.ID)
+	}
+	return nil
+}
+
+// Allows reports whether the key has a scope, which the admin scope implies.
+func (k *APIKeyConfig) Allows(scope string) bool {
+	return slices.Contains(k.Scopes, scope) || slices.Contains(k.Scopes, ScopeAdmin)
+}
+
+// ValidateAPIKeys checks a set of API keys, whose IDs must be unique.
+func ValidateAPIKeys(keys []APIKeyConfig) error {
+	seen := make(map[string]bool, len(keys))
+	for i := range keys {
+		if err := keys[i].Validate(); err != nil {
+			return err
+		}
+		if seen[keys[i].ID] {
+			return fmt.Errorf("duplicate API key ID %q", keys[i].ID)
+		}
+		seen[keys[i].ID] = true
+	}
+	return nil
+}
+
 // Config represents server-specific configuration settings.
 type Config struct {
 	// Host is the server host address
@@ -27,8 +112,12 @@ type Config struct {
 	MaxHeaderBytes int `yaml:"max_header_bytes"`
 	// SecurityEnabled determines if security features are enabled
 	SecurityEnabled bool `yaml:"security_enabled"`
-	// APIKey is the API key used for authentication
+	// APIKey is a single API key in id:key form, allowed every scope
 	APIKey string `yaml:"api_key"`
+	// APIKeys are the accepted API keys, stored as hashes
+	APIKeys []APIKeyConfig `yaml:"api_keys"`
+	// APIKeysFile is a YAML file of more API keys, reloaded when it changes
+	APIKeysFile string `yaml:"api_keys_file"`
 	// Address is the address to listen on (e.g., ":8080")
 	Address string `yaml:"address"`
 	// TLS contains TLS configuration
@@ -44,11 +133,14 @@ type Config struct {
 
 // Validate checks if the configuration is valid.
 func (c *Config) Validate() error {
-	if c.SecurityEnabled {
-		if c.APIKey == "" {
-			return errors.New("server security is enabled but no API key is provided")
-		}
+	if !c.SecurityEnabled {
+		return nil
+	}
+	if c.APIKey == "" && len(c.APIKeys) == 0 && c.APIKeysFile == "" {
+		return errors.New("server security is enabled but no API key is provided")
+	}
 
+	if c.APIKey != "" {
 		// Validate API key format
 		parts := strings.Split(c.APIKey, ":")
 		if len(parts) != APIKeyParts {
@@ -65,7 +157,7 @@ func (c *Config) Validate() error {
 		}
 	}
 
-	return nil
+	return ValidateAPIKeys(c.APIKeys)
 }
 
 // New creates a new server configuration with the given options.
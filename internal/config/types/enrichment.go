@@ -0,0 +1,33 @@
+package types
+
+import "fmt"
+
+// Enrichers add derived fields to articles between extraction and indexing.
+// Sources opt into them by name.
+const (
+	// EnricherKeyphrases extracts the main keyphrases of the body.
+	EnricherKeyphrases = "keyphrases"
+	// EnricherGazetteer tags the places and organizations of the gazetteer that
+	// are mentioned in the title and body.
+	EnricherGazetteer = "gazetteer"
+	// EnricherReadingTime estimates the reading time of the body.
+	EnricherReadingTime = "reading_time"
+)
+
+// ValidateEnrichers checks that all enricher names are known and not repeated.
+func ValidateEnrichers(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		switch name {
+		case EnricherKeyphrases, EnricherGazetteer, EnricherReadingTime:
+		default:
+			return fmt.Errorf("invalid enricher %q: must be %s, %s or %s",
+				name, EnricherKeyphrases, EnricherGazetteer, EnricherReadingTime)
+		}
+		if seen[name] {
+			return fmt.Errorf("enricher %q is listed more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
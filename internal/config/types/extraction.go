@@ -0,0 +1,52 @@
+package types
+
+import "fmt"
+
+// Extraction modes control how the main content of articles and pages is found.
+const (
+	// ExtractionSelectors uses the configured CSS selectors and falls back to
+	// readability scoring only when they yield no content. This is the default.
+	ExtractionSelectors = "selectors"
+	// ExtractionAuto runs both strategies and keeps the selector result unless
+	// readability scoring finds substantially more content.
+	ExtractionAuto = "auto"
+	// ExtractionReadability ignores content selectors and always uses readability scoring.
+	ExtractionReadability = "readability"
+)
+
+// ValidateExtraction checks that an extraction mode is known. An empty mode
+// selects ExtractionSelectors.
+func ValidateExtraction(mode string) error {
+	switch mode {
+	case "", ExtractionSelectors, ExtractionAuto, ExtractionReadability:
+		return nil
+	default:
+		return fmt.Errorf("invalid extraction mode %q: must be %s, %s or %s",
+			mode, ExtractionSelectors, ExtractionAuto, ExtractionReadability)
+	}
+}
+
+// Structured data precedence controls how schema.org structured data (JSON-LD,
+// microdata, RDFa) is combined with CSS selector results.
+const (
+	// StructuredDataFallback keeps selector results and fills missing fields from
+	// structured data. This is the default.
+	StructuredDataFallback = "fallback"
+	// StructuredDataPrefer uses structured data wherever it is present and keeps
+	// selector results only for fields it lacks.
+	StructuredDataPrefer = "prefer"
+	// StructuredDataIgnore does not use structured data beyond the publish date.
+	StructuredDataIgnore = "ignore"
+)
+
+// ValidateStructuredData checks that a structured data precedence is known. An
+// empty value selects StructuredDataFallback.
+func ValidateStructuredData(precedence string) error {
+	switch precedence {
+	case "", StructuredDataFallback, StructuredDataPrefer, StructuredDataIgnore:
+		return nil
+	default:
+		return fmt.Errorf("invalid structured_data precedence %q: must be %s, %s or %s",
+			precedence, StructuredDataFallback, StructuredDataPrefer, StructuredDataIgnore)
+	}
+}
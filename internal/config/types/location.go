@@ -0,0 +1,19 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/jonesrussell/gocrawl/internal/domain"
+)
+
+// ValidateCoordinates checks an optional latitude and longitude pair: both or
+// neither must be set, and a set pair must be within bounds.
+func ValidateCoordinates(lat, lon *float64) error {
+	if (lat == nil) != (lon == nil) {
+		return errors.New("latitude and longitude must be set together")
+	}
+	if point := domain.NewGeoPoint(lat, lon); point != nil {
+		return point.Validate()
+	}
+	return nil
+}
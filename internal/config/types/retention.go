@@ -0,0 +1,69 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// hoursPerDay is the number of hours in a retention day.
+	hoursPerDay = 24
+	// daysPerWeek is the number of days in a retention week.
+	daysPerWeek = 7
+)
+
+// ParseRetention parses a retention period such as "180d", "4w" or "720h".
+// In addition to the units understood by time.ParseDuration, it accepts
+// "d" (days) and "w" (weeks). An empty string yields zero, meaning no
+// age-based retention.
+func ParseRetention(retention string) (time.Duration, error) {
+	retention = strings.TrimSpace(retention)
+	if retention == "" {
+		return 0, nil
+	}
+
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(retention, "d"):
+		unit = hoursPerDay * time.Hour
+	case strings.HasSuffix(retention, "w"):
+		unit = daysPerWeek * hoursPerDay * time.Hour
+	}
+
+	var period time.Duration
+	if unit > 0 {
+		count, err := strconv.Atoi(strings.TrimSpace(retention[:len(retention)-1]))
+		if err != nil {
+			return 0, fmt.Errorf("invalid retention %q: %w", retention, err)
+		}
+		period = time.Duration(count) * unit
+	} else {
+		var err error
+		period, err = time.ParseDuration(retention)
+		if err != nil {
+			return 0, fmt.Errorf("invalid retention %q: %w", retention, err)
+		}
+	}
+
+	if period <= 0 {
+		return 0, errors.New("retention must be positive")
+	}
+
+	return period, nil
+}
+
+// FormatRetention formats a retention period the way sources declare it,
+// preferring whole days ("180d") over Go duration syntax.
+func FormatRetention(retention time.Duration) string {
+	if retention <= 0 {
+		return ""
+	}
+	day := hoursPerDay * time.Hour
+	if retention%day == 0 {
+		return fmt.Sprintf("%dd", retention/day)
+	}
+	return retention.String()
+}
@@ -1,15 +1,22 @@
 package types
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/jonesrussell/gocrawl/internal/constants"
+)
 
 // Rule represents a crawling rule.
 type Rule struct {
-	// Pattern is the URL pattern to match
-	Pattern string `yaml:"pattern"`
-	// Action is the action to take when the pattern matches
-	Action string `yaml:"action"`
-	// Priority is the priority of the rule
-	Priority int `yaml:"priority"`
+	// Pattern is a regular expression matched against the path of a link
+	Pattern string `yaml:"pattern" mapstructure:"pattern"`
+	// Action is the action to take when the pattern matches: allow or disallow
+	Action string `yaml:"action" mapstructure:"action"`
+	// Priority is the priority of the rule; the matching rule with the highest priority decides
+	Priority int `yaml:"priority" mapstructure:"priority"`
 }
 
 // Rules is a collection of crawling rules.
@@ -21,9 +28,16 @@ func (r Rules) Validate() error {
 		if rule.Pattern == "" {
 			return errors.New("pattern is required")
 		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("invalid rule pattern %d: %w", i, err)
+		}
 		if rule.Action == "" {
 			return errors.New("action is required")
 		}
+		if !constants.ValidRuleActions[rule.Action] {
+			return fmt.Errorf("invalid rule action %q: must be %s or %s",
+				rule.Action, constants.ActionAllow, constants.ActionDisallow)
+		}
 		if rule.Priority < 0 {
 			return errors.New("priority must be non-negative")
 		}
@@ -36,3 +50,48 @@ func (r Rules) Validate() error {
 	}
 	return nil
 }
+
+// RuleSet is a compiled set of crawling rules.
+type RuleSet struct {
+	rules []compiledRule
+}
+
+// compiledRule is a rule with its compiled pattern.
+type compiledRule struct {
+	pattern *regexp.Regexp
+	allow   bool
+}
+
+// Compile compiles the rules, highest priority first. Rules of equal priority
+// keep the order they are listed in.
+func (r Rules) Compile() (*RuleSet, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	sorted := slices.Clone(r)
+	slices.SortStableFunc(sorted, func(a, b Rule) int { return b.Priority - a.Priority })
+
+	set := &RuleSet{rules: make([]compiledRule, 0, len(sorted))}
+	for _, rule := range sorted {
+		set.rules = append(set.rules, compiledRule{
+			pattern: regexp.MustCompile(rule.Pattern),
+			allow:   rule.Action == constants.ActionAllow,
+		})
+	}
+	return set, nil
+}
+
+// Allows reports whether a link with the given path may be followed. The
+// first matching rule decides; paths that no rule matches are allowed.
+func (s *RuleSet) Allows(path string) bool {
+	if s == nil {
+		return true
+	}
+	for _, rule := range s.rules {
+		if rule.pattern.MatchString(path) {
+			return rule.allow
+		}
+	}
+	return true
+}
@@ -2,6 +2,9 @@ package types
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"time"
 )
 
 // Source represents a source to be crawled.
@@ -30,6 +33,36 @@ type Source struct {
 	Selectors SourceSelectors `yaml:"selectors"`
 	// Rules define crawling rules for this source
 	Rules Rules `yaml:"rules"`
+	// Retention is how long documents are kept, e.g. "180d" (empty keeps them forever)
+	Retention string `yaml:"retention"`
+	// MaxDocs caps the number of documents kept per index (0 means unlimited)
+	MaxDocs int `yaml:"max_docs"`
+	// URLRewrites are applied to canonical URLs before they are used for IDs and link deduplication
+	URLRewrites URLRewrites `yaml:"url_rewrites"`
+	// Extraction selects how main content is found: selectors (default), auto or readability
+	Extraction string `yaml:"extraction"`
+	// StructuredData sets the precedence of schema.org data against selectors: fallback (default), prefer or ignore
+	StructuredData string `yaml:"structured_data"`
+	// Locale is the language and region of the source's dates, e.g. "en-US" or "fr-CA"
+	Locale string `yaml:"locale"`
+	// Timezone is the IANA timezone used for dates without an explicit zone, e.g. "America/Toronto"
+	Timezone string `yaml:"timezone"`
+	// CityName is the city the source covers; it is added to the places of the gazetteer
+	CityName string `yaml:"city_name"`
+	// Region is the province, state or region the source covers
+	Region string `yaml:"region"`
+	// Latitude of the source's city in decimal degrees
+	Latitude *float64 `yaml:"latitude"`
+	// Longitude of the source's city in decimal degrees
+	Longitude *float64 `yaml:"longitude"`
+	// Group is the group the source belongs to, e.g. "ontario-news"
+	Group string `yaml:"group"`
+	// Tags are further groups the source belongs to
+	Tags []string `yaml:"tags"`
+	// Enrichers lists the enrichment stages run on the source's articles: keyphrases, gazetteer and reading_time
+	Enrichers []string `yaml:"enrichers"`
+	// Crawler overrides crawler settings for this source, keyed like the crawler configuration section
+	Crawler map[string]any `yaml:"crawler"`
 }
 
 // Validate validates the source configuration.
@@ -49,8 +82,48 @@ func (s *Source) Validate() error {
 	if s.RateLimit == "" {
 		return errors.New("rate_limit is required")
 	}
+	if s.MaxDocs < 0 {
+		return errors.New("max_docs must be non-negative")
+	}
+	if _, err := ParseRetention(s.Retention); err != nil {
+		return err
+	}
+	if err := s.URLRewrites.Validate(); err != nil {
+		return err
+	}
+	if err := ValidateExtraction(s.Extraction); err != nil {
+		return err
+	}
+	if err := ValidateStructuredData(s.StructuredData); err != nil {
+		return err
+	}
+	if err := ValidateEnrichers(s.Enrichers); err != nil {
+		return err
+	}
+	if err := ValidateCoordinates(s.Latitude, s.Longitude); err != nil {
+		return err
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+		}
+	}
 	if err := s.Selectors.Validate(); err != nil {
 		return err
 	}
 	return s.Rules.Validate()
 }
+
+// Groups returns the groups the source belongs to: its group followed by its tags.
+func (s *Source) Groups() []string {
+	groups := make([]string, 0, len(s.Tags)+1)
+	if s.Group != "" {
+		groups = append(groups, s.Group)
+	}
+	for _, tag := range s.Tags {
+		if tag != "" && !slices.Contains(groups, tag) {
+			groups = append(groups, tag)
+		}
+	}
+	return groups
+}
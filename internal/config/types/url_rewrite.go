@@ -0,0 +1,32 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// URLRewrite is a per-source rule applied to URLs after canonicalization,
+// e.g. to map mobile or print URLs onto their desktop equivalents.
+type URLRewrite struct {
+	// Pattern is a regular expression matched against the canonical URL
+	Pattern string `yaml:"pattern" mapstructure:"pattern"`
+	// Replacement is the replacement text; it may reference capture groups as $1
+	Replacement string `yaml:"replacement" mapstructure:"replacement"`
+}
+
+// URLRewrites is an ordered list of URL rewrite rules.
+type URLRewrites []URLRewrite
+
+// Validate validates the URL rewrite rules.
+func (r URLRewrites) Validate() error {
+	for i, rewrite := range r {
+		if rewrite.Pattern == "" {
+			return errors.New("url rewrite pattern is required")
+		}
+		if _, err := regexp.Compile(rewrite.Pattern); err != nil {
+			return fmt.Errorf("invalid url rewrite pattern %d: %w", i, err)
+		}
+	}
+	return nil
+}
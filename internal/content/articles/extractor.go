@@ -6,12 +6,19 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly/v2"
+	"github.com/jonesrussell/gocrawl/internal/common/dateparse"
+	"github.com/jonesrussell/gocrawl/internal/common/urlnorm"
 	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/content/media"
+	"github.com/jonesrussell/gocrawl/internal/content/readability"
+	"github.com/jonesrussell/gocrawl/internal/content/structured"
+	"github.com/jonesrussell/gocrawl/internal/domain"
 )
 
 // extractText extracts text from the first element matching the selector.
@@ -123,51 +130,6 @@ func extractMetaName(e *colly.HTMLElement, name string) string {
 	return e.ChildAttr(selector, "content")
 }
 
-// parseDate attempts to parse a date string in various formats.
-func parseDate(dateStr string) time.Time {
-	if dateStr == "" {
-		return time.Time{}
-	}
-
-	// Common date formats
-	formats := []string{
-		time.RFC3339,
-		time.RFC3339Nano,
-		"2006-01-02T15:04:05Z07:00",
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04:05",
-		"2006-01-02",
-		time.RFC1123,
-		time.RFC1123Z,
-		time.ANSIC,
-		time.UnixDate,
-		time.RubyDate,
-		time.RFC822,
-		time.RFC822Z,
-		time.RFC850,
-		time.RFC850,
-		time.RFC850,
-		"Mon, 02 Jan 2006 15:04:05 MST",
-		"02 Jan 2006 15:04:05 MST",
-		"2006-01-02T15:04:05-07:00",
-		"2006-01-02T15:04:05+07:00",
-	}
-
-	dateStr = strings.TrimSpace(dateStr)
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return t
-		}
-	}
-
-	// Try parsing as Unix timestamp
-	if unixTime, err := time.Parse(time.RFC3339, dateStr); err == nil {
-		return unixTime
-	}
-
-	return time.Time{}
-}
-
 // generateID generates a unique ID from a URL using SHA256 hash.
 func generateID(url string) string {
 	if url == "" {
@@ -186,22 +148,45 @@ func applyExcludes(e *colly.HTMLElement, excludes []string) {
 	}
 }
 
+// extractOptions holds the per-source settings that control article extraction.
+type extractOptions struct {
+	// Canonicalizer determines the canonical URL the article ID is generated from
+	Canonicalizer *urlnorm.Canonicalizer
+	// Extraction decides when readability scoring replaces the selectors
+	Extraction string
+	// StructuredData sets the precedence of schema.org data against selectors
+	StructuredData string
+	// Dates parses dates in the source's locale and timezone
+	Dates *dateparse.Parser
+}
+
 // extractArticle extracts article data from HTML element using selectors.
-func extractArticle(e *colly.HTMLElement, selectors configtypes.ArticleSelectors, sourceURL string) *ArticleData {
+func extractArticle(
+	e *colly.HTMLElement,
+	selectors configtypes.ArticleSelectors,
+	sourceURL string,
+	opts extractOptions,
+) *ArticleData {
 	data := &ArticleData{
 		Source:    sourceURL,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
+	// Relative dates such as "3 hours ago" are anchored on the fetch time
+	dates := opts.Dates.At(fetchTime(e))
+
 	// Extract basic fields (before applying excludes, as these are usually in head or specific locations)
 	extractBasicFields(data, e, selectors)
 
 	// Extract body content
-	extractBodyContent(data, e, selectors)
+	extractBodyContent(data, e, selectors, opts.Extraction)
+
+	// Extract images and embedded media from the body
+	extractMedia(data, e, selectors, sourceURL)
 
 	// Extract metadata
-	extractMetadata(data, e, selectors)
+	extractMetadata(data, e, selectors, dates)
 
 	// Extract tags
 	extractTags(data, e, selectors)
@@ -210,10 +195,15 @@ func extractArticle(e *colly.HTMLElement, selectors configtypes.ArticleSelectors
 	extractOpenGraphMetadata(data, e)
 
 	// Extract other metadata
-	extractOtherMetadata(data, e, selectors, sourceURL)
+	extractOtherMetadata(data, e, selectors, sourceURL, opts.Canonicalizer)
+
+	// Combine with schema.org structured data
+	if opts.StructuredData != configtypes.StructuredDataIgnore {
+		applyStructuredData(data, structured.Extract(e.DOM, selectors.JSONLD), opts.StructuredData, dates)
+	}
 
 	// Extract article ID
-	data.ID = extractArticleID(e, selectors, sourceURL)
+	data.ID = extractArticleID(e, selectors, data.CanonicalURL)
 
 	return data
 }
@@ -240,8 +230,13 @@ func extractBasicFields(data *ArticleData, e *colly.HTMLElement, selectors confi
 	}
 }
 
-// extractBodyContent extracts the article body content.
-func extractBodyContent(data *ArticleData, e *colly.HTMLElement, selectors configtypes.ArticleSelectors) {
+// extractBodyContent extracts the article body content and records which strategy produced it.
+func extractBodyContent(
+	data *ArticleData,
+	e *colly.HTMLElement,
+	selectors configtypes.ArticleSelectors,
+	extraction string,
+) {
 	// Extract body - use container-based extraction if container selector is available
 	// This is the most reliable method as it scopes to the article container and applies excludes
 	if selectors.Container != "" {
@@ -259,15 +254,49 @@ func extractBodyContent(data *ArticleData, e *colly.HTMLElement, selectors confi
 		data.Body = extractText(e, selectors.Body)
 	}
 
+	// Fall back to (or, depending on the mode, compare with) readability scoring
+	data.Body, data.ExtractionMethod = readability.Choose(extraction, data.Body, readability.StrategySelectors, e.DOM)
+
 	// Additional fallbacks for body if still empty
 	if data.Body == "" {
 		// Try common article content containers
 		data.Body = extractTextFromContainer(e, "article, main, .article-content, .article-body", selectors.Exclude)
+		if data.Body != "" {
+			data.ExtractionMethod = readability.StrategyContainer
+		}
+	}
+}
+
+// mediaSelectors are tried in order to find the element holding the article's images.
+var mediaSelectors = []string{"[itemprop='articleBody']", "article", "main"}
+
+// extractMedia collects the images and videos of the article. Images matching
+// the image selector come first so that the lead image leads the list.
+func extractMedia(data *ArticleData, e *colly.HTMLElement, selectors configtypes.ArticleSelectors, pageURL string) {
+	collector := media.NewCollector(pageURL)
+	if selectors.Image != "" {
+		data.Images = collector.Images(e.DOM.Find(selectors.Image))
+	}
+
+	for _, selector := range append([]string{selectors.Container}, mediaSelectors...) {
+		if selector == "" {
+			continue
+		}
+		if scope := e.DOM.Find(selector).First(); scope.Length() > 0 {
+			data.Images = append(data.Images, collector.Images(scope)...)
+			data.Media = collector.Media(scope)
+			return
+		}
 	}
 }
 
 // extractMetadata extracts author, byline, and published date.
-func extractMetadata(data *ArticleData, e *colly.HTMLElement, selectors configtypes.ArticleSelectors) {
+func extractMetadata(
+	data *ArticleData,
+	e *colly.HTMLElement,
+	selectors configtypes.ArticleSelectors,
+	dates *dateparse.Parser,
+) {
 	data.Author = extractText(e, selectors.Author)
 	if data.Author == "" {
 		data.Author = extractMeta(e, "article:author")
@@ -279,7 +308,7 @@ func extractMetadata(data *ArticleData, e *colly.HTMLElement, selectors configty
 	}
 
 	// Extract dates with multiple fallback strategies
-	data.PublishedDate = extractPublishedDate(e, selectors)
+	data.PublishedDate = extractPublishedDate(e, selectors, dates)
 }
 
 // extractTags extracts tags and keywords from the article.
@@ -372,6 +401,7 @@ func extractOtherMetadata(
 	e *colly.HTMLElement,
 	selectors configtypes.ArticleSelectors,
 	sourceURL string,
+	canonicalizer *urlnorm.Canonicalizer,
 ) {
 	data.Description = extractMetaName(e, "description")
 	if data.Description == "" {
@@ -390,51 +420,78 @@ func extractOtherMetadata(
 	}
 	// Clean category will be applied when converting to domain.Article
 
-	data.CanonicalURL = extractAttr(e, selectors.Canonical, "href")
-	if data.CanonicalURL == "" {
-		data.CanonicalURL = sourceURL
-	}
+	// Prefer the page's declared canonical URL, normalized so that tracking
+	// parameters, AMP variants and the like map to the same article
+	data.CanonicalURL = canonicalizer.Resolve(sourceURL,
+		extractAttr(e, selectors.Canonical, "href"),
+		extractAttr(e, "link[rel='canonical']", "href"),
+		data.OgURL,
+	)
 }
 
 // extractPublishedDate extracts published date with multiple fallback strategies
-func extractPublishedDate(e *colly.HTMLElement, selectors configtypes.ArticleSelectors) time.Time {
+func extractPublishedDate(
+	e *colly.HTMLElement,
+	selectors configtypes.ArticleSelectors,
+	dates *dateparse.Parser,
+) time.Time {
 	// Strategy 1: Try JSON-LD structured data (highest priority)
 	if selectors.JSONLD != "" {
-		if date := extractDateFromJSONLD(e, selectors.JSONLD); !date.IsZero() {
+		if date := extractDateFromJSONLD(e, selectors.JSONLD, dates); !date.IsZero() {
 			return date
 		}
 	}
 
 	// Strategy 2: Try schema.org datePublished in microdata
-	if date := extractDateFromSchemaOrg(e); !date.IsZero() {
+	if date := extractDateFromSchemaOrg(e, dates); !date.IsZero() {
 		return date
 	}
 
 	// Strategy 3: Try published_time selector (datetime attribute)
-	if date := tryPublishedTimeSelector(e, selectors); !date.IsZero() {
+	if date := tryPublishedTimeSelector(e, selectors, dates); !date.IsZero() {
 		return date
 	}
 
 	// Strategy 4: Try Open Graph article:published_time
-	if date := tryOpenGraphDate(e); !date.IsZero() {
+	if date := tryOpenGraphDate(e, dates); !date.IsZero() {
 		return date
 	}
 
 	// Strategy 5: Try meta name="date" or "publishdate"
-	if date := tryMetaNameDate(e); !date.IsZero() {
+	if date := tryMetaNameDate(e, dates); !date.IsZero() {
 		return date
 	}
 
-	// Strategy 6: Try common HTML date patterns
-	return tryTimeElementDate(e)
+	// Strategy 6: Try the time_ago selector ("3 hours ago", "hier à 14h")
+	if date := dates.Parse(extractText(e, selectors.TimeAgo)); !date.IsZero() {
+		return date
+	}
+
+	// Strategy 7: Try common HTML date patterns
+	return tryTimeElementDate(e, dates)
+}
+
+// fetchTime returns when the page was fetched, taken from the response Date
+// header when the server sent one.
+func fetchTime(e *colly.HTMLElement) time.Time {
+	if e.Response != nil && e.Response.Headers != nil {
+		if fetched, err := http.ParseTime(e.Response.Headers.Get("Date")); err == nil {
+			return fetched
+		}
+	}
+	return time.Now()
 }
 
 // tryPublishedTimeSelector tries to extract date from published_time selector.
-func tryPublishedTimeSelector(e *colly.HTMLElement, selectors configtypes.ArticleSelectors) time.Time {
+func tryPublishedTimeSelector(
+	e *colly.HTMLElement,
+	selectors configtypes.ArticleSelectors,
+	dates *dateparse.Parser,
+) time.Time {
 	// Try datetime attribute first
 	publishedTimeStr := extractAttr(e, selectors.PublishedTime, "datetime")
 	if publishedTimeStr != "" {
-		if date := parseDate(publishedTimeStr); !date.IsZero() {
+		if date := dates.Parse(publishedTimeStr); !date.IsZero() {
 			return date
 		}
 	}
@@ -442,7 +499,7 @@ func tryPublishedTimeSelector(e *colly.HTMLElement, selectors configtypes.Articl
 	// Try text content
 	publishedTimeStr = extractText(e, selectors.PublishedTime)
 	if publishedTimeStr != "" {
-		if date := parseDate(publishedTimeStr); !date.IsZero() {
+		if date := dates.Parse(publishedTimeStr); !date.IsZero() {
 			return date
 		}
 	}
@@ -451,10 +508,10 @@ func tryPublishedTimeSelector(e *colly.HTMLElement, selectors configtypes.Articl
 }
 
 // tryOpenGraphDate tries to extract date from Open Graph meta tag.
-func tryOpenGraphDate(e *colly.HTMLElement) time.Time {
+func tryOpenGraphDate(e *colly.HTMLElement, dates *dateparse.Parser) time.Time {
 	publishedTimeStr := extractMeta(e, "article:published_time")
 	if publishedTimeStr != "" {
-		if date := parseDate(publishedTimeStr); !date.IsZero() {
+		if date := dates.Parse(publishedTimeStr); !date.IsZero() {
 			return date
 		}
 	}
@@ -462,12 +519,12 @@ func tryOpenGraphDate(e *colly.HTMLElement) time.Time {
 }
 
 // tryMetaNameDate tries to extract date from meta name tags.
-func tryMetaNameDate(e *colly.HTMLElement) time.Time {
+func tryMetaNameDate(e *colly.HTMLElement, dates *dateparse.Parser) time.Time {
 	metaNames := []string{"date", "publishdate", "pubdate"}
 	for _, name := range metaNames {
 		publishedTimeStr := extractMetaName(e, name)
 		if publishedTimeStr != "" {
-			if date := parseDate(publishedTimeStr); !date.IsZero() {
+			if date := dates.Parse(publishedTimeStr); !date.IsZero() {
 				return date
 			}
 		}
@@ -476,10 +533,10 @@ func tryMetaNameDate(e *colly.HTMLElement) time.Time {
 }
 
 // tryTimeElementDate tries to extract date from time element.
-func tryTimeElementDate(e *colly.HTMLElement) time.Time {
+func tryTimeElementDate(e *colly.HTMLElement, dates *dateparse.Parser) time.Time {
 	publishedTimeStr := extractAttr(e, "time", "datetime")
 	if publishedTimeStr != "" {
-		if date := parseDate(publishedTimeStr); !date.IsZero() {
+		if date := dates.Parse(publishedTimeStr); !date.IsZero() {
 			return date
 		}
 	}
@@ -487,7 +544,7 @@ func tryTimeElementDate(e *colly.HTMLElement) time.Time {
 }
 
 // extractDateFromJSONLD extracts published date from JSON-LD structured data
-func extractDateFromJSONLD(e *colly.HTMLElement, selector string) time.Time {
+func extractDateFromJSONLD(e *colly.HTMLElement, selector string, dates *dateparse.Parser) time.Time {
 	if selector == "" {
 		return time.Time{}
 	}
@@ -500,7 +557,7 @@ func extractDateFromJSONLD(e *colly.HTMLElement, selector string) time.Time {
 			continue
 		}
 
-		date := parseJSONLDDate(jsonText)
+		date := parseJSONLDDate(jsonText, dates)
 		if !date.IsZero() {
 			return date
 		}
@@ -510,14 +567,14 @@ func extractDateFromJSONLD(e *colly.HTMLElement, selector string) time.Time {
 }
 
 // parseJSONLDDate parses JSON-LD text and extracts date.
-func parseJSONLDDate(jsonText string) time.Time {
+func parseJSONLDDate(jsonText string, dates *dateparse.Parser) time.Time {
 	var jsonData any
 	if err := json.Unmarshal([]byte(jsonText), &jsonData); err != nil {
 		return time.Time{}
 	}
 
 	items := normalizeJSONLDItems(jsonData)
-	return findDateInJSONLDItems(items)
+	return findDateInJSONLDItems(items, dates)
 }
 
 // normalizeJSONLDItems normalizes JSON-LD data to a slice of items.
@@ -533,7 +590,7 @@ func normalizeJSONLDItems(jsonData any) []any {
 }
 
 // findDateInJSONLDItems searches for date in JSON-LD items.
-func findDateInJSONLDItems(items []any) time.Time {
+func findDateInJSONLDItems(items []any, dates *dateparse.Parser) time.Time {
 	for _, item := range items {
 		obj, ok := item.(map[string]any)
 		if !ok {
@@ -541,13 +598,13 @@ func findDateInJSONLDItems(items []any) time.Time {
 		}
 
 		// Try nested @graph first
-		if date := extractDateFromGraph(obj); !date.IsZero() {
+		if date := extractDateFromGraph(obj, dates); !date.IsZero() {
 			return date
 		}
 
 		// Check for article type and extract date
 		if isArticleType(obj) {
-			if date := extractDateFromJSONLDObject(obj); !date.IsZero() {
+			if date := extractDateFromJSONLDObject(obj, dates); !date.IsZero() {
 				return date
 			}
 		}
@@ -557,7 +614,7 @@ func findDateInJSONLDItems(items []any) time.Time {
 }
 
 // extractDateFromGraph extracts date from @graph in JSON-LD.
-func extractDateFromGraph(obj map[string]any) time.Time {
+func extractDateFromGraph(obj map[string]any, dates *dateparse.Parser) time.Time {
 	// Only process if @type is not present (meaning we should check @graph)
 	if _, hasType := obj["@type"].(string); hasType {
 		return time.Time{}
@@ -573,7 +630,7 @@ func extractDateFromGraph(obj map[string]any) time.Time {
 		if !isGraphObj {
 			continue
 		}
-		if date := extractDateFromJSONLDObject(graphObj); !date.IsZero() {
+		if date := extractDateFromJSONLDObject(graphObj, dates); !date.IsZero() {
 			return date
 		}
 	}
@@ -599,24 +656,24 @@ func isArticleType(obj map[string]any) bool {
 }
 
 // extractDateFromJSONLDObject extracts date from a JSON-LD object
-func extractDateFromJSONLDObject(obj map[string]any) time.Time {
+func extractDateFromJSONLDObject(obj map[string]any, dates *dateparse.Parser) time.Time {
 	// Try datePublished first
 	if datePublished, ok := obj["datePublished"].(string); ok {
-		if date := parseDate(datePublished); !date.IsZero() {
+		if date := dates.Parse(datePublished); !date.IsZero() {
 			return date
 		}
 	}
 
 	// Try publishedDate
 	if publishedDate, ok := obj["publishedDate"].(string); ok {
-		if date := parseDate(publishedDate); !date.IsZero() {
+		if date := dates.Parse(publishedDate); !date.IsZero() {
 			return date
 		}
 	}
 
 	// Try date
 	if date, ok := obj["date"].(string); ok {
-		if parsedDate := parseDate(date); !parsedDate.IsZero() {
+		if parsedDate := dates.Parse(date); !parsedDate.IsZero() {
 			return parsedDate
 		}
 	}
@@ -625,7 +682,7 @@ func extractDateFromJSONLDObject(obj map[string]any) time.Time {
 }
 
 // extractDateFromSchemaOrg extracts date from schema.org microdata
-func extractDateFromSchemaOrg(e *colly.HTMLElement) time.Time {
+func extractDateFromSchemaOrg(e *colly.HTMLElement, dates *dateparse.Parser) time.Time {
 	// Try itemscope with itemtype="http://schema.org/NewsArticle" or similar
 	articleTypes := []string{
 		"http://schema.org/NewsArticle",
@@ -641,7 +698,7 @@ func extractDateFromSchemaOrg(e *colly.HTMLElement) time.Time {
 			continue
 		}
 
-		if date := extractDateFromSchemaArticle(article); !date.IsZero() {
+		if date := extractDateFromSchemaArticle(article, dates); !date.IsZero() {
 			return date
 		}
 	}
@@ -650,7 +707,7 @@ func extractDateFromSchemaOrg(e *colly.HTMLElement) time.Time {
 }
 
 // extractDateFromSchemaArticle extracts date from a schema.org article element.
-func extractDateFromSchemaArticle(article *goquery.Selection) time.Time {
+func extractDateFromSchemaArticle(article *goquery.Selection, dates *dateparse.Parser) time.Time {
 	datePublished := article.Find("[itemprop='datePublished']").First()
 	if datePublished.Length() == 0 {
 		return time.Time{}
@@ -661,7 +718,7 @@ func extractDateFromSchemaArticle(article *goquery.Selection) time.Time {
 		return time.Time{}
 	}
 
-	date := parseDate(dateStr)
+	date := dates.Parse(dateStr)
 	if date.IsZero() {
 		return time.Time{}
 	}
@@ -669,8 +726,8 @@ func extractDateFromSchemaArticle(article *goquery.Selection) time.Time {
 	return date
 }
 
-// extractArticleID extracts the article ID from various attributes or generates one from URL.
-func extractArticleID(e *colly.HTMLElement, selectors configtypes.ArticleSelectors, sourceURL string) string {
+// extractArticleID extracts the article ID from various attributes or generates one from the canonical URL.
+func extractArticleID(e *colly.HTMLElement, selectors configtypes.ArticleSelectors, canonicalURL string) string {
 	// Extract article ID if available
 	articleID := extractAttr(e, selectors.ArticleID, "data-article-id")
 	if articleID == "" {
@@ -682,7 +739,7 @@ func extractArticleID(e *colly.HTMLElement, selectors configtypes.ArticleSelecto
 
 	// Generate ID from URL if article ID not found
 	if articleID == "" {
-		articleID = generateID(sourceURL)
+		articleID = generateID(urlnorm.Key(canonicalURL))
 	}
 	return articleID
 }
@@ -709,6 +766,85 @@ type ArticleData struct {
 	OgType        string
 	OgSiteName    string
 	CanonicalURL  string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// Images and Media are collected from the body
+	Images []domain.Image
+	Media  []domain.Media
+	// ExtractionMethod records which strategy produced Body
+	ExtractionMethod string
+	// Fields only available from structured data
+	Authors             []string
+	ModifiedDate        time.Time
+	Publisher           string
+	IsAccessibleForFree *bool
+	StructuredData      []string
+	// DeclaredWordCount is the word count the page declares for itself
+	DeclaredWordCount int
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// applyStructuredData combines schema.org structured data with the selector
+// results. With StructuredDataPrefer structured values replace selector values;
+// otherwise they only fill fields the selectors left empty.
+func applyStructuredData(data *ArticleData, sd *structured.Data, precedence string, dates *dateparse.Parser) {
+	if sd.Empty() {
+		return
+	}
+	prefer := precedence == configtypes.StructuredDataPrefer
+	pick := func(current, value string) string {
+		if value == "" || (current != "" && !prefer) {
+			return current
+		}
+		return value
+	}
+
+	data.StructuredData = sd.Formats
+	data.Title = pick(data.Title, sd.Headline)
+	data.Description = pick(data.Description, sd.Description)
+	data.Section = pick(data.Section, sd.Section)
+	if data.Category == "" {
+		data.Category = sd.Section
+	}
+
+	if len(sd.Authors) > 0 {
+		data.Author = pick(data.Author, strings.Join(sd.Authors, ", "))
+		data.Authors = sd.Authors
+	}
+
+	if published := dates.Parse(sd.DatePublished); !published.IsZero() && (prefer || data.PublishedDate.IsZero()) {
+		data.PublishedDate = published
+	}
+	data.ModifiedDate = dates.Parse(sd.DateModified)
+
+	if len(sd.Keywords) > 0 && (prefer || len(data.Keywords) == 0) {
+		data.Keywords = append(append([]string{}, sd.Keywords...), data.Keywords...)
+		data.Tags = append(data.Tags, sd.Keywords...)
+	}
+	if data.OgImage == "" && len(sd.Images) > 0 {
+		data.OgImage = sd.Images[0]
+	}
+	if len(data.Images) == 0 {
+		for _, src := range sd.Images {
+			data.Images = append(data.Images, domain.Image{Src: src})
+		}
+	}
+
+	data.Publisher = firstNonBlank(sd.Publisher, data.OgSiteName)
+	data.IsAccessibleForFree = sd.IsAccessibleForFree
+	data.DeclaredWordCount = sd.WordCount
+
+	if sd.Body != "" && (prefer || data.Body == "") {
+		data.Body = sd.Body
+		data.ExtractionMethod = readability.StrategyStructuredData
+	}
+}
+
+// firstNonBlank returns the first value that is not blank.
+func firstNonBlank(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
 }
@@ -6,12 +6,20 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/jonesrussell/gocrawl/internal/common/dateparse"
+	"github.com/jonesrussell/gocrawl/internal/common/urlnorm"
 	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/content/dedup"
+	"github.com/jonesrussell/gocrawl/internal/content/enrich"
+	"github.com/jonesrussell/gocrawl/internal/content/readability"
 	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/jonesrussell/gocrawl/internal/language"
 	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/runhistory"
 	"github.com/jonesrussell/gocrawl/internal/sources"
 	"github.com/jonesrussell/gocrawl/internal/storage/types"
 )
@@ -52,6 +60,15 @@ type ContentService struct {
 	indexName string
 	sources   sources.Interface
 	validator *ArticleValidator
+	dedup     *dedup.Deduplicator
+	// enrichment runs the enrichers listed on each source
+	enrichment *enrich.Pipeline
+	// runRecorder counts the indexed articles of the current crawl run
+	runRecorder *runhistory.Recorder
+	// canonicalizers caches the URL canonicalizer of each source
+	canonicalizers urlnorm.Cache
+	// dateParsers caches the date parser of each source, keyed by name, locale and timezone
+	dateParsers sync.Map
 }
 
 // NewContentService creates a new article service.
@@ -80,6 +97,21 @@ func NewContentServiceWithSources(
 	}
 }
 
+// SetDeduplicator enables near-duplicate detection before articles are indexed.
+func (s *ContentService) SetDeduplicator(deduplicator *dedup.Deduplicator) {
+	s.dedup = deduplicator
+}
+
+// SetEnrichment enables the enrichers listed on each source.
+func (s *ContentService) SetEnrichment(pipeline *enrich.Pipeline) {
+	s.enrichment = pipeline
+}
+
+// SetRunRecorder counts the indexed articles in the history of crawl runs.
+func (s *ContentService) SetRunRecorder(recorder *runhistory.Recorder) {
+	s.runRecorder = recorder
+}
+
 // Process implements the Interface for HTML element processing.
 func (s *ContentService) Process(e *colly.HTMLElement) error {
 	if e == nil {
@@ -92,6 +124,10 @@ func (s *ContentService) Process(e *colly.HTMLElement) error {
 	// Use local variable to avoid data race when Process() is called concurrently
 	indexName := s.indexName
 	var selectors configtypes.ArticleSelectors
+	var opts extractOptions
+	var sourceName, locale, city, region string
+	var location *domain.GeoPoint
+	var enrichers, groups []string
 	if s.sources != nil {
 		// Try to find source by matching URL domain
 		sourceConfig := s.findSourceByURL(sourceURL)
@@ -127,6 +163,17 @@ func (s *ContentService) Process(e *colly.HTMLElement) error {
 				ArticleID:     sourceConfig.Selectors.Article.ArticleID,
 				Exclude:       sourceConfig.Selectors.Article.Exclude,
 			}
+			opts = extractOptions{
+				Canonicalizer:  s.canonicalizers.ForSource(sourceConfig.Name, sourceConfig.URLRewrites),
+				Extraction:     sourceConfig.Extraction,
+				StructuredData: sourceConfig.StructuredData,
+				Dates:          s.dateParser(sourceConfig),
+			}
+			sourceName = sourceConfig.Name
+			locale = sourceConfig.Locale
+			enrichers = sourceConfig.Enrichers
+			groups = sourceConfig.Groups()
+			city, region, location = sourceConfig.CityName, sourceConfig.Region, sourceConfig.Location()
 			// Use source's article index if available (local variable, no race condition)
 			if sourceConfig.ArticleIndex != "" {
 				indexName = sourceConfig.ArticleIndex
@@ -138,7 +185,8 @@ func (s *ContentService) Process(e *colly.HTMLElement) error {
 	}
 
 	// Extract article data using Colly methods
-	articleData := extractArticle(e, selectors, sourceURL)
+	articleData := extractArticle(e, selectors, sourceURL, opts)
+	logExtractionFallback(s.logger, opts.Extraction, articleData.ExtractionMethod, sourceName, sourceURL)
 
 	// Clean category field
 	categories := CleanCategory(articleData.Category)
@@ -147,34 +195,55 @@ func (s *ContentService) Process(e *colly.HTMLElement) error {
 		categoryStr = categories[0] // Use first category as primary
 	}
 
-	// Calculate word count
+	// Calculate word count, falling back to the count the page declares
 	wordCount := CalculateWordCount(articleData.Body)
+	if wordCount == 0 {
+		wordCount = articleData.DeclaredWordCount
+	}
 
 	// Convert to domain.Article
 	article := &domain.Article{
-		ID:            articleData.ID,
-		Title:         articleData.Title,
-		Body:          articleData.Body,
-		Intro:         articleData.Intro,
-		Author:        articleData.Author,
-		BylineName:    articleData.BylineName,
-		PublishedDate: articleData.PublishedDate,
-		Source:        articleData.Source,
-		Tags:          articleData.Tags,
-		Keywords:      articleData.Keywords,
-		Description:   articleData.Description,
-		Section:       articleData.Section,
-		Category:      categoryStr,
-		OgTitle:       articleData.OgTitle,
-		OgDescription: articleData.OgDescription,
-		OgImage:       articleData.OgImage,
-		OgURL:         articleData.OgURL,
-		CanonicalURL:  articleData.CanonicalURL,
-		WordCount:     wordCount,
-		CreatedAt:     articleData.CreatedAt,
-		UpdatedAt:     articleData.UpdatedAt,
+		ID:                  articleData.ID,
+		Title:               articleData.Title,
+		Body:                articleData.Body,
+		Intro:               articleData.Intro,
+		Author:              articleData.Author,
+		BylineName:          articleData.BylineName,
+		Authors:             articleData.Authors,
+		PublishedDate:       articleData.PublishedDate,
+		Source:              articleData.Source,
+		Tags:                articleData.Tags,
+		Keywords:            articleData.Keywords,
+		Description:         articleData.Description,
+		Section:             articleData.Section,
+		Category:            categoryStr,
+		OgTitle:             articleData.OgTitle,
+		OgDescription:       articleData.OgDescription,
+		OgImage:             articleData.OgImage,
+		OgURL:               articleData.OgURL,
+		CanonicalURL:        articleData.CanonicalURL,
+		Images:              articleData.Images,
+		Media:               articleData.Media,
+		ExtractionMethod:    articleData.ExtractionMethod,
+		Language:            language.DetectDocument(locale, articleData.Title, articleData.Body),
+		Groups:              groups,
+		City:                city,
+		Region:              region,
+		Location:            location,
+		Publisher:           articleData.Publisher,
+		IsAccessibleForFree: articleData.IsAccessibleForFree,
+		StructuredData:      articleData.StructuredData,
+		WordCount:           wordCount,
+		CreatedAt:           articleData.CreatedAt,
+		UpdatedAt:           articleData.UpdatedAt,
+	}
+
+	if !articleData.ModifiedDate.IsZero() {
+		article.ModifiedDate = &articleData.ModifiedDate
 	}
 
+	s.enrichment.Enrich(enrichers, article)
+
 	// Validate article before indexing
 	validationResult := s.validator.ValidateArticle(article)
 	if !validationResult.IsValid {
@@ -190,6 +259,48 @@ func (s *ContentService) Process(e *colly.HTMLElement) error {
 	return s.ProcessArticleWithIndex(context.Background(), article, indexName)
 }
 
+// logExtractionFallback warns when the configured selectors yielded no body and a
+// fallback strategy was used instead, which usually means the site changed its markup.
+func logExtractionFallback(log logger.Interface, extraction, method, sourceName, pageURL string) {
+	if extraction == configtypes.ExtractionAuto || extraction == configtypes.ExtractionReadability {
+		return
+	}
+	switch method {
+	case readability.StrategyReadability, readability.StrategyContainer:
+		log.Warn("Selectors produced no content, used fallback extraction",
+			"source", sourceName,
+			"url", pageURL,
+			"extraction_method", method)
+	case readability.StrategyNone:
+		log.Warn("No extraction strategy produced content",
+			"source", sourceName,
+			"url", pageURL)
+	}
+}
+
+// dateParser returns the date parser for a source's locale and timezone.
+// An invalid timezone is logged and dates are parsed in UTC instead.
+func (s *ContentService) dateParser(source *sources.Config) *dateparse.Parser {
+	key := source.Name + "|" + source.Locale + "|" + source.Timezone
+	if cached, ok := s.dateParsers.Load(key); ok {
+		if parser, isParser := cached.(*dateparse.Parser); isParser {
+			return parser
+		}
+	}
+
+	parser, err := dateparse.New(source.Locale, source.Timezone)
+	if err != nil {
+		s.logger.Warn("Invalid date settings for source, parsing dates in UTC",
+			"source", source.Name,
+			"locale", source.Locale,
+			"timezone", source.Timezone,
+			"error", err)
+		parser, _ = dateparse.New(source.Locale, "")
+	}
+	s.dateParsers.Store(key, parser)
+	return parser
+}
+
 // findSourceByURL attempts to find a source configuration by matching the URL domain.
 func (s *ContentService) findSourceByURL(pageURL string) *sources.Config {
 	if s.sources == nil {
@@ -279,6 +390,19 @@ func (s *ContentService) ProcessArticleWithIndex(ctx context.Context, article *d
 		}
 	}
 
+	// Resolve near-duplicates against recently indexed articles
+	if s.dedup != nil {
+		skip, err := s.dedup.Process(ctx, indexName, article)
+		if err != nil {
+			s.logger.Warn("Near-duplicate check failed, indexing article anyway",
+				"error", err,
+				"articleID", article.ID,
+				"url", article.Source)
+		} else if skip {
+			return nil
+		}
+	}
+
 	// Prepare article for indexing: clean empty fields, normalize arrays, prevent duplication
 	article.PrepareForIndexing()
 
@@ -291,6 +415,7 @@ func (s *ContentService) ProcessArticleWithIndex(ctx context.Context, article *d
 			"index", indexName)
 		return fmt.Errorf("failed to index article: %w", err)
 	}
+	s.runRecorder.ArticleIndexed(article)
 
 	s.logger.Info("Article indexed successfully",
 		"articleID", article.ID,
@@ -0,0 +1,157 @@
+package dedup_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/content/dedup"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const wireStory = `City council approved the new transit budget on Tuesday after a lengthy debate
+over bus routes, fare increases and the future of the downtown light rail extension. Council members
+voted eight to three in favour of the plan, which adds two new express routes and freezes fares for
+seniors until the end of next year. The mayor said the budget balances service improvements with the
+need to keep property taxes in check, while opponents argued the light rail project remains underfunded
+and will face further delays without provincial support.`
+
+func TestSimHashNearDuplicates(t *testing.T) {
+	t.Parallel()
+
+	original := dedup.SimHash(wireStory)
+	edited := dedup.SimHash(strings.Replace(wireStory, "Tuesday", "Wednesday", 1) + " Reporting by staff.")
+	unrelated := dedup.SimHash(`The home team rallied in the third period with two power play goals to win the
+series opener, and the coach praised the goaltender for a forty save performance in front of a sold out crowd.`)
+
+	assert.Equal(t, original, dedup.SimHash(strings.ToUpper(wireStory)), "case should not change the fingerprint")
+	assert.Less(t, dedup.Distance(original, edited), dedup.Distance(original, unrelated))
+	assert.Greater(t, dedup.Distance(original, unrelated), crawlerconfig.DefaultDedupMaxDistance)
+}
+
+func TestBandsShareBandWithinDistance(t *testing.T) {
+	t.Parallel()
+
+	fingerprint := dedup.SimHash(wireStory)
+	flipped := fingerprint ^ (1 << 3) ^ (1 << 20) ^ (1 << 41)
+
+	bands := dedup.Bands(fingerprint, 3)
+	require.Len(t, bands, 4)
+
+	shared := 0
+	for i, band := range dedup.Bands(flipped, 3) {
+		if band == bands[i] {
+			shared++
+		}
+	}
+	assert.Positive(t, shared)
+
+	parsed, err := dedup.ParseFingerprint(dedup.FormatFingerprint(fingerprint))
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, parsed)
+}
+
+func TestProcessLinksDuplicate(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewNoOp()
+	stor, ok := testutils.NewMockStorage(log).(*testutils.MockStorage)
+	require.True(t, ok)
+
+	cfg := crawlerconfig.NewDedupConfig()
+	cfg.MinWords = 10
+
+	canonicalHash := dedup.FormatFingerprint(dedup.SimHash(wireStory))
+	stor.On("IndexExists", mock.Anything, crawlerconfig.DefaultDedupIndex).Return(true, nil)
+	stor.On("Search", mock.Anything, crawlerconfig.DefaultDedupIndex, mock.Anything).Return([]any{
+		map[string]any{
+			"_id":     "canonical",
+			"_index":  "articles",
+			"_source": map[string]any{"simhash": canonicalHash, "source": "https://wire.example.com/story"},
+		},
+	}, nil)
+	stor.On("UpdateDocument", mock.Anything, "articles", "canonical", mock.Anything).Return(nil)
+
+	article := &domain.Article{ID: "copy", Source: "https://local.example.com/story", Body: wireStory}
+	skip, err := dedup.NewDeduplicator(log, stor, cfg).Process(context.Background(), "articles", article)
+	require.NoError(t, err)
+
+	assert.False(t, skip)
+	assert.Equal(t, "canonical", article.DuplicateOf)
+	assert.Equal(t, canonicalHash, article.SimHash)
+
+	// The canonical article is only appended to, never reindexed in full
+	update, updateOK := stor.Calls[len(stor.Calls)-1].Arguments.Get(3).(map[string]any)
+	require.True(t, updateOK)
+	script, scriptOK := update["script"].(map[string]any)
+	require.True(t, scriptOK)
+	assert.Contains(t, script["source"], "alternate_sources.add(params.url)")
+	params, paramsOK := script["params"].(map[string]any)
+	require.True(t, paramsOK)
+	assert.Equal(t, "https://local.example.com/story", params["url"])
+	stor.AssertNotCalled(t, "IndexDocument", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessSkipsDuplicate(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewNoOp()
+	stor, ok := testutils.NewMockStorage(log).(*testutils.MockStorage)
+	require.True(t, ok)
+
+	cfg := crawlerconfig.NewDedupConfig()
+	cfg.Mode = crawlerconfig.DedupModeSkip
+	cfg.MinWords = 10
+
+	stor.On("IndexExists", mock.Anything, crawlerconfig.DefaultDedupIndex).Return(true, nil)
+	stor.On("Search", mock.Anything, crawlerconfig.DefaultDedupIndex, mock.Anything).Return([]any{
+		map[string]any{
+			"_id":     "canonical",
+			"_index":  "articles",
+			"_source": map[string]any{"simhash": dedup.FormatFingerprint(dedup.SimHash(wireStory))},
+		},
+	}, nil)
+
+	article := &domain.Article{ID: "copy", Source: "https://local.example.com/story", Body: wireStory}
+	skip, err := dedup.NewDeduplicator(log, stor, cfg).Process(context.Background(), "articles", article)
+	require.NoError(t, err)
+	assert.True(t, skip)
+	stor.AssertNotCalled(t, "UpdateDocument", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessLinksDuplicateOnOtherSource(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewNoOp()
+	stor, ok := testutils.NewMockStorage(log).(*testutils.MockStorage)
+	require.True(t, ok)
+
+	cfg := crawlerconfig.NewDedupConfig()
+	cfg.MinWords = 10
+
+	// The wire copy was indexed by another source, in its own article index
+	stor.On("IndexExists", mock.Anything, crawlerconfig.DefaultDedupIndex).Return(true, nil)
+	stor.On("Search", mock.Anything, crawlerconfig.DefaultDedupIndex, mock.Anything).Return([]any{
+		map[string]any{
+			"_id":     "canonical",
+			"_index":  "wire_articles",
+			"_source": map[string]any{"simhash": dedup.FormatFingerprint(dedup.SimHash(wireStory))},
+		},
+	}, nil)
+	stor.On("UpdateDocument", mock.Anything, "wire_articles", "canonical", mock.Anything).Return(nil)
+
+	article := &domain.Article{ID: "copy", Source: "https://local.example.com/story", Body: wireStory}
+	skip, err := dedup.NewDeduplicator(log, stor, cfg).Process(context.Background(), "local_articles", article)
+	require.NoError(t, err)
+
+	assert.False(t, skip)
+	assert.Equal(t, "canonical", article.DuplicateOf)
+	stor.AssertExpectations(t)
+	stor.AssertNotCalled(t, "IndexExists", mock.Anything, "local_articles")
+}
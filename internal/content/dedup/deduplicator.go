@@ -0,0 +1,216 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+// maxCandidates is the number of stored fingerprints compared per article.
+const maxCandidates = 50
+
+// Match describes a previously indexed article that an article duplicates.
+type Match struct {
+	// ID is the ID of the canonical article
+	ID string
+	// Index is the index holding the canonical article
+	Index string
+	// Source is the URL of the canonical article
+	Source string
+	// Distance is the Hamming distance between the fingerprints
+	Distance int
+}
+
+// Deduplicator finds near-duplicates of articles before they are indexed.
+type Deduplicator struct {
+	logger  logger.Interface
+	storage types.Interface
+	config  crawlerconfig.DedupConfig
+	now     func() time.Time
+}
+
+// NewDeduplicator creates a new deduplicator.
+func NewDeduplicator(log logger.Interface, storage types.Interface, cfg crawlerconfig.DedupConfig) *Deduplicator {
+	return &Deduplicator{
+		logger:  log,
+		storage: storage,
+		config:  cfg,
+		now:     time.Now,
+	}
+}
+
+// Process fingerprints an article that is about to be indexed in the given index
+// and resolves it against recently indexed articles of every source, in the
+// indices of the dedup configuration. It reports whether the article should be
+// skipped. In link mode a duplicate is marked with duplicate_of and its URL is
+// added to the alternate sources of the canonical article, in its own index.
+func (d *Deduplicator) Process(ctx context.Context, index string, article *domain.Article) (bool, error) {
+	if article == nil {
+		return false, errors.New("article is nil")
+	}
+
+	if len(Words(article.Body)) < d.config.MinWords {
+		return false, nil
+	}
+
+	fingerprint := SimHash(article.Body)
+	article.SimHash = FormatFingerprint(fingerprint)
+	article.SimHashBands = Bands(fingerprint, d.config.MaxDistance)
+
+	match, err := d.FindDuplicate(ctx, d.searchIndex(index), article)
+	if err != nil {
+		return false, err
+	}
+	if match == nil {
+		return false, nil
+	}
+
+	d.logger.Info("Near-duplicate article detected",
+		"articleID", article.ID,
+		"url", article.Source,
+		"duplicateOf", match.ID,
+		"canonicalURL", match.Source,
+		"distance", match.Distance,
+		"mode", d.config.Mode)
+
+	if d.config.Mode == crawlerconfig.DedupModeSkip {
+		return true, nil
+	}
+
+	article.DuplicateOf = match.ID
+	canonicalIndex := match.Index
+	if canonicalIndex == "" {
+		canonicalIndex = index
+	}
+	if linkErr := d.addAlternateSource(ctx, canonicalIndex, match.ID, article.Source); linkErr != nil {
+		return false, linkErr
+	}
+	return false, nil
+}
+
+// searchIndex returns the indices searched for copies of an article bound for index.
+func (d *Deduplicator) searchIndex(index string) string {
+	if d.config.Index == "" {
+		return index
+	}
+	return d.config.Index
+}
+
+// FindDuplicate returns the closest canonical article in an index, alias or pattern
+// within the configured window and distance, or nil if there is none. The article
+// must already carry its fingerprint.
+func (d *Deduplicator) FindDuplicate(ctx context.Context, index string, article *domain.Article) (*Match, error) {
+	fingerprint, err := ParseFingerprint(article.SimHash)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := d.storage.IndexExists(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check index: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	filters := []any{
+		map[string]any{"terms": map[string]any{"simhash_bands": article.SimHashBands}},
+	}
+	if d.config.Window > 0 {
+		filters = append(filters, map[string]any{
+			"range": map[string]any{
+				"created_at": map[string]any{"gte": d.now().Add(-d.config.Window).UTC().Format(time.RFC3339)},
+			},
+		})
+	}
+
+	hits, err := d.storage.Search(ctx, index, map[string]any{
+		"size":    maxCandidates,
+		"_source": []string{"simhash", "source"},
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": filters,
+				"must_not": []any{
+					map[string]any{"ids": map[string]any{"values": []string{article.ID}}},
+					map[string]any{"exists": map[string]any{"field": "duplicate_of"}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search fingerprints: %w", err)
+	}
+
+	var best *Match
+	for _, hit := range hits {
+		candidate, ok := parseCandidate(hit, fingerprint)
+		if !ok || candidate.Distance > d.config.MaxDistance {
+			continue
+		}
+		if best == nil || candidate.Distance < best.Distance {
+			best = candidate
+		}
+	}
+	return best, nil
+}
+
+// alternateSourceScript appends a URL to alternate_sources unless the article
+// already carries it, leaving every other field of the document untouched.
+const alternateSourceScript = `if (ctx._source.source == params.url) { ctx.op = 'noop'; return; }
+if (ctx._source.alternate_sources == null) { ctx._source.alternate_sources = []; }
+if (ctx._source.alternate_sources.contains(params.url)) { ctx.op = 'noop'; return; }
+ctx._source.alternate_sources.add(params.url);
+ctx._source.updated_at = params.updated_at;`
+
+// addAlternateSource records a duplicate's URL on the canonical article with a
+// scripted partial update, so concurrent duplicates do not overwrite each other.
+func (d *Deduplicator) addAlternateSource(ctx context.Context, index, canonicalID, sourceURL string) error {
+	update := map[string]any{
+		"script": map[string]any{
+			"lang":   "painless",
+			"source": alternateSourceScript,
+			"params": map[string]any{
+				"url":        sourceURL,
+				"updated_at": d.now().UTC().Format(time.RFC3339Nano),
+			},
+		},
+	}
+	if err := d.storage.UpdateDocument(ctx, index, canonicalID, update); err != nil {
+		return fmt.Errorf("failed to update canonical article %s: %w", canonicalID, err)
+	}
+	return nil
+}
+
+// parseCandidate converts a raw search hit into a match.
+func parseCandidate(hit any, fingerprint uint64) (*Match, bool) {
+	hitMap, ok := hit.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	id, _ := hitMap["_id"].(string)
+	index, _ := hitMap["_index"].(string)
+	source, _ := hitMap["_source"].(map[string]any)
+	stored, _ := source["simhash"].(string)
+	if id == "" || stored == "" {
+		return nil, false
+	}
+
+	candidate, err := ParseFingerprint(stored)
+	if err != nil {
+		return nil, false
+	}
+
+	sourceURL, _ := source["source"].(string)
+	return &Match{
+		ID:       id,
+		Index:    index,
+		Source:   sourceURL,
+		Distance: Distance(fingerprint, candidate),
+	}, true
+}
@@ -0,0 +1,99 @@
+// Package dedup detects near-duplicate articles, such as syndicated wire stories
+// published by several sources under different URLs. Article bodies are
+// fingerprinted with SimHash and compared against fingerprints stored with
+// recently indexed articles.
+package dedup
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// fingerprintBits is the size of a SimHash fingerprint.
+	fingerprintBits = 64
+	// shingleSize is the number of consecutive words hashed together.
+	shingleSize = 3
+)
+
+// SimHash computes the 64-bit SimHash of a text from its lowercased word shingles.
+// Texts that differ only in a few words produce fingerprints with a small Hamming distance.
+func SimHash(text string) uint64 {
+	words := Words(text)
+	if len(words) == 0 {
+		return 0
+	}
+
+	size := min(shingleSize, len(words))
+	var weights [fingerprintBits]int
+	for i := 0; i+size <= len(words); i++ {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(strings.Join(words[i:i+size], " ")))
+		sum := h.Sum64()
+		for bit := range fingerprintBits {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+	return fingerprint
+}
+
+// Words splits a text into lowercased words, ignoring punctuation.
+func Words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Distance returns the Hamming distance between two fingerprints.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// FormatFingerprint formats a fingerprint as 16 hex digits.
+func FormatFingerprint(fingerprint uint64) string {
+	return fmt.Sprintf("%016x", fingerprint)
+}
+
+// ParseFingerprint parses a fingerprint formatted by FormatFingerprint.
+func ParseFingerprint(s string) (uint64, error) {
+	fingerprint, err := strconv.ParseUint(s, 16, fingerprintBits)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fingerprint %q: %w", s, err)
+	}
+	return fingerprint, nil
+}
+
+// Bands splits a fingerprint into maxDistance+1 bands. Two fingerprints within
+// maxDistance bits of each other share at least one band, so candidates can be
+// found with an exact terms query. Each band is prefixed with the band count and
+// position so that bands from different configurations never match.
+func Bands(fingerprint uint64, maxDistance int) []string {
+	count := maxDistance + 1
+	bands := make([]string, 0, count)
+	start := 0
+	for i := range count {
+		width := fingerprintBits / count
+		if i < fingerprintBits%count {
+			width++
+		}
+		band := (fingerprint >> start) & (1<<width - 1)
+		bands = append(bands, fmt.Sprintf("%d.%d:%x", count, i, band))
+		start += width
+	}
+	return bands
+}
@@ -0,0 +1,56 @@
+// Package enrich adds derived fields to articles between extraction and
+// indexing: keyphrases, gazetteer places and organizations, and reading time.
+//
+// Each source lists the enrichers run on its articles by name (see
+// configtypes.EnricherKeyphrases and its siblings); a Pipeline maps those names
+// to Enricher implementations, and further enrichers can be registered on it.
+package enrich
+
+import (
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+)
+
+// Enricher adds derived fields to an article.
+type Enricher interface {
+	Enrich(article *domain.Article)
+}
+
+// Pipeline runs the enrichers a source opts into.
+type Pipeline struct {
+	enrichers map[string]Enricher
+}
+
+// NewPipeline returns a pipeline with the built-in enrichers. A nil gazetteer
+// tags nothing.
+func NewPipeline(cfg crawlerconfig.EnrichmentConfig, gazetteer *Gazetteer) *Pipeline {
+	if gazetteer == nil {
+		gazetteer = NewGazetteer()
+	}
+	return &Pipeline{
+		enrichers: map[string]Enricher{
+			configtypes.EnricherKeyphrases:  NewKeyphraseExtractor(cfg.MaxKeyphrases),
+			configtypes.EnricherGazetteer:   gazetteer,
+			configtypes.EnricherReadingTime: NewReadingTime(cfg.WordsPerMinute),
+		},
+	}
+}
+
+// Register adds or replaces the enricher run for a name.
+func (p *Pipeline) Register(name string, enricher Enricher) {
+	p.enrichers[name] = enricher
+}
+
+// Enrich runs the named enrichers on an article, in order. Unknown names are
+// skipped; source validation rejects them. A nil pipeline does nothing.
+func (p *Pipeline) Enrich(names []string, article *domain.Article) {
+	if p == nil || article == nil {
+		return
+	}
+	for _, name := range names {
+		if enricher, ok := p.enrichers[name]; ok {
+			enricher.Enrich(article)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package enrich_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/content/enrich"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const body = `City council approved the new transit terminal on Tuesday. The transit terminal
+will replace the downtown bus depot, which has served riders since 1974. Councillors
+debated the terminal location for two hours before the vote. Construction of the
+transit terminal is expected to start next spring, according to the city's
+infrastructure report.`
+
+func TestKeyphrases(t *testing.T) {
+	t.Parallel()
+
+	extractor := enrich.NewKeyphraseExtractor(3)
+	keyphrases := extractor.Extract(body, "en")
+
+	require.Len(t, keyphrases, 3)
+	assert.Contains(t, keyphrases, "transit terminal")
+	for _, phrase := range keyphrases {
+		assert.NotContains(t, phrase, "the ")
+		assert.NotContains(t, phrase, "1974")
+	}
+}
+
+func TestKeyphrasesFrench(t *testing.T) {
+	t.Parallel()
+
+	extractor := enrich.NewKeyphraseExtractor(5)
+	keyphrases := extractor.Extract("Le conseil municipal a approuvé l'aréna communautaire. "+
+		"L'aréna communautaire ouvrira ses portes au printemps.", "fr")
+
+	assert.Contains(t, keyphrases, "aréna communautaire")
+	assert.NotContains(t, keyphrases, "l'aréna communautaire")
+}
+
+func TestGazetteer(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "gazetteer.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+places:
+  - name: Sault Ste. Marie
+    aliases: [the Soo]
+    lat: 46.52
+    lon: -84.35
+organizations:
+  - name: Sudbury Wolves
+  - name: Laurentian University
+    aliases: [Laurentian]
+`), 0o600))
+
+	gazetteer, err := enrich.LoadGazetteer(path)
+	require.NoError(t, err)
+	gazetteer.AddPlace("Sudbury", nil)
+
+	places, organizations := gazetteer.Tag(
+		"The Sudbury Wolves beat the Soo Greyhounds in Sault-Ste-Marie. " +
+			"Laurentian students drove back to Sudbury, where the sudbury basin mine is.")
+
+	assert.Equal(t, []string{"Sault Ste. Marie", "Sudbury"}, places)
+	assert.Equal(t, []string{"Sudbury Wolves", "Laurentian University"}, organizations)
+}
+
+func TestGazetteerMatchesCase(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "gazetteer.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+places:
+  - name: Sault Ste. Marie
+    ignore_case_aliases: [the Soo]
+  - name: Mine Centre
+    aliases: [Mine]
+organizations:
+  - name: Mine Rescue
+    aliases: [MINE]
+`), 0o600))
+
+	gazetteer, err := enrich.LoadGazetteer(path)
+	require.NoError(t, err)
+
+	places, organizations := gazetteer.Tag("The Soo cheered. MINE crews met at the mine.")
+	assert.Equal(t, []string{"Sault Ste. Marie"}, places, "only aliases marked to ignore case match in any case")
+	assert.Equal(t, []string{"Mine Rescue"}, organizations)
+
+	places, _ = gazetteer.Tag("Crews met at Mine.")
+	assert.Equal(t, []string{"Mine Centre"}, places)
+}
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	gazetteer := enrich.NewGazetteer()
+	gazetteer.AddPlace("Timmins", &domain.GeoPoint{Lat: 48.48, Lon: -81.33})
+	pipeline := enrich.NewPipeline(crawlerconfig.NewEnrichmentConfig(), gazetteer)
+
+	article := &domain.Article{Title: "Timmins approves transit terminal", Body: body, Language: "en"}
+	pipeline.Enrich([]string{configtypes.EnricherGazetteer, configtypes.EnricherReadingTime}, article)
+
+	assert.Equal(t, []string{"Timmins"}, article.Places)
+	assert.Equal(t, []domain.GeoPoint{{Lat: 48.48, Lon: -81.33}}, article.PlaceLocations)
+	assert.Equal(t, 1, article.ReadingTimeMinutes)
+	assert.Empty(t, article.Keyphrases, "only the listed enrichers run")
+
+	var nilPipeline *enrich.Pipeline
+	nilPipeline.Enrich([]string{configtypes.EnricherKeyphrases}, article)
+	assert.Empty(t, article.Keyphrases)
+}
+
+func TestValidateEnrichers(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, configtypes.ValidateEnrichers([]string{"keyphrases", "reading_time"}))
+	require.Error(t, configtypes.ValidateEnrichers([]string{"sentiment"}))
+	require.Error(t, configtypes.ValidateEnrichers([]string{"gazetteer", "gazetteer"}))
+}
@@ -0,0 +1,237 @@
+package enrich
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Gazetteer entity kinds
+const (
+	kindPlace = iota
+	kindOrganization
+)
+
+// GazetteerFile is the layout of a gazetteer file:
+//
+//	places:
+//	  - name: Greater Sudbury
+//	    aliases: [Sudbury]
+//	    lat: 46.49
+//	    lon: -80.99
+//	  - name: Sault Ste. Marie
+//	    ignore_case_aliases: [the Soo]
+//	organizations:
+//	  - name: Laurentian University
+type GazetteerFile struct {
+	Places        []GazetteerEntry `yaml:"places"`
+	Organizations []GazetteerEntry `yaml:"organizations"`
+}
+
+// GazetteerEntry is a named place or organization. Mentions of any alias are
+// tagged with the name. Places with coordinates are also geotagged.
+type GazetteerEntry struct {
+	Name    string   `yaml:"name"`
+	Aliases []string `yaml:"aliases"`
+	// IgnoreCaseAliases are aliases matched whatever their capitalization
+	IgnoreCaseAliases []string `yaml:"ignore_case_aliases"`
+	Lat               *float64 `yaml:"lat"`
+	Lon               *float64 `yaml:"lon"`
+}
+
+// Gazetteer tags the known places and organizations mentioned in articles.
+// Mentions match whole words written as in the gazetteer, so that common words
+// such as "mine" or "valley" are not tagged. Only aliases marked to ignore case,
+// such as "the Soo", match whatever their capitalization.
+type Gazetteer struct {
+	// terms maps the first word of each name and alias to the terms it starts
+	terms map[string][]term
+	// locations holds the coordinates of places by name
+	locations map[string]domain.GeoPoint
+}
+
+// term is one name or alias of a gazetteer entry.
+type term struct {
+	// words are the lowercased words of the term
+	words []string
+	// cased are the words as written in the gazetteer
+	cased []string
+	// ignoreCase is set for aliases that match whatever their capitalization
+	ignoreCase bool
+	name       string
+	kind       int
+}
+
+// NewGazetteer returns an empty gazetteer.
+func NewGazetteer() *Gazetteer {
+	return &Gazetteer{
+		terms:     make(map[string][]term),
+		locations: make(map[string]domain.GeoPoint),
+	}
+}
+
+// LoadGazetteer reads a gazetteer file.
+func LoadGazetteer(path string) (*Gazetteer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gazetteer: %w", err)
+	}
+
+	var file GazetteerFile
+	if unmarshalErr := yaml.Unmarshal(data, &file); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse gazetteer %s: %w", path, unmarshalErr)
+	}
+
+	g := NewGazetteer()
+	for _, entry := range file.Places {
+		if coordErr := configtypes.ValidateCoordinates(entry.Lat, entry.Lon); coordErr != nil {
+			return nil, fmt.Errorf("invalid gazetteer place %q: %w", entry.Name, coordErr)
+		}
+		g.AddPlace(entry.Name, domain.NewGeoPoint(entry.Lat, entry.Lon), entry.Aliases...)
+		g.addIgnoreCase(kindPlace, entry.Name, entry.IgnoreCaseAliases)
+	}
+	for _, entry := range file.Organizations {
+		g.AddOrganization(entry.Name, entry.Aliases...)
+		g.addIgnoreCase(kindOrganization, entry.Name, entry.IgnoreCaseAliases)
+	}
+	return g, nil
+}
+
+// AddPlace adds a place and its aliases. The location may be nil; a place
+// added again keeps the first location it was given.
+func (g *Gazetteer) AddPlace(name string, location *domain.GeoPoint, aliases ...string) {
+	name = strings.TrimSpace(name)
+	if _, known := g.locations[name]; !known && location != nil && name != "" {
+		g.locations[name] = *location
+	}
+	g.add(kindPlace, name, aliases)
+}
+
+// AddOrganization adds an organization and its aliases.
+func (g *Gazetteer) AddOrganization(name string, aliases ...string) {
+	g.add(kindOrganization, name, aliases)
+}
+
+// add indexes a name and its aliases by their first word.
+func (g *Gazetteer) add(kind int, name string, aliases []string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	for _, variant := range append([]string{name}, aliases...) {
+		g.addTerm(kind, name, variant, false)
+	}
+}
+
+// addIgnoreCase indexes aliases of a name that match whatever their capitalization.
+func (g *Gazetteer) addIgnoreCase(kind int, name string, aliases []string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	for _, alias := range aliases {
+		g.addTerm(kind, name, alias, true)
+	}
+}
+
+// addTerm indexes one name or alias by its lowercased first word.
+func (g *Gazetteer) addTerm(kind int, name, variant string, ignoreCase bool) {
+	cased := tokenize(variant)
+	if len(cased) == 0 {
+		return
+	}
+	words := make([]string, len(cased))
+	for i, word := range cased {
+		words[i] = strings.ToLower(word)
+	}
+	g.terms[words[0]] = append(g.terms[words[0]], term{
+		words:      words,
+		cased:      cased,
+		ignoreCase: ignoreCase,
+		name:       name,
+		kind:       kind,
+	})
+}
+
+// Enrich sets Places, PlaceLocations and Organizations from the title and body.
+func (g *Gazetteer) Enrich(article *domain.Article) {
+	article.Places, article.Organizations = g.Tag(article.Title + "\n" + article.Body)
+	article.PlaceLocations = nil
+	for _, place := range article.Places {
+		if location, ok := g.locations[place]; ok {
+			article.PlaceLocations = append(article.PlaceLocations, location)
+		}
+	}
+}
+
+// Tag returns the names of the places and organizations mentioned in a text,
+// in order of first mention. Where names overlap the longest one wins, so
+// "Sudbury Wolves" is not also tagged as the place "Sudbury"; between terms of
+// the same length one written as in the text wins over one that ignores case.
+func (g *Gazetteer) Tag(text string) ([]string, []string) {
+	tokens := tokenize(text)
+	lower := make([]string, len(tokens))
+	for i, token := range tokens {
+		lower[i] = strings.ToLower(token)
+	}
+
+	var places, organizations []string
+	seen := make(map[string]bool)
+	for i := 0; i < len(tokens); {
+		var best *term
+		bestCased := false
+		for j := range g.terms[lower[i]] {
+			candidate := &g.terms[lower[i]][j]
+			cased := matchesAt(tokens, i, candidate.cased)
+			if !cased && (!candidate.ignoreCase || !matchesAt(lower, i, candidate.words)) {
+				continue
+			}
+			if best == nil || len(candidate.words) > len(best.words) ||
+				(len(candidate.words) == len(best.words) && cased && !bestCased) {
+				best, bestCased = candidate, cased
+			}
+		}
+		if best == nil {
+			i++
+			continue
+		}
+
+		key := fmt.Sprintf("%d|%s", best.kind, best.name)
+		if !seen[key] {
+			seen[key] = true
+			if best.kind == kindPlace {
+				places = append(places, best.name)
+			} else {
+				organizations = append(organizations, best.name)
+			}
+		}
+		i += len(best.words)
+	}
+	return places, organizations
+}
+
+// matchesAt reports whether words occur in tokens at position i.
+func matchesAt(tokens []string, i int, words []string) bool {
+	if i+len(words) > len(tokens) {
+		return false
+	}
+	for j, word := range words {
+		if tokens[i+j] != word {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenize splits text into words of letters and digits, so that "Sault Ste.
+// Marie" and "Sault-Ste-Marie" both become the words Sault, Ste and Marie.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,182 @@
+package enrich
+
+import (
+	"embed"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+)
+
+const (
+	// maxPhraseWords is the longest keyphrase kept; RAKE favours long phrases,
+	// which rarely make useful keywords.
+	maxPhraseWords = 3
+	// minWordLength is the shortest word considered part of a keyphrase.
+	minWordLength = 3
+)
+
+//go:embed stopwords/*.txt
+var stopwordFiles embed.FS
+
+var (
+	stopwords     map[string]map[string]bool
+	stopwordsOnce sync.Once
+)
+
+// KeyphraseExtractor extracts the main keyphrases of an article with RAKE
+// (Rapid Automatic Keyword Extraction): candidate phrases are the runs of words
+// between stopwords and punctuation, and each is scored by the co-occurrence
+// degree of its words relative to their frequency. Unlike plain RAKE, the score
+// is weighted by how often the phrase occurs, so that a repeated two-word topic
+// outranks a long phrase used once. It needs no corpus, so the result of an
+// article does not depend on what was crawled before it.
+type KeyphraseExtractor struct {
+	max int
+}
+
+// NewKeyphraseExtractor returns an extractor keeping up to max keyphrases.
+func NewKeyphraseExtractor(maxKeyphrases int) *KeyphraseExtractor {
+	if maxKeyphrases < 1 {
+		maxKeyphrases = crawlerconfig.DefaultMaxKeyphrases
+	}
+	return &KeyphraseExtractor{max: maxKeyphrases}
+}
+
+// Enrich sets Keyphrases from the title and body, using the stopwords of the
+// article's language or of all languages when it is unknown.
+func (k *KeyphraseExtractor) Enrich(article *domain.Article) {
+	article.Keyphrases = k.Extract(article.Title+".\n"+article.Body, article.Language)
+}
+
+// Extract returns the keyphrases of a text, best first.
+func (k *KeyphraseExtractor) Extract(text, lang string) []string {
+	stop := stopwordsFor(lang)
+	phrases := candidates(text, stop)
+
+	frequency := make(map[string]int)
+	degree := make(map[string]int)
+	for _, phrase := range phrases {
+		for _, word := range phrase {
+			frequency[word]++
+			degree[word] += len(phrase)
+		}
+	}
+
+	scores := make(map[string]float64)
+	for _, phrase := range phrases {
+		var score float64
+		for _, word := range phrase {
+			score += float64(degree[word]) / float64(frequency[word])
+		}
+		scores[strings.Join(phrase, " ")] += score
+	}
+
+	keyphrases := make([]string, 0, len(scores))
+	for key := range scores {
+		keyphrases = append(keyphrases, key)
+	}
+	sort.Slice(keyphrases, func(i, j int) bool {
+		if scores[keyphrases[i]] != scores[keyphrases[j]] {
+			return scores[keyphrases[i]] > scores[keyphrases[j]]
+		}
+		return keyphrases[i] < keyphrases[j]
+	})
+	if len(keyphrases) > k.max {
+		keyphrases = keyphrases[:k.max]
+	}
+	return keyphrases
+}
+
+// candidates splits a text into the lowercased word runs between stopwords and
+// punctuation. Runs longer than maxPhraseWords or containing short words or
+// numbers are dropped.
+func candidates(text string, stop map[string]bool) [][]string {
+	var phrases [][]string
+	var current []string
+	valid := true
+	flush := func() {
+		if valid && len(current) > 0 && len(current) <= maxPhraseWords {
+			phrases = append(phrases, current)
+		}
+		current = nil
+		valid = true
+	}
+
+	var word strings.Builder
+	endWord := func() {
+		if word.Len() == 0 {
+			return
+		}
+		w := strings.Trim(strings.TrimSuffix(word.String(), "'s"), "'-")
+		word.Reset()
+		if w == "" || stop[w] {
+			flush()
+			return
+		}
+		if len([]rune(w)) < minWordLength || strings.IndexFunc(w, unicode.IsDigit) >= 0 {
+			valid = false
+		}
+		current = append(current, w)
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		case (r == '\'' || r == '’') && word.Len() > 0:
+			// Elided articles such as l' and d' are stopwords of their own
+			word.WriteRune('\'')
+			if stop[word.String()] {
+				word.Reset()
+				flush()
+			}
+		case r == '-' && word.Len() > 0:
+			word.WriteRune(r)
+		case unicode.IsSpace(r):
+			endWord()
+		default:
+			endWord()
+			flush()
+		}
+	}
+	endWord()
+	flush()
+	return phrases
+}
+
+// stopwordsFor returns the stopwords of a language, or of all languages when
+// the language has none.
+func stopwordsFor(lang string) map[string]bool {
+	stopwordsOnce.Do(loadStopwords)
+	if stop, ok := stopwords[lang]; ok {
+		return stop
+	}
+	return stopwords[""]
+}
+
+// loadStopwords reads the embedded stopword lists; the "" entry holds their union.
+func loadStopwords() {
+	entries, err := stopwordFiles.ReadDir("stopwords")
+	if err != nil {
+		panic("enrich: stopwords not embedded: " + err.Error())
+	}
+
+	stopwords = map[string]map[string]bool{"": {}}
+	for _, entry := range entries {
+		data, readErr := stopwordFiles.ReadFile(path.Join("stopwords", entry.Name()))
+		if readErr != nil {
+			panic("enrich: stopwords not readable: " + readErr.Error())
+		}
+		lang := strings.TrimSuffix(entry.Name(), ".txt")
+		stopwords[lang] = make(map[string]bool)
+		for _, word := range strings.Fields(string(data)) {
+			stopwords[lang][word] = true
+			stopwords[""][word] = true
+		}
+	}
+}
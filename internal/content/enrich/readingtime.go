@@ -0,0 +1,27 @@
+package enrich
+
+import (
+	"strings"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+)
+
+// ReadingTime estimates how many minutes the body of an article takes to read.
+type ReadingTime struct {
+	wordsPerMinute int
+}
+
+// NewReadingTime returns a reading time estimator for a reading speed.
+func NewReadingTime(wordsPerMinute int) *ReadingTime {
+	if wordsPerMinute < 1 {
+		wordsPerMinute = crawlerconfig.DefaultWordsPerMinute
+	}
+	return &ReadingTime{wordsPerMinute: wordsPerMinute}
+}
+
+// Enrich sets ReadingTimeMinutes, rounding up so that any body takes at least a minute.
+func (r *ReadingTime) Enrich(article *domain.Article) {
+	words := len(strings.Fields(article.Body))
+	article.ReadingTimeMinutes = (words + r.wordsPerMinute - 1) / r.wordsPerMinute
+}
@@ -0,0 +1,55 @@
+package media
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Video providers recognized in iframes.
+const (
+	ProviderYouTube     = "youtube"
+	ProviderVimeo       = "vimeo"
+	ProviderDailymotion = "dailymotion"
+	ProviderFacebook    = "facebook"
+)
+
+// identifyEmbed returns the provider and video ID of an embedded player URL.
+// It reports false for iframes that are not video players, such as ads or maps.
+func identifyEmbed(u *url.URL) (string, string, bool) {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	path := strings.Trim(u.Path, "/")
+
+	switch host {
+	case "youtube.com", "m.youtube.com", "youtube-nocookie.com":
+		if id, ok := strings.CutPrefix(path, "embed/"); ok {
+			return ProviderYouTube, firstSegment(id), true
+		}
+		if path == "watch" {
+			return ProviderYouTube, u.Query().Get("v"), true
+		}
+	case "youtu.be":
+		return ProviderYouTube, firstSegment(path), true
+	case "player.vimeo.com":
+		if id, ok := strings.CutPrefix(path, "video/"); ok {
+			return ProviderVimeo, firstSegment(id), true
+		}
+	case "dailymotion.com", "geo.dailymotion.com":
+		if id, ok := strings.CutPrefix(path, "embed/video/"); ok {
+			return ProviderDailymotion, firstSegment(id), true
+		}
+		if strings.HasPrefix(path, "player") {
+			return ProviderDailymotion, u.Query().Get("video"), true
+		}
+	case "facebook.com":
+		if path == "plugins/video.php" {
+			return ProviderFacebook, "", true
+		}
+	}
+	return "", "", false
+}
+
+// firstSegment returns a path up to its first slash.
+func firstSegment(path string) string {
+	segment, _, _ := strings.Cut(path, "/")
+	return segment
+}
@@ -0,0 +1,292 @@
+// Package media collects the images, videos and embedded players of an article
+// body, resolving lazy-loading attributes and srcset candidates to absolute URLs.
+package media
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+)
+
+const (
+	// maxPixelSize is the largest width or height treated as a tracking pixel.
+	maxPixelSize = 2
+	// maxIconSize is the largest declared width and height treated as an icon.
+	maxIconSize = 48
+)
+
+// srcAttrs are the attributes holding an image URL, lazy-loading ones first.
+var srcAttrs = []string{"data-src", "data-lazy-src", "data-original", "data-url", "src"}
+
+// srcsetAttrs are the attributes holding a srcset, lazy-loading ones first.
+var srcsetAttrs = []string{"data-srcset", "data-lazy-srcset", "srcset"}
+
+// creditSelector matches elements holding a photo credit inside a figure.
+const creditSelector = ".credit, .photo-credit, .image-credit, .caption-credit, [class*='credit'], " +
+	"[itemprop='copyrightHolder'], [itemprop='creditText'], [itemprop='author']"
+
+// creditSuffix matches a credit written at the end of a caption, e.g. "(Photo: Jane Doe/Reuters)".
+var creditSuffix = regexp.MustCompile(
+	`(?i)[\s(\[]*(?:photo(?:graph)?|image|credit|courtesy|crédit|foto)(?:\s+by|\s+de|\s*:)\s*([^()\[\]]+?)[)\]]?\s*$`,
+)
+
+// Collector extracts images and media from a document.
+type Collector struct {
+	base *url.URL
+	seen map[string]bool
+}
+
+// NewCollector creates a collector resolving relative URLs against pageURL.
+func NewCollector(pageURL string) *Collector {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		base = nil
+	}
+	return &Collector{base: base, seen: make(map[string]bool)}
+}
+
+// Images returns the images inside the selection in document order. Tracking
+// pixels, icons and images already returned by the collector are skipped.
+func (c *Collector) Images(scope *goquery.Selection) []domain.Image {
+	if scope == nil {
+		return nil
+	}
+
+	var images []domain.Image
+	scope.Find("img").AddSelection(scope.Filter("img")).Each(func(_ int, img *goquery.Selection) {
+		image, renditions, ok := c.image(img)
+		if !ok || c.seen[image.Src] {
+			return
+		}
+		// Other renditions of the same image are not collected again
+		for _, rendition := range renditions {
+			c.seen[rendition] = true
+		}
+		images = append(images, image)
+	})
+	return images
+}
+
+// Media returns the videos and embedded players inside the selection in document order.
+func (c *Collector) Media(scope *goquery.Selection) []domain.Media {
+	if scope == nil {
+		return nil
+	}
+
+	var media []domain.Media
+	scope.Find("video, iframe").Each(func(_ int, s *goquery.Selection) {
+		var item domain.Media
+		var ok bool
+		if goquery.NodeName(s) == "video" {
+			item, ok = c.video(s)
+		} else {
+			item, ok = c.embed(s)
+		}
+		if !ok || c.seen[item.Src] {
+			return
+		}
+		c.seen[item.Src] = true
+		media = append(media, item)
+	})
+	return media
+}
+
+// image converts an img element, using its picture and figure for extra sources
+// and the caption. It also returns the URLs of all renditions of the image.
+func (c *Collector) image(img *goquery.Selection) (domain.Image, []string, bool) {
+	candidates := c.srcset(img)
+	if picture := img.Parent(); goquery.NodeName(picture) == "picture" {
+		picture.ChildrenFiltered("source").Each(func(_ int, source *goquery.Selection) {
+			candidates = append(candidates, c.srcset(source)...)
+		})
+	}
+
+	image := domain.Image{
+		Alt:    normalizeSpace(img.AttrOr("alt", "")),
+		Width:  dimension(img.AttrOr("width", "")),
+		Height: dimension(img.AttrOr("height", "")),
+	}
+
+	if best, ok := largest(candidates); ok {
+		image.Src = best.url
+		if image.Width == 0 && best.width > 0 {
+			// The height is only kept when it belongs to the same rendition
+			image.Width, image.Height = best.width, 0
+		}
+	}
+	src := c.firstURL(img, srcAttrs)
+	if image.Src == "" {
+		image.Src = src
+	}
+	if image.Src == "" || isPlaceholder(image) {
+		return domain.Image{}, nil, false
+	}
+
+	renditions := []string{image.Src, src}
+	for _, rendition := range candidates {
+		renditions = append(renditions, rendition.url)
+	}
+
+	if figure := img.Closest("figure"); figure.Length() > 0 {
+		image.Caption, image.Credit = captionAndCredit(figure)
+	}
+	if image.Credit == "" {
+		image.Credit = normalizeSpace(img.AttrOr("data-credit", ""))
+	}
+	return image, renditions, true
+}
+
+// video converts a video element.
+func (c *Collector) video(s *goquery.Selection) (domain.Media, bool) {
+	src := c.firstURL(s, srcAttrs)
+	if src == "" {
+		s.ChildrenFiltered("source").EachWithBreak(func(_ int, source *goquery.Selection) bool {
+			src = c.firstURL(source, srcAttrs)
+			return src == ""
+		})
+	}
+	if src == "" {
+		return domain.Media{}, false
+	}
+
+	return domain.Media{
+		Type:   domain.TypeVideo,
+		Src:    src,
+		Poster: c.resolve(s.AttrOr("poster", "")),
+		Title:  firstNonBlank(s.AttrOr("title", ""), s.AttrOr("aria-label", "")),
+		Width:  dimension(s.AttrOr("width", "")),
+		Height: dimension(s.AttrOr("height", "")),
+	}, true
+}
+
+// embed converts an iframe from a known video provider.
+func (c *Collector) embed(s *goquery.Selection) (domain.Media, bool) {
+	src := c.firstURL(s, srcAttrs)
+	if src == "" {
+		return domain.Media{}, false
+	}
+	parsed, err := url.Parse(src)
+	if err != nil {
+		return domain.Media{}, false
+	}
+
+	provider, videoID, ok := identifyEmbed(parsed)
+	if !ok {
+		return domain.Media{}, false
+	}
+
+	item := domain.Media{
+		Type:     domain.TypeVideo,
+		Provider: provider,
+		Src:      src,
+		VideoID:  videoID,
+		Title:    firstNonBlank(s.AttrOr("title", ""), s.AttrOr("aria-label", "")),
+		Width:    dimension(s.AttrOr("width", "")),
+		Height:   dimension(s.AttrOr("height", "")),
+	}
+	if provider == ProviderYouTube && videoID != "" {
+		item.Poster = "https://i.ytimg.com/vi/" + videoID + "/hqdefault.jpg"
+	}
+	return item, true
+}
+
+// firstURL returns the first non-empty attribute among attrs as an absolute URL.
+// Inline data: URIs are ignored because they are placeholders or icons.
+func (c *Collector) firstURL(s *goquery.Selection, attrs []string) string {
+	for _, attr := range attrs {
+		value := strings.TrimSpace(s.AttrOr(attr, ""))
+		if value == "" || strings.HasPrefix(value, "data:") {
+			continue
+		}
+		if resolved := c.resolve(value); resolved != "" {
+			return resolved
+		}
+	}
+	return ""
+}
+
+// resolve returns ref as an absolute http(s) URL, or "" if it cannot be resolved.
+func (c *Collector) resolve(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if c.base != nil {
+		parsed = c.base.ResolveReference(parsed)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return ""
+	}
+	return parsed.String()
+}
+
+// captionAndCredit returns the figure caption and credit. A credit element
+// wins; otherwise a trailing "Photo: ..." phrase is split off the caption.
+func captionAndCredit(figure *goquery.Selection) (string, string) {
+	caption := figure.ChildrenFiltered("figcaption").First()
+	if caption.Length() == 0 {
+		caption = figure.Find("figcaption").First()
+	}
+
+	var credit string
+	if creditElement := figure.Find(creditSelector).First(); creditElement.Length() > 0 {
+		credit = normalizeSpace(creditElement.Text())
+	}
+
+	if caption.Length() == 0 {
+		return "", credit
+	}
+
+	captionCopy := caption.Clone()
+	captionCopy.Find(creditSelector).Remove()
+	text := normalizeSpace(captionCopy.Text())
+
+	if credit == "" {
+		if match := creditSuffix.FindStringSubmatchIndex(text); match != nil {
+			credit = strings.TrimSpace(text[match[2]:match[3]])
+			text = strings.TrimSpace(text[:match[0]])
+		}
+	}
+	return text, credit
+}
+
+// isPlaceholder reports whether an image is a tracking pixel or an icon.
+func isPlaceholder(image domain.Image) bool {
+	if (image.Width > 0 && image.Width <= maxPixelSize) || (image.Height > 0 && image.Height <= maxPixelSize) {
+		return true
+	}
+	return image.Width > 0 && image.Width <= maxIconSize && image.Height > 0 && image.Height <= maxIconSize
+}
+
+// dimension parses a width or height attribute, ignoring relative values such as "100%".
+func dimension(value string) int {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "px")
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// normalizeSpace collapses runs of whitespace into single spaces.
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// firstNonBlank returns the first value that is not blank.
+func firstNonBlank(values ...string) string {
+	for _, value := range values {
+		if value = normalizeSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
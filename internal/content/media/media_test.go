@@ -0,0 +1,112 @@
+package media_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/jonesrussell/gocrawl/internal/content/media"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pageURL = "https://example.com/news/2026/10/bridge"
+
+func parse(t *testing.T, markup string) *goquery.Selection {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(markup))
+	require.NoError(t, err)
+	return doc.Selection
+}
+
+func TestImages(t *testing.T) {
+	t.Parallel()
+
+	doc := parse(t, `<html><body><article>
+<figure>
+  <picture>
+    <source media="(min-width: 800px)" srcset="/img/bridge-1600.webp 1600w, /img/bridge-800.webp 800w">
+    <img src="/img/bridge-400.jpg" alt="The  reopened bridge" srcset="/img/bridge-400.jpg 400w, /img/bridge-800.jpg 800w">
+  </picture>
+  <figcaption>Traffic crosses the bridge on Monday. <span class="photo-credit">Jane Doe/Example News</span></figcaption>
+</figure>
+<p>Text <img src="/pixel.gif" width="1" height="1"></p>
+<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" data-src="//cdn.example.com/lazy.jpg" width="640" height="360" alt="">
+<figure>
+  <img src="https://cdn.example.com/mayor.jpg" alt="Mayor">
+  <figcaption>The mayor speaks. (Photo: Sam Lens/Reuters)</figcaption>
+</figure>
+<img src="/icons/share.png" width="24" height="24">
+<img src="/img/bridge-400.jpg">
+</article></body></html>`)
+
+	images := media.NewCollector(pageURL).Images(doc.Find("article"))
+	require.Len(t, images, 3)
+
+	assert.Equal(t, domain.Image{
+		Src:     "https://example.com/img/bridge-1600.webp",
+		Alt:     "The reopened bridge",
+		Caption: "Traffic crosses the bridge on Monday.",
+		Credit:  "Jane Doe/Example News",
+		Width:   1600,
+	}, images[0])
+	assert.Equal(t, domain.Image{Src: "https://cdn.example.com/lazy.jpg", Width: 640, Height: 360}, images[1])
+	assert.Equal(t, domain.Image{
+		Src:     "https://cdn.example.com/mayor.jpg",
+		Alt:     "Mayor",
+		Caption: "The mayor speaks.",
+		Credit:  "Sam Lens/Reuters",
+	}, images[2])
+}
+
+func TestMedia(t *testing.T) {
+	t.Parallel()
+
+	doc := parse(t, `<html><body><article>
+<iframe src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ?rel=0" title="Council meeting" width="560" height="315"></iframe>
+<iframe data-src="https://player.vimeo.com/video/76979871" src="about:blank"></iframe>
+<iframe src="https://ads.example.net/frame.html"></iframe>
+<video poster="/img/poster.jpg" width="1280"><source src="/video/clip.mp4" type="video/mp4"></video>
+</article></body></html>`)
+
+	items := media.NewCollector(pageURL).Media(doc.Find("article"))
+	require.Len(t, items, 3)
+
+	assert.Equal(t, domain.Media{
+		Type:     domain.TypeVideo,
+		Provider: media.ProviderYouTube,
+		Src:      "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ?rel=0",
+		VideoID:  "dQw4w9WgXcQ",
+		Poster:   "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg",
+		Title:    "Council meeting",
+		Width:    560,
+		Height:   315,
+	}, items[0])
+	assert.Equal(t, media.ProviderVimeo, items[1].Provider)
+	assert.Equal(t, "76979871", items[1].VideoID)
+	assert.Equal(t, domain.Media{
+		Type:   domain.TypeVideo,
+		Src:    "https://example.com/video/clip.mp4",
+		Poster: "https://example.com/img/poster.jpg",
+		Width:  1280,
+	}, items[2])
+}
+
+func TestCollectorSkipsDuplicatesAcrossCalls(t *testing.T) {
+	t.Parallel()
+
+	doc := parse(t, `<html><body>
+<div class="lead"><img src="/img/lead.jpg" alt="Lead"></div>
+<article><img src="/img/lead.jpg" alt="Lead again"><img src="/img/second.jpg"></article>
+</body></html>`)
+
+	collector := media.NewCollector(pageURL)
+	lead := collector.Images(doc.Find(".lead img"))
+	body := collector.Images(doc.Find("article"))
+
+	require.Len(t, lead, 1)
+	assert.Equal(t, "Lead", lead[0].Alt)
+	require.Len(t, body, 1)
+	assert.Equal(t, "https://example.com/img/second.jpg", body[0].Src)
+}
@@ -0,0 +1,102 @@
+package media
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// candidate is one image rendition from a srcset.
+type candidate struct {
+	url string
+	// width is the w descriptor, 0 when absent
+	width int
+	// density is the x descriptor, 1 when absent
+	density float64
+}
+
+// srcset returns the renditions listed in an element's srcset attributes.
+func (c *Collector) srcset(s *goquery.Selection) []candidate {
+	for _, attr := range srcsetAttrs {
+		value := strings.TrimSpace(s.AttrOr(attr, ""))
+		if value == "" {
+			continue
+		}
+
+		var candidates []candidate
+		for _, parsed := range parseSrcset(value) {
+			if resolved := c.resolve(parsed.url); resolved != "" {
+				parsed.url = resolved
+				candidates = append(candidates, parsed)
+			}
+		}
+		if len(candidates) > 0 {
+			return candidates
+		}
+	}
+	return nil
+}
+
+// parseSrcset parses "a.jpg 640w, b.jpg 1280w" or "a.jpg, b.jpg 2x". URLs may
+// contain commas, so a candidate only ends at a comma followed by whitespace
+// or at a comma after its descriptor.
+func parseSrcset(value string) []candidate {
+	var candidates []candidate
+	current := candidate{density: 1}
+
+	flush := func() {
+		if current.url != "" {
+			candidates = append(candidates, current)
+		}
+		current = candidate{density: 1}
+	}
+
+	for _, token := range strings.Fields(value) {
+		ends := strings.HasSuffix(token, ",")
+		token = strings.TrimSuffix(token, ",")
+
+		if current.url == "" {
+			current.url = token
+		} else {
+			applyDescriptor(&current, token)
+		}
+		if ends {
+			flush()
+		}
+	}
+	flush()
+	return candidates
+}
+
+// applyDescriptor applies a width (640w) or pixel density (2x) descriptor.
+func applyDescriptor(c *candidate, descriptor string) {
+	if descriptor == "" {
+		return
+	}
+	number := descriptor[:len(descriptor)-1]
+	switch descriptor[len(descriptor)-1] {
+	case 'w':
+		if width, err := strconv.Atoi(number); err == nil {
+			c.width = width
+		}
+	case 'x':
+		if density, err := strconv.ParseFloat(number, 64); err == nil {
+			c.density = density
+		}
+	}
+}
+
+// largest returns the widest rendition, or the densest one when no widths are given.
+func largest(candidates []candidate) (candidate, bool) {
+	if len(candidates) == 0 {
+		return candidate{}, false
+	}
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.width > best.width || (c.width == best.width && c.density > best.density) {
+			best = c
+		}
+	}
+	return best, true
+}
@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/jonesrussell/gocrawl/internal/common/urlnorm"
 	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/content/readability"
 )
 
 var (
@@ -194,21 +196,26 @@ func GetSelectorsForURL(sourceManager interface {
 }
 
 // extractPage extracts page data from HTML element using selectors.
-func extractPage(e *colly.HTMLElement, selectors configtypes.PageSelectors, sourceURL string) *PageData {
+// The canonicalizer determines the canonical URL the page ID is generated from,
+// and the extraction mode decides when readability scoring replaces the selectors.
+func extractPage(
+	e *colly.HTMLElement,
+	selectors configtypes.PageSelectors,
+	sourceURL string,
+	canonicalizer *urlnorm.Canonicalizer,
+	extraction string,
+) *PageData {
 	data := &PageData{
 		URL:       sourceURL,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
-	// Generate ID from URL
-	data.ID = generateID(sourceURL)
-
 	// Extract title
 	extractPageTitle(data, e, selectors)
 
 	// Extract content
-	extractPageContent(data, e, selectors)
+	extractPageContent(data, e, selectors, extraction)
 
 	// Extract description and keywords
 	extractPageDescriptionKeywords(data, e, selectors)
@@ -217,7 +224,10 @@ func extractPage(e *colly.HTMLElement, selectors configtypes.PageSelectors, sour
 	extractPageOpenGraphMetadata(data, e, selectors)
 
 	// Extract canonical URL
-	extractPageCanonicalURL(data, e, selectors, sourceURL)
+	extractPageCanonicalURL(data, e, selectors, sourceURL, canonicalizer)
+
+	// Generate ID from the canonical URL's key so http and https copies share an ID
+	data.ID = generateID(urlnorm.Key(data.CanonicalURL))
 
 	return data
 }
@@ -235,8 +245,14 @@ func extractPageTitle(data *PageData, e *colly.HTMLElement, selectors configtype
 	}
 }
 
-// extractPageContent extracts the page content with multiple fallback strategies.
-func extractPageContent(data *PageData, e *colly.HTMLElement, selectors configtypes.PageSelectors) {
+// extractPageContent extracts the page content with multiple fallback strategies
+// and records which strategy produced it.
+func extractPageContent(
+	data *PageData,
+	e *colly.HTMLElement,
+	selectors configtypes.PageSelectors,
+	extraction string,
+) {
 	// Extract content - use container selector if available, otherwise use content selector
 	if selectors.Container != "" {
 		// Use container-based extraction with excludes applied
@@ -250,7 +266,15 @@ func extractPageContent(data *PageData, e *colly.HTMLElement, selectors configty
 		data.Content = extractText(e, selectors.Content)
 	}
 
+	// Fall back to (or, depending on the mode, compare with) readability scoring
+	data.Content, data.ExtractionMethod = readability.Choose(
+		extraction, data.Content, readability.StrategySelectors, e.DOM)
+	if data.Content != "" {
+		return
+	}
+
 	// Additional fallbacks if still empty
+	data.ExtractionMethod = readability.StrategyContainer
 	if data.Content == "" {
 		// Try common content containers
 		data.Content = extractTextFromContainer(e, "main", selectors.Exclude)
@@ -264,6 +288,9 @@ func extractPageContent(data *PageData, e *colly.HTMLElement, selectors configty
 		bodyText := e.ChildText("body")
 		data.Content = cleanText(bodyText)
 	}
+	if data.Content == "" {
+		data.ExtractionMethod = readability.StrategyNone
+	}
 }
 
 // extractPageDescriptionKeywords extracts description and keywords.
@@ -325,14 +352,13 @@ func extractPageCanonicalURL(
 	e *colly.HTMLElement,
 	selectors configtypes.PageSelectors,
 	sourceURL string,
+	canonicalizer *urlnorm.Canonicalizer,
 ) {
-	data.CanonicalURL = extractAttr(e, selectors.Canonical, "href")
-	if data.CanonicalURL == "" {
-		data.CanonicalURL = extractAttr(e, "link[rel='canonical']", "href")
-	}
-	if data.CanonicalURL == "" {
-		data.CanonicalURL = sourceURL
-	}
+	data.CanonicalURL = canonicalizer.Resolve(sourceURL,
+		extractAttr(e, selectors.Canonical, "href"),
+		extractAttr(e, "link[rel='canonical']", "href"),
+		data.OgURL,
+	)
 }
 
 // PageData holds extracted page data before conversion to models.Page
@@ -348,6 +374,8 @@ type PageData struct {
 	OgImage       string
 	OgURL         string
 	CanonicalURL  string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// ExtractionMethod records which strategy produced Content
+	ExtractionMethod string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
@@ -9,8 +9,10 @@ import (
 	"strings"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/jonesrussell/gocrawl/internal/common/urlnorm"
 	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
 	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/jonesrussell/gocrawl/internal/language"
 	"github.com/jonesrussell/gocrawl/internal/logger"
 	"github.com/jonesrussell/gocrawl/internal/sources"
 	sourcestypes "github.com/jonesrussell/gocrawl/internal/sources/types"
@@ -29,6 +31,8 @@ type ContentService struct {
 	indexName     string
 	sources       sources.Interface
 	sourceManager SourceManager
+	// canonicalizers caches the URL canonicalizer of each source
+	canonicalizers urlnorm.Cache
 }
 
 // SourceManager defines the interface for managing sources.
@@ -97,9 +101,18 @@ func (s *ContentService) Process(e *colly.HTMLElement) error {
 	// Use local variable to avoid data race when Process() is called concurrently
 	indexName := s.indexName
 	selectors := GetSelectorsForURL(s.sourceManager, sourceURL)
+	var canonicalizer *urlnorm.Canonicalizer
+	var extraction, locale, city, region string
+	var location *domain.GeoPoint
+	var groups []string
 	if s.sources != nil {
 		sourceConfig := s.findSourceByURL(sourceURL)
 		if sourceConfig != nil {
+			canonicalizer = s.canonicalizers.ForSource(sourceConfig.Name, sourceConfig.URLRewrites)
+			extraction = sourceConfig.Extraction
+			locale = sourceConfig.Locale
+			groups = sourceConfig.Groups()
+			city, region, location = sourceConfig.CityName, sourceConfig.Region, sourceConfig.Location()
 			// Use source's page index if available (local variable, no race condition)
 			// Prefer PageIndex, fallback to Index for backward compatibility
 			if sourceConfig.PageIndex != "" {
@@ -111,23 +124,29 @@ func (s *ContentService) Process(e *colly.HTMLElement) error {
 	}
 
 	// Extract page data using Colly methods with selectors
-	pageData := extractPage(e, selectors, sourceURL)
+	pageData := extractPage(e, selectors, sourceURL, canonicalizer, extraction)
 
 	// Convert to domain.Page
 	page := &domain.Page{
-		ID:            pageData.ID,
-		URL:           pageData.URL,
-		Title:         pageData.Title,
-		Content:       pageData.Content,
-		Description:   pageData.Description,
-		Keywords:      pageData.Keywords,
-		OgTitle:       pageData.OgTitle,
-		OgDescription: pageData.OgDescription,
-		OgImage:       pageData.OgImage,
-		OgURL:         pageData.OgURL,
-		CanonicalURL:  pageData.CanonicalURL,
-		CreatedAt:     pageData.CreatedAt,
-		UpdatedAt:     pageData.UpdatedAt,
+		ID:               pageData.ID,
+		URL:              pageData.URL,
+		Title:            pageData.Title,
+		Content:          pageData.Content,
+		Description:      pageData.Description,
+		Keywords:         pageData.Keywords,
+		OgTitle:          pageData.OgTitle,
+		OgDescription:    pageData.OgDescription,
+		OgImage:          pageData.OgImage,
+		OgURL:            pageData.OgURL,
+		CanonicalURL:     pageData.CanonicalURL,
+		ExtractionMethod: pageData.ExtractionMethod,
+		Language:         language.DetectDocument(locale, pageData.Title, pageData.Content),
+		Groups:           groups,
+		City:             city,
+		Region:           region,
+		Location:         location,
+		CreatedAt:        pageData.CreatedAt,
+		UpdatedAt:        pageData.UpdatedAt,
 	}
 
 	// Index the page to Elasticsearch
@@ -144,7 +163,8 @@ func (s *ContentService) Process(e *colly.HTMLElement) error {
 		"pageID", page.ID,
 		"url", page.URL,
 		"index", indexName,
-		"title", page.Title)
+		"title", page.Title,
+		"extraction_method", page.ExtractionMethod)
 
 	return nil
 }
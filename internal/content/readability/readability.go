@@ -0,0 +1,322 @@
+// Package readability finds the main content of a page without configured
+// selectors. Like Mozilla Readability it scores paragraph containers by text
+// length and comma count, penalizes link-heavy and boilerplate blocks, and
+// keeps the best scoring container together with related siblings.
+package readability
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"golang.org/x/net/html"
+)
+
+// Strategies record which extraction strategy produced the body of a document.
+const (
+	// StrategySelectors means the configured CSS selectors produced the body.
+	StrategySelectors = "selectors"
+	// StrategyContainer means a generic container such as <article> or <main> produced the body.
+	StrategyContainer = "container"
+	// StrategyReadability means readability scoring produced the body.
+	StrategyReadability = "readability"
+	// StrategyStructuredData means the articleBody of schema.org structured data produced the body.
+	StrategyStructuredData = "structured_data"
+	// StrategyNone means no strategy produced a body.
+	StrategyNone = "none"
+)
+
+const (
+	// MinTextLength is the minimum length of extracted text for a result to be usable.
+	MinTextLength = 140
+	// minParagraphLength is the minimum length of a paragraph that contributes to scoring.
+	minParagraphLength = 25
+	// maxLengthBonus caps the score a paragraph earns for its length.
+	maxLengthBonus = 3
+	// lengthBonusUnit is the number of characters per length bonus point.
+	lengthBonusUnit = 100
+	// classWeight is added or subtracted for positive or negative class and id names.
+	classWeight = 25
+	// siblingScoreRatio is the fraction of the top score a sibling needs to be included.
+	siblingScoreRatio = 0.2
+	// minSiblingScore is the minimum score a sibling needs to be included.
+	minSiblingScore = 10
+	// siblingParagraphLength is the length above which a sibling paragraph is always included.
+	siblingParagraphLength = 80
+	// maxSiblingLinkDensity is the link density above which sibling paragraphs are dropped.
+	maxSiblingLinkDensity = 0.25
+	// autoPreferRatio is how much longer the readability text must be to win in auto mode.
+	autoPreferRatio = 1.5
+)
+
+// removeSelector matches elements that never hold main content.
+const removeSelector = "script, style, noscript, template, iframe, svg, form, button, " +
+	"nav, header, footer, aside, select, textarea, input, [hidden], [aria-hidden='true']"
+
+var (
+	unlikelyCandidates = regexp.MustCompile(`(?i)-ad-|ad-break|advert|banner|breadcrumb|combx|comment|` +
+		`community|cookie|disqus|extra|footer|gdpr|header|legends|menu|modal|newsletter|pager|popup|` +
+		`promo|related|remark|replies|rss|share|shoutbox|sidebar|social|sponsor|subscribe|tags|tool|widget`)
+	maybeCandidate = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow|story|entry|post|text`)
+	positiveNames  = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|` +
+		`pagination|post|text|blog|story`)
+	negativeNames = regexp.MustCompile(`(?i)-ad-|hidden|banner|combx|comment|com-|contact|foot|footer|` +
+		`footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|` +
+		`sponsor|shopping|social|tags|tool|widget`)
+)
+
+// Result is the outcome of readability extraction.
+type Result struct {
+	// Text is the extracted main content with paragraphs separated by blank lines
+	Text string
+	// Score is the score of the winning container
+	Score float64
+	// LinkDensity is the fraction of the winning container's text inside links
+	LinkDensity float64
+}
+
+// Extract finds the main content of a document. The selection is not modified.
+// It reports false when no container holds at least MinTextLength characters.
+func Extract(doc *goquery.Selection) (Result, bool) {
+	if doc == nil || doc.Length() == 0 {
+		return Result{}, false
+	}
+
+	root := doc.Clone()
+	root.Find(removeSelector).Remove()
+	removeUnlikelyCandidates(root)
+
+	candidates := scoreParagraphs(root)
+	top, topScore := candidates.top()
+	if top == nil {
+		return Result{}, false
+	}
+
+	text := collectText(top, candidates.scores, topScore)
+	if len(text) < MinTextLength {
+		return Result{}, false
+	}
+
+	return Result{
+		Text:        text,
+		Score:       topScore,
+		LinkDensity: linkDensity(goquery.NewDocumentFromNode(top).Selection),
+	}, true
+}
+
+// Choose returns the body and strategy for an extraction mode given the
+// result of selector-based extraction and the document to fall back to.
+func Choose(mode, selectorText, selectorStrategy string, doc *goquery.Selection) (string, string) {
+	selectorText = strings.TrimSpace(selectorText)
+
+	switch mode {
+	case configtypes.ExtractionReadability:
+		if result, ok := Extract(doc); ok {
+			return result.Text, StrategyReadability
+		}
+	case configtypes.ExtractionAuto:
+		if result, ok := Extract(doc); ok {
+			selectorWords := len(strings.Fields(selectorText))
+			if float64(len(strings.Fields(result.Text))) > autoPreferRatio*float64(selectorWords) {
+				return result.Text, StrategyReadability
+			}
+		}
+	default:
+		if selectorText == "" {
+			if result, ok := Extract(doc); ok {
+				return result.Text, StrategyReadability
+			}
+		}
+	}
+
+	if selectorText == "" {
+		return "", StrategyNone
+	}
+	return selectorText, selectorStrategy
+}
+
+// removeUnlikelyCandidates drops elements whose class or id marks them as boilerplate.
+func removeUnlikelyCandidates(root *goquery.Selection) {
+	root.Find("*").Each(func(_ int, s *goquery.Selection) {
+		if goquery.NodeName(s) == "body" || goquery.NodeName(s) == "article" {
+			return
+		}
+		names := classAndID(s)
+		if names != "" && unlikelyCandidates.MatchString(names) && !maybeCandidate.MatchString(names) {
+			s.Remove()
+		}
+	})
+}
+
+// candidates holds the scored containers in document order.
+type candidates struct {
+	scores map[*html.Node]float64
+	order  []*html.Node
+}
+
+// scoreParagraphs scores the parents and grandparents of every paragraph-like element.
+func scoreParagraphs(root *goquery.Selection) *candidates {
+	c := &candidates{scores: make(map[*html.Node]float64)}
+
+	root.Find("p, pre, td, blockquote, div").Each(func(_ int, s *goquery.Selection) {
+		// Only divs without block children behave like paragraphs
+		if goquery.NodeName(s) == "div" && s.ChildrenFiltered("p, div, table, ul, ol, pre, blockquote").Length() > 0 {
+			return
+		}
+
+		text := normalizeSpace(s.Text())
+		if len(text) < minParagraphLength {
+			return
+		}
+
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text)/lengthBonusUnit), maxLengthBonus)
+
+		parent := s.Parent()
+		if parent.Length() == 0 {
+			return
+		}
+		c.add(parent, score)
+
+		if grandparent := parent.Parent(); grandparent.Length() > 0 {
+			c.add(grandparent, score/2)
+		}
+	})
+
+	for _, node := range c.order {
+		c.scores[node] *= 1 - linkDensity(goquery.NewDocumentFromNode(node).Selection)
+	}
+	return c
+}
+
+// add adds to a candidate's score, initializing it from its tag and class names.
+func (c *candidates) add(s *goquery.Selection, score float64) {
+	node := s.Get(0)
+	if _, ok := c.scores[node]; !ok {
+		c.scores[node] = initialScore(s)
+		c.order = append(c.order, node)
+	}
+	c.scores[node] += score
+}
+
+// initialScore gives a candidate a head start based on its tag and class names.
+func initialScore(s *goquery.Selection) float64 {
+	var score float64
+	switch goquery.NodeName(s) {
+	case "article":
+		score = 10
+	case "main", "section", "div":
+		score = 5
+	case "pre", "td", "blockquote":
+		score = 3
+	case "ol", "ul", "dl", "dd", "dt", "li", "form":
+		score = -3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score = -5
+	}
+
+	names := classAndID(s)
+	if negativeNames.MatchString(names) {
+		score -= classWeight
+	}
+	if positiveNames.MatchString(names) {
+		score += classWeight
+	}
+	return score
+}
+
+// top returns the highest scoring candidate; ties go to the first in document order.
+func (c *candidates) top() (*html.Node, float64) {
+	var top *html.Node
+	topScore := math.Inf(-1)
+	for _, node := range c.order {
+		if score := c.scores[node]; score > topScore {
+			top, topScore = node, score
+		}
+	}
+	if top == nil || topScore <= 0 {
+		return nil, 0
+	}
+	return top, topScore
+}
+
+// collectText joins the text of the top candidate and its related siblings.
+func collectText(top *html.Node, scores map[*html.Node]float64, topScore float64) string {
+	threshold := math.Max(minSiblingScore, topScore*siblingScoreRatio)
+
+	var parts []string
+	for sibling := firstSibling(top); sibling != nil; sibling = sibling.NextSibling {
+		if sibling.Type != html.ElementNode {
+			continue
+		}
+
+		s := goquery.NewDocumentFromNode(sibling).Selection
+		include := sibling == top || scores[sibling] >= threshold
+		if !include && sibling.Data == "p" {
+			text := normalizeSpace(s.Text())
+			include = len(text) > siblingParagraphLength && linkDensity(s) < maxSiblingLinkDensity
+		}
+		if include {
+			parts = append(parts, blockText(s)...)
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// firstSibling returns the first sibling of a node, or the node itself at the root.
+func firstSibling(node *html.Node) *html.Node {
+	if node.Parent == nil {
+		return node
+	}
+	return node.Parent.FirstChild
+}
+
+// blockText returns the text of the block-level elements in a selection, one entry per block.
+func blockText(s *goquery.Selection) []string {
+	blocks := s.Find("p, h2, h3, h4, h5, h6, li, pre, blockquote")
+	if blocks.Length() == 0 {
+		if text := normalizeSpace(s.Text()); text != "" {
+			return []string{text}
+		}
+		return nil
+	}
+
+	var parts []string
+	blocks.Each(func(_ int, block *goquery.Selection) {
+		// Nested blocks are covered by their outermost block
+		if block.ParentsFiltered("p, li, pre, blockquote").Length() > 0 {
+			return
+		}
+		if text := normalizeSpace(block.Text()); text != "" {
+			parts = append(parts, text)
+		}
+	})
+	return parts
+}
+
+// linkDensity returns the fraction of a selection's text that sits inside links.
+func linkDensity(s *goquery.Selection) float64 {
+	total := len(normalizeSpace(s.Text()))
+	if total == 0 {
+		return 0
+	}
+	var linked int
+	s.Find("a").Each(func(_ int, a *goquery.Selection) {
+		linked += len(normalizeSpace(a.Text()))
+	})
+	return math.Min(float64(linked)/float64(total), 1)
+}
+
+// classAndID returns an element's class and id attributes joined by a space.
+func classAndID(s *goquery.Selection) string {
+	class, _ := s.Attr("class")
+	id, _ := s.Attr("id")
+	return strings.TrimSpace(class + " " + id)
+}
+
+// normalizeSpace collapses runs of whitespace.
+func normalizeSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
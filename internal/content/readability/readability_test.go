@@ -0,0 +1,83 @@
+package readability_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/content/readability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const articleHTML = `<html><head><title>Council approves budget</title></head><body>
+<nav class="menu"><a href="/">Home</a> <a href="/news">News</a> <a href="/sports">Sports</a></nav>
+<div class="sidebar"><p>Most read: <a href="/a">Story A</a>, <a href="/b">Story B</a>, <a href="/c">Story C</a></p></div>
+<div class="x7f3">
+  <h1>Council approves budget</h1>
+  <p>City council approved the annual budget on Tuesday, after a lengthy debate that stretched late into the evening.</p>
+  <p>The budget includes new funding for road repairs, library hours, and an expanded transit service, the mayor said.</p>
+  <p>Residents will see a property tax increase of two percent, which councillors described as modest, necessary, and fair.</p>
+</div>
+<div class="share-tools"><a href="#">Share on Facebook</a> <a href="#">Share on X</a></div>
+<footer><p>Copyright 2026 Example News. All rights reserved, including the right to reproduce.</p></footer>
+</body></html>`
+
+func parse(t *testing.T, markup string) *goquery.Selection {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(markup))
+	require.NoError(t, err)
+	return doc.Selection
+}
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	doc := parse(t, articleHTML)
+	result, ok := readability.Extract(doc)
+	require.True(t, ok)
+
+	assert.Contains(t, result.Text, "City council approved the annual budget")
+	assert.Contains(t, result.Text, "property tax increase")
+	assert.NotContains(t, result.Text, "Most read")
+	assert.NotContains(t, result.Text, "Share on Facebook")
+	assert.NotContains(t, result.Text, "Copyright")
+	assert.Positive(t, result.Score)
+	assert.Less(t, result.LinkDensity, 0.25)
+
+	// The input document is left untouched
+	assert.Equal(t, 1, doc.Find("nav").Length())
+}
+
+func TestExtractTooShort(t *testing.T) {
+	t.Parallel()
+
+	_, ok := readability.Extract(parse(t, `<html><body><div><p>Just a short note.</p></div></body></html>`))
+	assert.False(t, ok)
+
+	_, ok = readability.Extract(nil)
+	assert.False(t, ok)
+}
+
+func TestChoose(t *testing.T) {
+	t.Parallel()
+
+	doc := parse(t, articleHTML)
+
+	body, strategy := readability.Choose(configtypes.ExtractionSelectors, "Selector body", readability.StrategySelectors, doc)
+	assert.Equal(t, "Selector body", body)
+	assert.Equal(t, readability.StrategySelectors, strategy)
+
+	body, strategy = readability.Choose("", "", readability.StrategySelectors, doc)
+	assert.Contains(t, body, "annual budget")
+	assert.Equal(t, readability.StrategyReadability, strategy)
+
+	body, strategy = readability.Choose(configtypes.ExtractionAuto, "Council approves budget", readability.StrategySelectors, doc)
+	assert.Contains(t, body, "annual budget")
+	assert.Equal(t, readability.StrategyReadability, strategy)
+
+	_, strategy = readability.Choose(configtypes.ExtractionReadability, "", readability.StrategySelectors,
+		parse(t, `<html><body></body></html>`))
+	assert.Equal(t, readability.StrategyNone, strategy)
+}
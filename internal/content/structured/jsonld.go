@@ -0,0 +1,171 @@
+package structured
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// extractJSONLD returns the first article object found in the JSON-LD blocks of a document.
+func extractJSONLD(doc *goquery.Selection, selector string) *Data {
+	var found *Data
+	doc.Find(selector).EachWithBreak(func(_ int, script *goquery.Selection) bool {
+		var raw any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(script.Text())), &raw); err != nil {
+			return true
+		}
+
+		objects := flattenJSONLD(raw)
+		index := indexByID(objects)
+		for _, obj := range objects {
+			if isArticle(obj["@type"]) {
+				found = dataFromJSONLD(obj, index)
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// flattenJSONLD returns every object in a JSON-LD document, expanding arrays and @graph.
+func flattenJSONLD(raw any) []map[string]any {
+	var objects []map[string]any
+	switch v := raw.(type) {
+	case []any:
+		for _, item := range v {
+			objects = append(objects, flattenJSONLD(item)...)
+		}
+	case map[string]any:
+		if graph, ok := v["@graph"]; ok {
+			objects = append(objects, flattenJSONLD(graph)...)
+		}
+		if _, ok := v["@type"]; ok {
+			objects = append(objects, v)
+		}
+	}
+	return objects
+}
+
+// indexByID maps @id values to their objects so references can be resolved.
+func indexByID(objects []map[string]any) map[string]map[string]any {
+	index := make(map[string]map[string]any, len(objects))
+	for _, obj := range objects {
+		if id, ok := obj["@id"].(string); ok && id != "" {
+			index[id] = obj
+		}
+	}
+	return index
+}
+
+// isArticle reports whether a JSON-LD @type value names an article type.
+func isArticle(typeValue any) bool {
+	for _, t := range stringsOf(typeValue) {
+		if articleTypes[t] {
+			return true
+		}
+	}
+	return false
+}
+
+// dataFromJSONLD converts a JSON-LD article object.
+func dataFromJSONLD(obj map[string]any, index map[string]map[string]any) *Data {
+	data := &Data{
+		Headline:      firstNonEmpty(stringOf(obj["headline"]), stringOf(obj["name"])),
+		Description:   stringOf(obj["description"]),
+		Body:          stringOf(obj["articleBody"]),
+		DatePublished: stringOf(obj["datePublished"]),
+		DateModified:  stringOf(obj["dateModified"]),
+		URL:           firstNonEmpty(stringOf(obj["url"]), nameOf(obj["mainEntityOfPage"], index, "@id")),
+	}
+
+	if types := stringsOf(obj["@type"]); len(types) > 0 {
+		data.Type = types[0]
+	}
+	if sections := stringsOf(obj["articleSection"]); len(sections) > 0 {
+		data.Section = sections[0]
+	}
+
+	for _, author := range listOf(obj["author"]) {
+		data.Authors = appendUnique(data.Authors, nameOf(author, index, "name"))
+	}
+	for _, keyword := range stringsOf(obj["keywords"]) {
+		data.Keywords = appendUnique(data.Keywords, splitKeywords(keyword)...)
+	}
+	for _, image := range listOf(obj["image"]) {
+		data.Images = appendUnique(data.Images, nameOf(image, index, "url", "contentUrl"))
+	}
+	data.Publisher = nameOf(obj["publisher"], index, "name")
+
+	switch free := obj["isAccessibleForFree"].(type) {
+	case bool:
+		data.IsAccessibleForFree = &free
+	case string:
+		data.IsAccessibleForFree = parseBool(free)
+	}
+
+	switch count := obj["wordCount"].(type) {
+	case float64:
+		data.WordCount = int(count)
+	case string:
+		data.WordCount, _ = strconv.Atoi(strings.TrimSpace(count))
+	}
+
+	return data
+}
+
+// nameOf returns a value's text: strings are returned as is, objects (or @id
+// references to them) yield the first non-empty of the given keys.
+func nameOf(value any, index map[string]map[string]any, keys ...string) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		if id, ok := v["@id"].(string); ok {
+			if resolved, found := index[id]; found {
+				v = resolved
+			}
+		}
+		for _, key := range keys {
+			if s := stringOf(v[key]); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// listOf wraps a single value in a slice.
+func listOf(value any) []any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	default:
+		return []any{v}
+	}
+}
+
+// stringOf returns a JSON string value, or the first string of an array.
+func stringOf(value any) string {
+	if values := stringsOf(value); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// stringsOf returns the string values of a JSON value.
+func stringsOf(value any) []string {
+	var values []string
+	for _, item := range listOf(value) {
+		if s, ok := item.(string); ok {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,190 @@
+package structured
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// scopedSyntax describes an attribute-based structured data syntax. Microdata
+// and RDFa both mark an item with a scope attribute carrying its type and its
+// properties with a property attribute; they only differ in attribute names.
+type scopedSyntax struct {
+	// typeAttr holds the item type, e.g. itemtype or typeof
+	typeAttr string
+	// propAttr holds property names, e.g. itemprop or property
+	propAttr string
+}
+
+var (
+	microdata = scopedSyntax{typeAttr: "itemtype", propAttr: "itemprop"}
+	rdfa      = scopedSyntax{typeAttr: "typeof", propAttr: "property"}
+)
+
+// extractScoped returns the first article item marked up with the given syntax.
+func extractScoped(doc *goquery.Selection, syntax scopedSyntax) *Data {
+	var item *goquery.Selection
+	var itemType string
+	doc.Find("[" + syntax.typeAttr + "]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		for _, t := range syntax.types(s) {
+			if articleTypes[t] {
+				item, itemType = s, t
+				return false
+			}
+		}
+		return true
+	})
+	if item == nil {
+		return nil
+	}
+
+	data := &Data{
+		Type:          itemType,
+		Headline:      firstNonEmpty(syntax.value(item, "headline"), syntax.value(item, "name")),
+		Description:   syntax.value(item, "description"),
+		Body:          syntax.value(item, "articleBody"),
+		DatePublished: syntax.value(item, "datePublished"),
+		DateModified:  syntax.value(item, "dateModified"),
+		Section:       syntax.value(item, "articleSection"),
+		URL:           syntax.value(item, "url"),
+		Publisher:     syntax.nestedName(item, "publisher"),
+	}
+
+	for _, author := range syntax.properties(item, "author") {
+		data.Authors = appendUnique(data.Authors, syntax.nameOf(author))
+	}
+	for _, keywords := range syntax.properties(item, "keywords") {
+		data.Keywords = appendUnique(data.Keywords, splitKeywords(syntax.valueOf(keywords))...)
+	}
+	for _, image := range syntax.properties(item, "image") {
+		url := syntax.valueOf(image)
+		if syntax.isItem(image) {
+			url = firstNonEmpty(syntax.value(image, "url"), syntax.value(image, "contentUrl"), url)
+		}
+		data.Images = appendUnique(data.Images, url)
+	}
+	if free := syntax.value(item, "isAccessibleForFree"); free != "" {
+		data.IsAccessibleForFree = parseBool(free)
+	}
+	if count := syntax.value(item, "wordCount"); count != "" {
+		data.WordCount, _ = strconv.Atoi(count)
+	}
+
+	return data
+}
+
+// types returns the schema.org type names of an item.
+func (s scopedSyntax) types(item *goquery.Selection) []string {
+	raw, _ := item.Attr(s.typeAttr)
+	fields := strings.Fields(raw)
+	types := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSuffix(field, "/")
+		if i := strings.LastIndexAny(field, "/:#"); i >= 0 {
+			field = field[i+1:]
+		}
+		types = append(types, field)
+	}
+	return types
+}
+
+// isItem reports whether an element starts a nested item.
+func (s scopedSyntax) isItem(el *goquery.Selection) bool {
+	_, ok := el.Attr(s.typeAttr)
+	return ok
+}
+
+// properties returns the elements carrying a property of an item, skipping
+// properties that belong to nested items.
+func (s scopedSyntax) properties(item *goquery.Selection, name string) []*goquery.Selection {
+	itemNode := item.Get(0)
+	var found []*goquery.Selection
+	item.Find("[" + s.propAttr + "]").Each(func(_ int, el *goquery.Selection) {
+		if !s.hasProperty(el, name) {
+			return
+		}
+		owner := el.ParentsFiltered("[" + s.typeAttr + "]").First()
+		if owner.Length() == 0 || owner.Get(0) != itemNode {
+			return
+		}
+		found = append(found, el)
+	})
+	return found
+}
+
+// hasProperty reports whether an element declares the named property, with or
+// without a vocabulary prefix such as "schema:".
+func (s scopedSyntax) hasProperty(el *goquery.Selection, name string) bool {
+	raw, _ := el.Attr(s.propAttr)
+	for _, field := range strings.Fields(raw) {
+		if i := strings.LastIndexAny(field, "/:#"); i >= 0 {
+			field = field[i+1:]
+		}
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// value returns the value of the first matching property of an item.
+func (s scopedSyntax) value(item *goquery.Selection, name string) string {
+	for _, el := range s.properties(item, name) {
+		if v := s.valueOf(el); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// nestedName returns the name of the first matching property, which may be a nested item.
+func (s scopedSyntax) nestedName(item *goquery.Selection, name string) string {
+	for _, el := range s.properties(item, name) {
+		if v := s.nameOf(el); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// nameOf returns the name of a nested item, or the element's value for plain properties.
+func (s scopedSyntax) nameOf(el *goquery.Selection) string {
+	if s.isItem(el) {
+		if name := s.value(el, "name"); name != "" {
+			return name
+		}
+	}
+	return s.valueOf(el)
+}
+
+// valueOf returns an element's property value following the microdata and RDFa
+// rules: content first, then element-specific attributes, then the text.
+func (s scopedSyntax) valueOf(el *goquery.Selection) string {
+	if content, ok := el.Attr("content"); ok {
+		return strings.TrimSpace(content)
+	}
+
+	var attr string
+	switch goquery.NodeName(el) {
+	case "time":
+		attr = "datetime"
+	case "a", "link", "area":
+		attr = "href"
+	case "img", "audio", "video", "source", "embed", "iframe":
+		attr = "src"
+	case "meta":
+		return ""
+	}
+	if attr != "" {
+		if v, ok := el.Attr(attr); ok && strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	// RDFa also allows the value in resource or href on any element
+	if v, ok := el.Attr("resource"); ok && s.propAttr == rdfa.propAttr {
+		return strings.TrimSpace(v)
+	}
+
+	return strings.Join(strings.Fields(el.Text()), " ")
+}
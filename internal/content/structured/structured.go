@@ -0,0 +1,187 @@
+// Package structured extracts schema.org article metadata embedded in pages
+// as JSON-LD, microdata or RDFa. Formats are merged in that order: the first
+// format that provides a field wins.
+package structured
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Formats identify where structured data was found.
+const (
+	FormatJSONLD    = "json-ld"
+	FormatMicrodata = "microdata"
+	FormatRDFa      = "rdfa"
+)
+
+// DefaultJSONLDSelector matches JSON-LD script blocks.
+const DefaultJSONLDSelector = "script[type='application/ld+json']"
+
+// articleTypes are the schema.org types treated as articles.
+var articleTypes = map[string]bool{
+	"Article":                  true,
+	"NewsArticle":              true,
+	"AnalysisNewsArticle":      true,
+	"BackgroundNewsArticle":    true,
+	"OpinionNewsArticle":       true,
+	"ReportageNewsArticle":     true,
+	"ReviewNewsArticle":        true,
+	"BlogPosting":              true,
+	"LiveBlogPosting":          true,
+	"ScholarlyArticle":         true,
+	"TechArticle":              true,
+	"Report":                   true,
+	"SatiricalArticle":         true,
+	"AdvertiserContentArticle": true,
+}
+
+// Data holds the article metadata found in structured data. Dates are kept as
+// raw strings so callers can parse them with their own date handling.
+type Data struct {
+	// Formats lists the formats that contributed, in precedence order
+	Formats []string
+	// Type is the schema.org type of the article, e.g. NewsArticle
+	Type string
+	// Headline is the article headline
+	Headline string
+	// Description is the article summary
+	Description string
+	// Body is the full article text (articleBody)
+	Body string
+	// Authors are the names of the article's authors
+	Authors []string
+	// DatePublished is the raw publication date
+	DatePublished string
+	// DateModified is the raw modification date
+	DateModified string
+	// Section is the article section
+	Section string
+	// Keywords are the article keywords
+	Keywords []string
+	// Images are the URLs of the article images
+	Images []string
+	// Publisher is the name of the publishing organization
+	Publisher string
+	// URL is the URL the article declares for itself
+	URL string
+	// IsAccessibleForFree is nil when the page does not say
+	IsAccessibleForFree *bool
+	// WordCount is the declared word count (0 when absent)
+	WordCount int
+}
+
+// Empty reports whether no structured data was found.
+func (d *Data) Empty() bool {
+	return d == nil || len(d.Formats) == 0
+}
+
+// Extract reads article structured data from a document. An empty jsonLDSelector
+// uses DefaultJSONLDSelector. It returns an empty Data when the page has none.
+func Extract(doc *goquery.Selection, jsonLDSelector string) *Data {
+	data := &Data{}
+	if doc == nil {
+		return data
+	}
+	if jsonLDSelector == "" {
+		jsonLDSelector = DefaultJSONLDSelector
+	}
+
+	if found := extractJSONLD(doc, jsonLDSelector); found != nil {
+		data.merge(found, FormatJSONLD)
+	}
+	if found := extractScoped(doc, microdata); found != nil {
+		data.merge(found, FormatMicrodata)
+	}
+	if found := extractScoped(doc, rdfa); found != nil {
+		data.merge(found, FormatRDFa)
+	}
+
+	return data
+}
+
+// merge fills the fields of d that are still empty from other.
+func (d *Data) merge(other *Data, format string) {
+	d.Formats = append(d.Formats, format)
+
+	d.Type = firstNonEmpty(d.Type, other.Type)
+	d.Headline = firstNonEmpty(d.Headline, other.Headline)
+	d.Description = firstNonEmpty(d.Description, other.Description)
+	d.Body = firstNonEmpty(d.Body, other.Body)
+	d.DatePublished = firstNonEmpty(d.DatePublished, other.DatePublished)
+	d.DateModified = firstNonEmpty(d.DateModified, other.DateModified)
+	d.Section = firstNonEmpty(d.Section, other.Section)
+	d.Publisher = firstNonEmpty(d.Publisher, other.Publisher)
+	d.URL = firstNonEmpty(d.URL, other.URL)
+
+	if len(d.Authors) == 0 {
+		d.Authors = other.Authors
+	}
+	if len(d.Keywords) == 0 {
+		d.Keywords = other.Keywords
+	}
+	if len(d.Images) == 0 {
+		d.Images = other.Images
+	}
+	if d.IsAccessibleForFree == nil {
+		d.IsAccessibleForFree = other.IsAccessibleForFree
+	}
+	if d.WordCount == 0 {
+		d.WordCount = other.WordCount
+	}
+}
+
+// firstNonEmpty returns the first non-blank value.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// splitKeywords splits a comma-separated keyword string.
+func splitKeywords(s string) []string {
+	parts := strings.Split(s, ",")
+	keywords := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			keywords = append(keywords, part)
+		}
+	}
+	return keywords
+}
+
+// appendUnique appends values that are not blank and not already present.
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// parseBool parses schema.org boolean values, which appear as JSON booleans or
+// as "True"/"False" strings, optionally as schema.org URLs.
+func parseBool(s string) *bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "https://schema.org/"), "http://schema.org/")
+	var value bool
+	switch s {
+	case "true", "yes", "1":
+		value = true
+	case "false", "no", "0":
+		value = false
+	default:
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,121 @@
+package structured_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/jonesrussell/gocrawl/internal/content/structured"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, markup string) *goquery.Selection {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(markup))
+	require.NoError(t, err)
+	return doc.Selection
+}
+
+func TestExtractJSONLDGraph(t *testing.T) {
+	t.Parallel()
+
+	doc := parse(t, `<html><head><script type="application/ld+json">
+{
+  "@context": "https://schema.org",
+  "@graph": [
+    {"@type": "WebPage", "@id": "https://example.com/news/budget#webpage"},
+    {"@type": "Person", "@id": "https://example.com/#/person/1", "name": "Jane Reporter"},
+    {"@type": "Organization", "@id": "https://example.com/#org", "name": "Example News"},
+    {
+      "@type": ["NewsArticle"],
+      "headline": "Council approves budget",
+      "author": [{"@id": "https://example.com/#/person/1"}, {"@type": "Person", "name": "John Editor"}],
+      "publisher": {"@id": "https://example.com/#org"},
+      "datePublished": "2026-10-14T09:00:00-04:00",
+      "dateModified": "2026-10-14T12:30:00-04:00",
+      "articleSection": ["Local", "Politics"],
+      "keywords": "budget, council,taxes",
+      "image": [{"@type": "ImageObject", "url": "https://example.com/img/council.jpg"}, "https://example.com/img/2.jpg"],
+      "isAccessibleForFree": "False",
+      "wordCount": 742,
+      "mainEntityOfPage": {"@id": "https://example.com/news/budget#webpage"}
+    }
+  ]
+}
+</script></head><body></body></html>`)
+
+	data := structured.Extract(doc, "")
+	require.False(t, data.Empty())
+
+	assert.Equal(t, []string{structured.FormatJSONLD}, data.Formats)
+	assert.Equal(t, "NewsArticle", data.Type)
+	assert.Equal(t, "Council approves budget", data.Headline)
+	assert.Equal(t, []string{"Jane Reporter", "John Editor"}, data.Authors)
+	assert.Equal(t, "Example News", data.Publisher)
+	assert.Equal(t, "2026-10-14T09:00:00-04:00", data.DatePublished)
+	assert.Equal(t, "2026-10-14T12:30:00-04:00", data.DateModified)
+	assert.Equal(t, "Local", data.Section)
+	assert.Equal(t, []string{"budget", "council", "taxes"}, data.Keywords)
+	assert.Equal(t, []string{"https://example.com/img/council.jpg", "https://example.com/img/2.jpg"}, data.Images)
+	require.NotNil(t, data.IsAccessibleForFree)
+	assert.False(t, *data.IsAccessibleForFree)
+	assert.Equal(t, 742, data.WordCount)
+	assert.Equal(t, "https://example.com/news/budget#webpage", data.URL)
+}
+
+func TestExtractMicrodata(t *testing.T) {
+	t.Parallel()
+
+	doc := parse(t, `<html><body>
+<article itemscope itemtype="https://schema.org/NewsArticle">
+  <h1 itemprop="headline">Bridge reopens</h1>
+  <span itemprop="author" itemscope itemtype="https://schema.org/Person"><span itemprop="name">Sam Writer</span></span>
+  <div itemprop="publisher" itemscope itemtype="https://schema.org/Organization">
+    <meta itemprop="name" content="Daily Example">
+  </div>
+  <time itemprop="datePublished" datetime="2026-10-01">October 1</time>
+  <meta itemprop="keywords" content="bridge, transit">
+  <img itemprop="image" src="https://example.com/bridge.jpg" alt="">
+  <div itemprop="articleBody">The bridge reopened on Monday.</div>
+</article>
+</body></html>`)
+
+	data := structured.Extract(doc, "")
+	assert.Equal(t, []string{structured.FormatMicrodata}, data.Formats)
+	assert.Equal(t, "Bridge reopens", data.Headline)
+	assert.Equal(t, []string{"Sam Writer"}, data.Authors)
+	assert.Equal(t, "Daily Example", data.Publisher)
+	assert.Equal(t, "2026-10-01", data.DatePublished)
+	assert.Equal(t, []string{"bridge", "transit"}, data.Keywords)
+	assert.Equal(t, []string{"https://example.com/bridge.jpg"}, data.Images)
+	assert.Equal(t, "The bridge reopened on Monday.", data.Body)
+}
+
+func TestExtractMergesFormats(t *testing.T) {
+	t.Parallel()
+
+	doc := parse(t, `<html><head>
+<script type="application/ld+json">{"@type": "Article", "headline": "From JSON-LD"}</script>
+</head><body>
+<div vocab="https://schema.org/" typeof="BlogPosting">
+  <h1 property="headline">From RDFa</h1>
+  <span property="author" typeof="Person"><span property="name">Rita Blogger</span></span>
+  <span property="schema:dateModified" content="2026-09-30T10:00:00Z">yesterday</span>
+</div>
+</body></html>`)
+
+	data := structured.Extract(doc, "")
+	assert.Equal(t, []string{structured.FormatJSONLD, structured.FormatRDFa}, data.Formats)
+	assert.Equal(t, "From JSON-LD", data.Headline, "earlier formats win")
+	assert.Equal(t, []string{"Rita Blogger"}, data.Authors, "later formats fill gaps")
+	assert.Equal(t, "2026-09-30T10:00:00Z", data.DateModified)
+}
+
+func TestExtractWithoutStructuredData(t *testing.T) {
+	t.Parallel()
+
+	data := structured.Extract(parse(t, `<html><body><p>Plain page</p></body></html>`), "")
+	assert.True(t, data.Empty())
+	assert.True(t, structured.Extract(nil, "").Empty())
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/jonesrussell/gocrawl/internal/content/page"
 	"github.com/jonesrussell/gocrawl/internal/crawler/events"
 	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/jonesrussell/gocrawl/internal/linkgraph"
 	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/runhistory"
 	"github.com/jonesrussell/gocrawl/internal/sources"
 	"github.com/jonesrussell/gocrawl/internal/storage/types"
 )
@@ -47,6 +49,10 @@ type CrawlerParams struct {
 	ArticleService articles.Interface
 	PageService    page.Interface
 	Storage        types.Interface
+	// LinkRecorder records the link graph; nil disables link capture
+	LinkRecorder *linkgraph.Recorder
+	// RunRecorder records the history of crawl runs; nil disables it
+	RunRecorder *runhistory.Recorder
 }
 
 // CrawlerResult holds the crawler instance and its channels
@@ -188,6 +194,9 @@ func NewCrawlerWithParams(p CrawlerParams) (*CrawlerResult, error) {
 		htmlProcessor:    NewHTMLProcessor(p.Logger, p.Sources),
 		cfg:              p.Config,
 		abortChan:        make(chan struct{}),
+		linkRecorder:     p.LinkRecorder,
+		runRecorder:      p.RunRecorder,
+		groupLimiter:     NewGroupLimiter(p.Config.Groups),
 	}
 
 	c.linkHandler = NewLinkHandler(c)
@@ -8,6 +8,7 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -22,8 +23,10 @@ import (
 	"github.com/jonesrussell/gocrawl/internal/content/contenttype"
 	"github.com/jonesrussell/gocrawl/internal/crawler/events"
 	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/jonesrussell/gocrawl/internal/linkgraph"
 	"github.com/jonesrussell/gocrawl/internal/logger"
 	"github.com/jonesrussell/gocrawl/internal/metrics"
+	"github.com/jonesrussell/gocrawl/internal/runhistory"
 	"github.com/jonesrussell/gocrawl/internal/sources"
 	sourcestypes "github.com/jonesrussell/gocrawl/internal/sources/types"
 	storagetypes "github.com/jonesrussell/gocrawl/internal/storage/types"
@@ -167,23 +170,48 @@ type Crawler struct {
 	articleChannel   chan *domain.Article
 	processors       []content.Processor
 	linkHandler      *LinkHandler
+	linkRecorder     *linkgraph.Recorder  // nil unless link graph capture is enabled
+	runRecorder      *runhistory.Recorder // nil unless run history is enabled
+	groupLimiter     *GroupLimiter        // request budgets shared by the sources of each group
+	sourceGroups     []string             // groups of the source being crawled
 	htmlProcessor    *HTMLProcessor
 	cfg              *crawler.Config
-	abortChan        chan struct{} // Channel to signal abort
-	maxDepthOverride int32         // Override for source's max_depth (0 means use source default), accessed atomically
+	sourceCfg        *crawler.Config // cfg with the overrides of the source being crawled
+	abortChan        chan struct{}   // Channel to signal abort
+	maxDepthOverride int32           // Override for source's max_depth (0 means use source default), accessed atomically
 }
 
 var _ Interface = (*Crawler)(nil)
 var _ CrawlerInterface = (*Crawler)(nil)
 var _ CrawlerMetrics = (*Crawler)(nil)
 
+// sourceConfig returns the crawler settings of the source being crawled,
+// or the global settings before a collector was set up.
+func (c *Crawler) sourceConfig() *crawler.Config {
+	if c.sourceCfg != nil {
+		return c.sourceCfg
+	}
+	return c.cfg
+}
+
 // Core Crawler Methods
 // -------------------
 
 // setupCollector configures the collector with the given source settings
 func (c *Crawler) setupCollector(source *configtypes.Source) error {
+	cfg, err := c.cfg.WithOverrides(source.Crawler)
+	if err != nil {
+		return fmt.Errorf("source %s: %w", source.Name, err)
+	}
+	c.sourceCfg = cfg
+	c.sourceGroups = source.Groups()
+	overridden := crawler.OverrideKeys(source.Crawler)
+
 	// Use override if set, otherwise use source's max depth
 	maxDepth := source.MaxDepth
+	if slices.Contains(overridden, "max_depth") {
+		maxDepth = cfg.MaxDepth
+	}
 	override := int(atomic.LoadInt32(&c.maxDepthOverride))
 	if override > 0 {
 		maxDepth = override
@@ -199,9 +227,13 @@ func (c *Crawler) setupCollector(source *configtypes.Source) error {
 		colly.Async(true),
 		colly.ParseHTTPErrorResponse(),
 		colly.IgnoreRobotsTxt(),
-		colly.UserAgent(c.cfg.UserAgent),
+		colly.UserAgent(cfg.UserAgent),
 		colly.AllowURLRevisit(),
 	}
+	// Colly's own body size limit applies unless the source overrides it
+	if slices.Contains(overridden, "max_body_size") {
+		opts = append(opts, colly.MaxBodySize(cfg.MaxBodySize))
+	}
 
 	// Only set allowed domains if they are configured
 	if len(source.AllowedDomains) > 0 {
@@ -209,6 +241,9 @@ func (c *Crawler) setupCollector(source *configtypes.Source) error {
 	}
 
 	c.collector = colly.NewCollector(opts...)
+	if slices.Contains(overridden, "request_timeout") {
+		c.collector.SetRequestTimeout(cfg.RequestTimeout)
+	}
 
 	// Parse and set rate limit
 	rateLimit, err := time.ParseDuration(source.RateLimit)
@@ -219,19 +254,30 @@ func (c *Crawler) setupCollector(source *configtypes.Source) error {
 			"error", err)
 		rateLimit = constants.DefaultRateLimit
 	}
+	if slices.Contains(overridden, "delay") {
+		rateLimit = cfg.Delay
+	}
+	randomDelay := rateLimit / RandomDelayDivisor
+	if slices.Contains(overridden, "random_delay") {
+		randomDelay = cfg.RandomDelay
+	}
+	parallelism := constants.DefaultParallelism
+	if slices.Contains(overridden, "max_concurrency") {
+		parallelism = cfg.MaxConcurrency
+	}
 
 	err = c.collector.Limit(&colly.LimitRule{
 		DomainGlob:  "*",
 		Delay:       rateLimit,
-		RandomDelay: rateLimit / RandomDelayDivisor,
-		Parallelism: constants.DefaultParallelism,
+		RandomDelay: randomDelay,
+		Parallelism: parallelism,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to set rate limit: %w", err)
 	}
 
 	// Configure transport with more reasonable settings
-	tlsConfig, err := transport.NewTLSConfig(c.cfg)
+	tlsConfig, err := transport.NewTLSConfig(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create TLS configuration: %w", err)
 	}
@@ -246,7 +292,7 @@ func (c *Crawler) setupCollector(source *configtypes.Source) error {
 		ExpectContinueTimeout: constants.DefaultExpectContinueTimeout,
 	})
 
-	if c.cfg.TLS.InsecureSkipVerify {
+	if cfg.TLS.InsecureSkipVerify {
 		c.logger.Warn("TLS certificate verification is disabled. This is not recommended for production use.",
 			"component", "crawler",
 			"source", source.Name,
@@ -257,7 +303,8 @@ func (c *Crawler) setupCollector(source *configtypes.Source) error {
 		"max_depth", maxDepth,
 		"allowed_domains", source.AllowedDomains,
 		"rate_limit", rateLimit,
-		"parallelism", constants.DefaultParallelism)
+		"parallelism", parallelism,
+		"overrides", overridden)
 
 	return nil
 }
@@ -282,9 +329,19 @@ func (c *Crawler) setupCallbacks(ctx context.Context) {
 			r.Abort()
 			return
 		default:
-			c.logger.Debug("Visiting URL",
-				"url", r.URL.String())
 		}
+
+		// Take from the request budgets of the source's groups
+		if err := c.groupLimiter.Wait(ctx, c.sourceGroups); err != nil {
+			c.logger.Debug("Aborting request waiting for group rate limit",
+				"url", r.URL.String(),
+				"error", err)
+			r.Abort()
+			return
+		}
+
+		c.logger.Debug("Visiting URL",
+			"url", r.URL.String())
 	})
 
 	// Set up HTML processing
@@ -344,7 +401,7 @@ func (c *Crawler) setupCallbacks(ctx context.Context) {
 
 	// Set up link following
 	c.collector.OnHTML("a[href]", func(e *colly.HTMLElement) {
-		c.linkHandler.HandleLink(e)
+		c.linkHandler.HandleLink(ctx, e)
 	})
 
 	// Set up scraped callback to handle abort
@@ -411,12 +468,24 @@ func (c *Crawler) Start(ctx context.Context, sourceName string) error {
 		return fmt.Errorf("failed to setup collector: %w", err)
 	}
 
+	// Reset link deduplication for this source
+	if resetErr := c.linkHandler.Reset(source); resetErr != nil {
+		return fmt.Errorf("failed to reset link handler: %w", resetErr)
+	}
+
+	// Save the links recorded during the crawl, even if it is cancelled
+	defer c.flushLinks(ctx)
+
 	// Set up callbacks
 	c.setupCallbacks(ctx)
 
 	// Start the crawler state
 	c.state.Start(ctx, sourceName)
 
+	// Record the run in the source's history once it is over
+	c.runRecorder.Begin(source.Name, time.Now())
+	defer c.finishRun(ctx)
+
 	// Visit the source URL
 	if visitErr := c.collector.Visit(source.URL); visitErr != nil {
 		return fmt.Errorf("failed to visit source URL: %w", visitErr)
@@ -481,6 +550,21 @@ func (c *Crawler) Start(ctx context.Context, sourceName string) error {
 	return nil
 }
 
+// finishRun saves the current run to the run history.
+func (c *Crawler) finishRun(ctx context.Context) {
+	cancelled := ctx.Err() != nil
+	if _, err := c.runRecorder.Finish(context.WithoutCancel(ctx), time.Now(), cancelled); err != nil {
+		c.logger.Warn("Failed to record crawl run", "error", err)
+	}
+}
+
+// flushLinks indexes the links still buffered by the link recorder.
+func (c *Crawler) flushLinks(ctx context.Context) {
+	if err := c.linkRecorder.Flush(context.WithoutCancel(ctx)); err != nil {
+		c.logger.Warn("Failed to record links", "error", err)
+	}
+}
+
 // cleanupResources performs periodic cleanup of crawler resources
 func (c *Crawler) cleanupResources() {
 	c.logger.Debug("Cleaning up crawler resources")
@@ -604,11 +688,13 @@ func (c *Crawler) GetMetrics() *metrics.Metrics {
 // IncrementProcessed increments the processed count.
 func (c *Crawler) IncrementProcessed() {
 	c.state.IncrementProcessed()
+	c.runRecorder.PageVisited()
 }
 
 // IncrementError increments the error count.
 func (c *Crawler) IncrementError() {
 	c.state.IncrementError()
+	c.runRecorder.Error()
 }
 
 // GetProcessedCount returns the number of processed items.
@@ -919,7 +1005,7 @@ func (c *Crawler) ProcessHTML(e *colly.HTMLElement) {
 		c.logger.Debug("No processor found for content",
 			"url", e.Request.URL.String(),
 			"type", contentType)
-		c.state.IncrementProcessed()
+		c.IncrementProcessed()
 		return
 	}
 
@@ -938,7 +1024,7 @@ func (c *Crawler) ProcessHTML(e *colly.HTMLElement) {
 				"error", err,
 				"url", e.Request.URL.String(),
 				"type", contentType)
-			c.state.IncrementError()
+			c.IncrementError()
 		}
 	} else {
 		contentType := c.htmlProcessor.DetectContentType(e, source)
@@ -947,7 +1033,7 @@ func (c *Crawler) ProcessHTML(e *colly.HTMLElement) {
 			"type", contentType)
 	}
 
-	c.state.IncrementProcessed()
+	c.IncrementProcessed()
 }
 
 // GetProcessor returns a processor for the given content type.
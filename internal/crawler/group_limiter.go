@@ -0,0 +1,44 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"golang.org/x/time/rate"
+)
+
+// GroupLimiter shares a request budget between the sources of each group. Every
+// group with a rate_limit has one token bucket that all of its sources take from.
+type GroupLimiter struct {
+	limiters map[string]*rate.Limiter
+}
+
+// NewGroupLimiter creates the token buckets of the groups that have a rate limit.
+func NewGroupLimiter(groups map[string]crawlerconfig.GroupConfig) *GroupLimiter {
+	limiters := make(map[string]*rate.Limiter)
+	for name, group := range groups {
+		if group.RateLimit > 0 {
+			limiters[name] = rate.NewLimiter(rate.Every(group.RateLimit), 1)
+		}
+	}
+	return &GroupLimiter{limiters: limiters}
+}
+
+// Wait blocks until each of the given groups allows another request. Groups
+// without a rate limit do not hold requests back.
+func (l *GroupLimiter) Wait(ctx context.Context, groups []string) error {
+	if l == nil {
+		return nil
+	}
+	for _, group := range groups {
+		limiter, ok := l.limiters[group]
+		if !ok {
+			continue
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("failed to wait for the rate limit of group %s: %w", group, err)
+		}
+	}
+	return nil
+}
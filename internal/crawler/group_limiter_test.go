@@ -0,0 +1,58 @@
+package crawler_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/crawler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupLimiterSharesBudgetBetweenSources(t *testing.T) {
+	t.Parallel()
+
+	const interval = 20 * time.Millisecond
+	limiter := crawler.NewGroupLimiter(map[string]crawlerconfig.GroupConfig{
+		"ontario-news": {RateLimit: interval},
+		"northern":     {Time: []string{"06:00"}},
+	})
+
+	// Two sources of the group request at the same time and take from one bucket
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 3 {
+				assert.NoError(t, limiter.Wait(context.Background(), []string{"ontario-news", "northern"}))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.GreaterOrEqual(t, time.Since(start), 5*interval-interval/2)
+
+	// Groups without a rate limit do not hold requests back
+	start = time.Now()
+	for range 10 {
+		require.NoError(t, limiter.Wait(context.Background(), []string{"northern", "prairies"}))
+	}
+	assert.Less(t, time.Since(start), interval)
+}
+
+func TestGroupLimiterStopsWaitingWhenCancelled(t *testing.T) {
+	t.Parallel()
+
+	limiter := crawler.NewGroupLimiter(map[string]crawlerconfig.GroupConfig{
+		"ontario-news": {RateLimit: time.Hour},
+	})
+	require.NoError(t, limiter.Wait(context.Background(), []string{"ontario-news"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, limiter.Wait(ctx, []string{"ontario-news"}))
+}
@@ -2,17 +2,31 @@
 package crawler
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/jonesrussell/gocrawl/internal/common/urlnorm"
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/linkgraph"
 )
 
 // LinkHandler handles link processing for the crawler.
 type LinkHandler struct {
 	crawler *Crawler
+	// canonicalizer normalizes links of the source being crawled
+	canonicalizer *urlnorm.Canonicalizer
+	// rules decide which links of the source being crawled are followed
+	rules *configtypes.RuleSet
+	// seen holds the key of every link queued during the current crawl
+	seen sync.Map
+	// source is the source being crawled
+	source *configtypes.Source
 }
 
 // NewLinkHandler creates a new link handler.
@@ -22,8 +36,31 @@ func NewLinkHandler(c *Crawler) *LinkHandler {
 	}
 }
 
+// Reset prepares the handler for a new crawl of the given source. Links that
+// only differ from an earlier link by tracking parameters, fragments or other
+// canonical-equivalent noise are skipped for the rest of the crawl.
+func (h *LinkHandler) Reset(source *configtypes.Source) error {
+	canonicalizer, err := urlnorm.New(source.URLRewrites)
+	if err != nil {
+		return fmt.Errorf("failed to create URL canonicalizer: %w", err)
+	}
+	rules, err := source.Rules.Compile()
+	if err != nil {
+		return fmt.Errorf("failed to compile crawling rules: %w", err)
+	}
+
+	h.canonicalizer = canonicalizer
+	h.rules = rules
+	h.source = source
+	h.seen.Clear()
+	if key, canonErr := canonicalizer.Key(source.URL); canonErr == nil {
+		h.seen.Store(key, struct{}{})
+	}
+	return nil
+}
+
 // HandleLink processes a single link from an HTML element.
-func (h *LinkHandler) HandleLink(e *colly.HTMLElement) {
+func (h *LinkHandler) HandleLink(ctx context.Context, e *colly.HTMLElement) {
 	link := e.Attr("href")
 	if link == "" {
 		return
@@ -48,7 +85,7 @@ func (h *LinkHandler) HandleLink(e *colly.HTMLElement) {
 	}
 
 	// Validate URL if configured
-	if h.crawler.cfg.ValidateURLs {
+	if h.crawler.sourceConfig().ValidateURLs {
 		if _, err := url.Parse(absLink); err != nil {
 			h.crawler.logger.Debug("Invalid URL",
 				"url", absLink,
@@ -57,9 +94,31 @@ func (h *LinkHandler) HandleLink(e *colly.HTMLElement) {
 		}
 	}
 
+	key, canonErr := h.canonicalizer.Key(absLink)
+
+	// Every occurrence of a link is part of the link graph, even if it is not followed
+	h.recordLink(ctx, e, absLink, key)
+
+	// Skip links the source's rules disallow
+	if parsed, err := url.Parse(absLink); err == nil && !h.rules.Allows(parsed.Path) {
+		h.crawler.logger.Debug("Skipping link disallowed by source rules",
+			"url", absLink)
+		return
+	}
+
+	// Skip links whose canonical form was already queued
+	if canonErr == nil {
+		if _, loaded := h.seen.LoadOrStore(key, struct{}{}); loaded {
+			h.crawler.logger.Debug("Skipping link with already visited canonical URL",
+				"url", absLink,
+				"canonical_url", key)
+			return
+		}
+	}
+
 	// Try to visit the URL with retries
 	var lastErr error
-	for i := range h.crawler.cfg.MaxRetries {
+	for i := range h.crawler.sourceConfig().MaxRetries {
 		err := e.Request.Visit(absLink)
 		if err == nil {
 			h.crawler.logger.Debug("Successfully visited link",
@@ -70,18 +129,23 @@ func (h *LinkHandler) HandleLink(e *colly.HTMLElement) {
 		// Check if error is non-retryable by checking both error type and message
 		// Colly may return errors with different message formats
 		errMsg := err.Error()
-		isNonRetryable := errors.Is(err, ErrAlreadyVisited) ||
-			errors.Is(err, ErrMaxDepth) ||
+		isMaxDepth := errors.Is(err, ErrMaxDepth) ||
+			strings.Contains(errMsg, "max depth") ||
+			strings.Contains(errMsg, "Max depth")
+		isNonRetryable := isMaxDepth ||
+			errors.Is(err, ErrAlreadyVisited) ||
 			errors.Is(err, ErrMissingURL) ||
 			errors.Is(err, ErrForbiddenDomain) ||
 			strings.Contains(errMsg, "forbidden domain") ||
 			strings.Contains(errMsg, "Forbidden domain") ||
-			strings.Contains(errMsg, "max depth") ||
-			strings.Contains(errMsg, "Max depth") ||
 			strings.Contains(errMsg, "already visited") ||
 			strings.Contains(errMsg, "Already visited")
 
 		if isNonRetryable {
+			// A link too deep here may still be reached from a shallower page later on
+			if isMaxDepth && canonErr == nil {
+				h.seen.Delete(key)
+			}
 			// These are expected conditions, log at debug level
 			h.crawler.logger.Debug("Skipping non-retryable link",
 				"url", absLink,
@@ -94,15 +158,74 @@ func (h *LinkHandler) HandleLink(e *colly.HTMLElement) {
 			"url", absLink,
 			"error", err,
 			"attempt", i+1,
-			"max_retries", h.crawler.cfg.MaxRetries)
+			"max_retries", h.crawler.sourceConfig().MaxRetries)
 
 		// Wait before retrying
-		time.Sleep(h.crawler.cfg.RetryDelay)
+		time.Sleep(h.crawler.sourceConfig().RetryDelay)
 	}
 
-	// If we get here, all retries failed
+	// If we get here, all retries failed; forget the link so a later occurrence can retry it
+	if canonErr == nil {
+		h.seen.Delete(key)
+	}
 	h.crawler.logger.Error("Failed to visit link after retries",
 		"url", absLink,
 		"error", lastErr,
-		"max_retries", h.crawler.cfg.MaxRetries)
+		"max_retries", h.crawler.sourceConfig().MaxRetries)
+}
+
+// recordLink adds a link to the link graph when link capture is enabled.
+func (h *LinkHandler) recordLink(ctx context.Context, e *colly.HTMLElement, absLink, canonicalLink string) {
+	if h.crawler.linkRecorder == nil || h.source == nil {
+		return
+	}
+
+	from, err := h.canonicalizer.Key(e.Request.URL.String())
+	if err != nil {
+		return
+	}
+	if canonicalLink == "" {
+		canonicalLink = absLink
+	}
+	target, err := url.Parse(canonicalLink)
+	if err != nil {
+		return
+	}
+
+	h.crawler.linkRecorder.Record(ctx, linkgraph.Link{
+		Source:     h.source.Name,
+		FromURL:    from,
+		ToURL:      canonicalLink,
+		ToHost:     target.Hostname(),
+		AnchorText: strings.Join(strings.Fields(e.Text), " "),
+		NoFollow:   isNoFollow(e.Attr("rel")),
+		Internal:   h.isInternal(canonicalLink, target.Hostname()),
+		Depth:      e.Request.Depth,
+		LastSeen:   time.Now(),
+	})
+}
+
+// isInternal reports whether a link points to the source's site or one of its allowed domains.
+func (h *LinkHandler) isInternal(link, host string) bool {
+	if urlnorm.SameSite(link, h.source.URL) {
+		return true
+	}
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	for _, domain := range h.source.AllowedDomains {
+		domain = strings.TrimPrefix(strings.ToLower(domain), "www.")
+		if host == domain || strings.HasSuffix(host, "."+strings.TrimPrefix(domain, "*.")) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNoFollow reports whether a rel attribute asks crawlers not to follow the link.
+func isNoFollow(rel string) bool {
+	for _, value := range strings.Fields(strings.ToLower(rel)) {
+		if value == "nofollow" || value == "ugc" || value == "sponsored" {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,59 @@
+package crawler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gocolly/colly/v2"
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/crawler"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkHandlerFollowsLinkFirstSeenAtMaxDepth(t *testing.T) {
+	t.Parallel()
+
+	var deepVisits atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `<html><body><a href="/section">Section</a><a href="/story">Story</a></body></html>`)
+	})
+	mux.HandleFunc("/section", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `<html><body><a href="/story">Story</a></body></html>`)
+	})
+	mux.HandleFunc("/story", func(w http.ResponseWriter, _ *http.Request) {
+		deepVisits.Add(1)
+		fmt.Fprint(w, `<html><body>Story</body></html>`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cfg := crawlerconfig.New()
+	result, err := crawler.NewCrawlerWithParams(crawler.CrawlerParams{
+		Logger: logger.NewNoOp(),
+		Config: cfg,
+	})
+	require.NoError(t, err)
+	c, ok := result.Crawler.(*crawler.Crawler)
+	require.True(t, ok)
+
+	handler := crawler.NewLinkHandler(c)
+	require.NoError(t, handler.Reset(&types.Source{Name: "test", URL: server.URL + "/"}))
+
+	// The synchronous collector reaches /story from /section, one level too deep,
+	// before it reaches it again straight from the start page.
+	collector := colly.NewCollector(colly.MaxDepth(2), colly.AllowURLRevisit())
+	collector.OnHTML("a[href]", func(e *colly.HTMLElement) {
+		handler.HandleLink(context.Background(), e)
+	})
+	require.NoError(t, collector.Visit(server.URL+"/"))
+
+	assert.Equal(t, int32(1), deepVisits.Load())
+}
@@ -20,8 +20,12 @@ type Article struct {
 	Author string `json:"author,omitempty" mapstructure:"author"`
 	// Byline name if different from author
 	BylineName string `json:"byline_name,omitempty" mapstructure:"byline_name"`
+	// Individual author names when the page lists several
+	Authors []string `json:"authors,omitempty" mapstructure:"authors"`
 	// Date when the article was published
 	PublishedDate time.Time `json:"published_date" mapstructure:"published_date"`
+	// Date when the article was last modified, if the page declares it
+	ModifiedDate *time.Time `json:"modified_date,omitempty" mapstructure:"modified_date"`
 	// Source of the article (e.g., website URL)
 	Source string `json:"source" mapstructure:"source"`
 	// Tags or categories related to the article
@@ -54,6 +58,56 @@ type Article struct {
 	Section string `json:"section,omitempty" mapstructure:"section"`
 	// Keywords from meta tags
 	Keywords []string `json:"keywords,omitempty" mapstructure:"keywords"`
+	// Images in the body, lead image first
+	Images []Image `json:"images,omitempty" mapstructure:"images"`
+	// Videos and embedded players in the body
+	Media []Media `json:"media,omitempty" mapstructure:"media"`
+	// Strategy that produced the body: selectors, container, readability, structured_data or none
+	ExtractionMethod string `json:"extraction_method,omitempty" mapstructure:"extraction_method"`
+	// ISO 639-1 code of the language of the title and body, e.g. en or fr
+	Language string `json:"language,omitempty" mapstructure:"language"`
+
+	// Structured data (schema.org)
+	// Name of the publishing organization
+	Publisher string `json:"publisher,omitempty" mapstructure:"publisher"`
+	// Whether the article is free to read; nil when the page does not say
+	IsAccessibleForFree *bool `json:"is_accessible_for_free,omitempty" mapstructure:"is_accessible_for_free"`
+	// Structured data formats found on the page, e.g. json-ld
+	StructuredData []string `json:"structured_data,omitempty" mapstructure:"structured_data"`
+
+	// Near-duplicate detection
+	// SimHash fingerprint of the body as 16 hex digits
+	SimHash string `json:"simhash,omitempty" mapstructure:"simhash"`
+	// SimHash bands used to look up candidate duplicates
+	SimHashBands []string `json:"simhash_bands,omitempty" mapstructure:"simhash_bands"`
+	// ID of the canonical article this article duplicates
+	DuplicateOf string `json:"duplicate_of,omitempty" mapstructure:"duplicate_of"`
+	// URLs of near-duplicate copies of this article on other sources
+	AlternateSources []string `json:"alternate_sources,omitempty" mapstructure:"alternate_sources"`
+
+	// Enrichment, set by the enrichers listed on the source
+	// Keyphrases extracted from the title and body, best first
+	Keyphrases []string `json:"keyphrases,omitempty" mapstructure:"keyphrases"`
+	// Gazetteer places mentioned in the title or body
+	Places []string `json:"places,omitempty" mapstructure:"places"`
+	// Gazetteer organizations mentioned in the title or body
+	Organizations []string `json:"organizations,omitempty" mapstructure:"organizations"`
+	// Estimated minutes needed to read the body
+	ReadingTimeMinutes int `json:"reading_time_minutes,omitempty" mapstructure:"reading_time_minutes"`
+
+	// Groups of the source: its group ID followed by its tags
+	Groups []string `json:"groups,omitempty" mapstructure:"groups"`
+
+	// Geolocation
+	// City covered by the source
+	City string `json:"city,omitempty" mapstructure:"city"`
+	// Province, state or region covered by the source
+	Region string `json:"region,omitempty" mapstructure:"region"`
+	// Coordinates of the source's city
+	Location *GeoPoint `json:"location,omitempty" mapstructure:"location"`
+	// Coordinates of the gazetteer places mentioned in the title or body
+	PlaceLocations []GeoPoint `json:"place_locations,omitempty" mapstructure:"place_locations"`
+
 	// Record creation timestamp
 	CreatedAt time.Time `json:"created_at" mapstructure:"created_at"`
 	// Record update timestamp
@@ -157,12 +211,18 @@ func (a *Article) cleanOGFields() {
 	a.OgURL = cleanString(a.OgURL)
 	a.Category = cleanString(a.Category)
 	a.Section = cleanString(a.Section)
+	a.Publisher = cleanString(a.Publisher)
 }
 
 // normalizeArrays ensures empty arrays are nil and deduplicates.
 func (a *Article) normalizeArrays() {
 	a.Tags = normalizeStringArray(a.Tags)
 	a.Keywords = normalizeStringArray(a.Keywords)
+	a.AlternateSources = normalizeStringArray(a.AlternateSources)
+	a.Authors = normalizeStringArray(a.Authors)
+	a.Keyphrases = normalizeStringArray(a.Keyphrases)
+	a.Places = normalizeStringArray(a.Places)
+	a.Organizations = normalizeStringArray(a.Organizations)
 }
 
 // normalizeStringArray removes empty items, deduplicates, and returns nil if empty.
@@ -0,0 +1,34 @@
+package domain
+
+import "fmt"
+
+// Latitude and longitude bounds in decimal degrees
+const (
+	maxLatitude  = 90
+	maxLongitude = 180
+)
+
+// GeoPoint is a location in decimal degrees, stored as an Elasticsearch geo_point.
+type GeoPoint struct {
+	Lat float64 `json:"lat" mapstructure:"lat"`
+	Lon float64 `json:"lon" mapstructure:"lon"`
+}
+
+// NewGeoPoint returns the point of a latitude and longitude, or nil unless both are set.
+func NewGeoPoint(lat, lon *float64) *GeoPoint {
+	if lat == nil || lon == nil {
+		return nil
+	}
+	return &GeoPoint{Lat: *lat, Lon: *lon}
+}
+
+// Validate checks that the point lies within the latitude and longitude bounds.
+func (p GeoPoint) Validate() error {
+	if p.Lat < -maxLatitude || p.Lat > maxLatitude {
+		return fmt.Errorf("latitude %v out of range [-90, 90]", p.Lat)
+	}
+	if p.Lon < -maxLongitude || p.Lon > maxLongitude {
+		return fmt.Errorf("longitude %v out of range [-180, 180]", p.Lon)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package domain provides domain models used across the application.
+package domain
+
+// Image represents an image found in an article body.
+type Image struct {
+	// Absolute URL of the largest available rendition
+	Src string `json:"src" mapstructure:"src"`
+	// Alternative text
+	Alt string `json:"alt,omitempty" mapstructure:"alt"`
+	// Caption from the enclosing figure, without the credit
+	Caption string `json:"caption,omitempty" mapstructure:"caption"`
+	// Photographer or agency credit
+	Credit string `json:"credit,omitempty" mapstructure:"credit"`
+	// Width in pixels, 0 when unknown
+	Width int `json:"width,omitempty" mapstructure:"width"`
+	// Height in pixels, 0 when unknown
+	Height int `json:"height,omitempty" mapstructure:"height"`
+}
+
+// Media represents an embedded video or player found in an article body.
+type Media struct {
+	// Kind of media, e.g. video
+	Type Type `json:"type" mapstructure:"type"`
+	// Hosting provider, e.g. youtube or vimeo; empty for self-hosted files
+	Provider string `json:"provider,omitempty" mapstructure:"provider"`
+	// Absolute URL of the file or embed
+	Src string `json:"src" mapstructure:"src"`
+	// Provider video ID when known
+	VideoID string `json:"video_id,omitempty" mapstructure:"video_id"`
+	// Poster or thumbnail image URL
+	Poster string `json:"poster,omitempty" mapstructure:"poster"`
+	// Title of the media
+	Title string `json:"title,omitempty" mapstructure:"title"`
+	// Width in pixels, 0 when unknown
+	Width int `json:"width,omitempty" mapstructure:"width"`
+	// Height in pixels, 0 when unknown
+	Height int `json:"height,omitempty" mapstructure:"height"`
+}
@@ -30,6 +30,18 @@ type Page struct {
 	OgURL         string `json:"og_url" mapstructure:"og_url"`
 	// Canonical URL if different from source
 	CanonicalURL string `json:"canonical_url" mapstructure:"canonical_url"`
+	// Strategy that produced the content: selectors, container, readability or none
+	ExtractionMethod string `json:"extraction_method,omitempty" mapstructure:"extraction_method"`
+	// ISO 639-1 code of the language of the title and content, e.g. en or fr
+	Language string `json:"language,omitempty" mapstructure:"language"`
+	// Groups of the source: its group ID followed by its tags
+	Groups []string `json:"groups,omitempty" mapstructure:"groups"`
+	// City covered by the source
+	City string `json:"city,omitempty" mapstructure:"city"`
+	// Province, state or region covered by the source
+	Region string `json:"region,omitempty" mapstructure:"region"`
+	// Coordinates of the source's city
+	Location *GeoPoint `json:"location,omitempty" mapstructure:"location"`
 	// Record creation timestamp
 	CreatedAt time.Time `json:"created_at" mapstructure:"created_at"`
 	// Record update timestamp
@@ -0,0 +1,199 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/export"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+	"github.com/jonesrussell/gocrawl/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testHits are the raw search hits returned by the mocked scroll.
+var testHits = []any{
+	map[string]any{
+		"_id": "a1",
+		"_source": map[string]any{
+			"title": "First, with a comma",
+			"tags":  []any{"news", "local"},
+		},
+	},
+	map[string]any{
+		"_id": "a2",
+		"_source": map[string]any{
+			"title": "Second",
+			"tags":  []any{"sports"},
+		},
+	},
+}
+
+// expectedDocs are the documents the importer should produce from an export of testHits.
+var expectedDocs = []types.BulkDocument{
+	{ID: "a1", Document: map[string]any{"title": "First, with a comma", "tags": []any{"news", "local"}}},
+	{ID: "a2", Document: map[string]any{"title": "Second", "tags": []any{"sports"}}},
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range export.Formats {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			log := logger.NewNoOp()
+			source, ok := testutils.NewMockStorage(log).(*testutils.MockStorage)
+			require.True(t, ok)
+			source.On("ScrollDocuments", mock.Anything, "articles", mock.Anything, export.DefaultBatchSize, mock.Anything).
+				Run(func(args mock.Arguments) {
+					handle, handleOK := args.Get(4).(types.ScrollHandler)
+					require.True(t, handleOK)
+					require.NoError(t, handle(testHits))
+				}).
+				Return(nil)
+
+			var buf bytes.Buffer
+			exported, err := export.NewExporter(log, source).Export(context.Background(), &buf, export.Options{
+				Index:  "articles",
+				Format: format,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), exported)
+
+			target, ok := testutils.NewMockStorage(log).(*testutils.MockStorage)
+			require.True(t, ok)
+			target.On("IndexExists", mock.Anything, "restored").Return(true, nil).Maybe()
+			target.On("GetMapping", mock.Anything, "restored").Return(map[string]any{}, nil).Maybe()
+			var imported []types.BulkDocument
+			target.On("BulkIndex", mock.Anything, "restored", mock.Anything).
+				Run(func(args mock.Arguments) {
+					docs, docsOK := args.Get(2).([]types.BulkDocument)
+					require.True(t, docsOK)
+					imported = append(imported, docs...)
+				}).
+				Return(nil)
+
+			count, err := export.NewImporter(log, target).Import(context.Background(), &buf, export.ImportOptions{
+				Index:  "restored",
+				Format: format,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), count)
+			assert.Equal(t, expectedDocs, imported)
+		})
+	}
+}
+
+func TestImportCSVUsesMapping(t *testing.T) {
+	t.Parallel()
+
+	csvData := "_id,title,word_count,score,breaking,published_date,tags\n" +
+		"a1,Budget,120,0.5,true,2026-10-01T12:00:00Z,\"[\"\"news\"\"]\"\n"
+	log := logger.NewNoOp()
+
+	newTarget := func() *testutils.MockStorage {
+		target, ok := testutils.NewMockStorage(log).(*testutils.MockStorage)
+		require.True(t, ok)
+		target.On("IndexExists", mock.Anything, "articles").Return(true, nil)
+		target.On("GetMapping", mock.Anything, "articles").Return(map[string]any{
+			"properties": map[string]any{
+				"title":          map[string]any{"type": "text"},
+				"word_count":     map[string]any{"type": "integer"},
+				"score":          map[string]any{"type": "float"},
+				"breaking":       map[string]any{"type": "boolean"},
+				"published_date": map[string]any{"type": "date"},
+				"tags":           map[string]any{"type": "keyword"},
+			},
+		}, nil)
+		return target
+	}
+
+	t.Run("converts cells to the mapped types", func(t *testing.T) {
+		t.Parallel()
+		target := newTarget()
+		var imported []types.BulkDocument
+		target.On("BulkIndex", mock.Anything, "articles", mock.Anything).
+			Run(func(args mock.Arguments) {
+				docs, ok := args.Get(2).([]types.BulkDocument)
+				require.True(t, ok)
+				imported = append(imported, docs...)
+			}).
+			Return(nil)
+
+		_, err := export.NewImporter(log, target).Import(context.Background(), strings.NewReader(csvData),
+			export.ImportOptions{Index: "articles", Format: export.FormatCSV})
+		require.NoError(t, err)
+		assert.Equal(t, []types.BulkDocument{{ID: "a1", Document: map[string]any{
+			"title":          "Budget",
+			"word_count":     int64(120),
+			"score":          0.5,
+			"breaking":       true,
+			"published_date": "2026-10-01T12:00:00Z",
+			"tags":           []any{"news"},
+		}}}, imported)
+	})
+
+	t.Run("rejects a value of the wrong type", func(t *testing.T) {
+		t.Parallel()
+		_, err := export.NewImporter(log, newTarget()).Import(context.Background(),
+			strings.NewReader("_id,word_count\na1,many\n"),
+			export.ImportOptions{Index: "articles", Format: export.FormatCSV})
+		require.ErrorContains(t, err, "row 1: field word_count")
+	})
+
+	t.Run("requires an existing index", func(t *testing.T) {
+		t.Parallel()
+		target, ok := testutils.NewMockStorage(log).(*testutils.MockStorage)
+		require.True(t, ok)
+		target.On("IndexExists", mock.Anything, "articles").Return(false, nil)
+
+		_, err := export.NewImporter(log, target).Import(context.Background(), strings.NewReader(csvData),
+			export.ImportOptions{Index: "articles", Format: export.FormatCSV})
+		require.ErrorContains(t, err, "does not exist")
+		target.AssertNotCalled(t, "BulkIndex", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestBuildQuery(t *testing.T) {
+	t.Parallel()
+
+	query, err := export.BuildQuery("", time.Time{}, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"query": map[string]any{"match_all": map[string]any{}}}, query)
+
+	since := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	query, err = export.BuildQuery(`{"term":{"source":"example"}}`, since, "published_date")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []any{
+					map[string]any{"term": map[string]any{"source": "example"}},
+					map[string]any{"range": map[string]any{
+						"published_date": map[string]any{"gte": "2026-01-01T00:00:00Z"},
+					}},
+				},
+			},
+		},
+	}, query)
+
+	_, err = export.BuildQuery("{not json", time.Time{}, "")
+	require.Error(t, err)
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := export.ParseFormat("NDJSON-Bulk")
+	require.NoError(t, err)
+	assert.Equal(t, export.FormatNDJSONBulk, format)
+
+	_, err = export.ParseFormat("parquet")
+	require.Error(t, err)
+}
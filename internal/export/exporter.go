@@ -0,0 +1,274 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+// Options configures an export.
+type Options struct {
+	// Index is the index or alias to export from
+	Index string
+	// Query is a query string or a JSON query DSL clause; empty matches all documents
+	Query string
+	// Since restricts the export to documents whose DateField is at or after this time
+	Since time.Time
+	// DateField is the date field Since applies to
+	DateField string
+	// Format is the output file format
+	Format Format
+	// Fields restricts and orders CSV columns; by default the fields of the first document are used
+	Fields []string
+	// BatchSize is the number of documents fetched per page
+	BatchSize int
+}
+
+// Exporter writes indexed documents to files.
+type Exporter struct {
+	logger  logger.Interface
+	storage types.Interface
+}
+
+// NewExporter creates a new exporter.
+func NewExporter(log logger.Interface, storage types.Interface) *Exporter {
+	return &Exporter{
+		logger:  log,
+		storage: storage,
+	}
+}
+
+// Export writes all documents matching the options to w and returns how many were written.
+func (e *Exporter) Export(ctx context.Context, w io.Writer, opts Options) (int64, error) {
+	if opts.Index == "" {
+		return 0, errors.New("index is required")
+	}
+
+	query, err := BuildQuery(opts.Query, opts.Since, opts.DateField)
+	if err != nil {
+		return 0, err
+	}
+
+	writer := newRecordWriter(w, opts)
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	var written int64
+	scrollErr := e.storage.ScrollDocuments(ctx, opts.Index, query, batchSize, func(hits []any) error {
+		for _, hit := range hits {
+			id, source, ok := parseHit(hit)
+			if !ok {
+				continue
+			}
+			if writeErr := writer.Write(id, source); writeErr != nil {
+				return fmt.Errorf("failed to write document %s: %w", id, writeErr)
+			}
+			written++
+		}
+		e.logger.Debug("Exported batch", "index", opts.Index, "documents", written)
+		return nil
+	})
+	if scrollErr != nil {
+		return written, fmt.Errorf("failed to export documents: %w", scrollErr)
+	}
+
+	if flushErr := writer.Flush(); flushErr != nil {
+		return written, fmt.Errorf("failed to flush output: %w", flushErr)
+	}
+
+	e.logger.Info("Export completed", "index", opts.Index, "format", opts.Format, "documents", written)
+	return written, nil
+}
+
+// BuildQuery builds the search body for an export. A query starting with "{" is
+// treated as a JSON query DSL clause, anything else as a query string.
+func BuildQuery(query string, since time.Time, dateField string) (map[string]any, error) {
+	filters := make([]any, 0, 2)
+
+	query = strings.TrimSpace(query)
+	switch {
+	case query == "":
+	case strings.HasPrefix(query, "{"):
+		var clause map[string]any
+		if err := json.Unmarshal([]byte(query), &clause); err != nil {
+			return nil, fmt.Errorf("invalid JSON query: %w", err)
+		}
+		if inner, ok := clause["query"].(map[string]any); ok {
+			clause = inner
+		}
+		filters = append(filters, clause)
+	default:
+		filters = append(filters, map[string]any{
+			"query_string": map[string]any{"query": query},
+		})
+	}
+
+	if !since.IsZero() {
+		if dateField == "" {
+			return nil, errors.New("a date field is required to filter by date")
+		}
+		filters = append(filters, map[string]any{
+			"range": map[string]any{
+				dateField: map[string]any{"gte": since.UTC().Format(time.RFC3339)},
+			},
+		})
+	}
+
+	if len(filters) == 0 {
+		return map[string]any{"query": map[string]any{"match_all": map[string]any{}}}, nil
+	}
+
+	return map[string]any{
+		"query": map[string]any{
+			"bool": map[string]any{"filter": filters},
+		},
+	}, nil
+}
+
+// parseHit extracts the ID and source of a raw search hit.
+func parseHit(hit any) (string, map[string]any, bool) {
+	hitMap, ok := hit.(map[string]any)
+	if !ok {
+		return "", nil, false
+	}
+	id, _ := hitMap["_id"].(string)
+	source, ok := hitMap["_source"].(map[string]any)
+	if !ok {
+		return "", nil, false
+	}
+	return id, source, true
+}
+
+// recordWriter writes documents in a specific format.
+type recordWriter interface {
+	Write(id string, source map[string]any) error
+	Flush() error
+}
+
+// newRecordWriter returns the writer for the export format.
+func newRecordWriter(w io.Writer, opts Options) recordWriter {
+	switch opts.Format {
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w), fields: opts.Fields}
+	case FormatNDJSONBulk:
+		return &bulkWriter{encoder: json.NewEncoder(w), index: opts.Index}
+	case FormatJSONL:
+		return &jsonlWriter{encoder: json.NewEncoder(w)}
+	default:
+		return &jsonlWriter{encoder: json.NewEncoder(w)}
+	}
+}
+
+// jsonlWriter writes one JSON document per line.
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+// Write writes a document with its ID in the _id field.
+func (w *jsonlWriter) Write(id string, source map[string]any) error {
+	record := make(map[string]any, len(source)+1)
+	maps.Copy(record, source)
+	record[IDField] = id
+	return w.encoder.Encode(record)
+}
+
+// Flush is a no-op for JSON lines.
+func (w *jsonlWriter) Flush() error {
+	return nil
+}
+
+// bulkWriter writes Elasticsearch bulk API action and source pairs.
+type bulkWriter struct {
+	encoder *json.Encoder
+	index   string
+}
+
+// Write writes the index action followed by the document source.
+func (w *bulkWriter) Write(id string, source map[string]any) error {
+	action := map[string]any{"index": map[string]any{"_index": w.index, "_id": id}}
+	if err := w.encoder.Encode(action); err != nil {
+		return err
+	}
+	return w.encoder.Encode(source)
+}
+
+// Flush is a no-op for bulk NDJSON.
+func (w *bulkWriter) Flush() error {
+	return nil
+}
+
+// csvWriter writes one document per row. The header is written with the first document.
+type csvWriter struct {
+	writer        *csv.Writer
+	fields        []string
+	headerWritten bool
+}
+
+// Write writes a document as a CSV row.
+func (w *csvWriter) Write(id string, source map[string]any) error {
+	if !w.headerWritten {
+		if len(w.fields) == 0 {
+			w.fields = append([]string{IDField}, slices.Sorted(maps.Keys(source))...)
+		}
+		if err := w.writer.Write(w.fields); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	row := make([]string, len(w.fields))
+	for i, field := range w.fields {
+		if field == IDField {
+			row[i] = id
+			continue
+		}
+		value, err := formatCSVValue(source[field])
+		if err != nil {
+			return fmt.Errorf("failed to format field %s: %w", field, err)
+		}
+		row[i] = value
+	}
+
+	return w.writer.Write(row)
+}
+
+// Flush flushes buffered rows.
+func (w *csvWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// formatCSVValue formats a field value for a CSV cell. Objects and arrays are written as JSON.
+func formatCSVValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
@@ -0,0 +1,41 @@
+// Package export moves indexed content in and out of Elasticsearch as files.
+// Documents are exported as JSON lines, CSV or Elasticsearch bulk NDJSON and
+// the same files can be loaded back with the importer.
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format identifies a file format supported by the exporter and importer.
+type Format string
+
+const (
+	// FormatJSONL writes one document source per line with its ID in the _id field.
+	FormatJSONL Format = "jsonl"
+	// FormatCSV writes one document per row with a header of field names.
+	FormatCSV Format = "csv"
+	// FormatNDJSONBulk writes Elasticsearch bulk API action and source line pairs.
+	FormatNDJSONBulk Format = "ndjson-bulk"
+
+	// IDField is the field holding the document ID in JSONL and CSV files.
+	IDField = "_id"
+
+	// DefaultBatchSize is the number of documents read or written per request.
+	DefaultBatchSize = 500
+)
+
+// Formats lists all supported formats.
+var Formats = []Format{FormatJSONL, FormatCSV, FormatNDJSONBulk}
+
+// ParseFormat parses a format name.
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	for _, supported := range Formats {
+		if format == supported {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported format %q, expected one of jsonl, csv, ndjson-bulk", name)
+}
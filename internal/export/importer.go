@@ -0,0 +1,310 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+// maxLineSize is the largest single line accepted in JSON lines and bulk files.
+const maxLineSize = 16 * 1024 * 1024
+
+// ImportOptions configures an import.
+type ImportOptions struct {
+	// Index is the target index; for bulk files it overrides the index in each action
+	Index string
+	// Format is the input file format
+	Format Format
+	// BatchSize is the number of documents sent per bulk request
+	BatchSize int
+}
+
+// Importer bulk-loads exported files into an index.
+type Importer struct {
+	logger  logger.Interface
+	storage types.Interface
+}
+
+// NewImporter creates a new importer.
+func NewImporter(log logger.Interface, storage types.Interface) *Importer {
+	return &Importer{
+		logger:  log,
+		storage: storage,
+	}
+}
+
+// record is a single document read from an import file.
+type record struct {
+	index string
+	doc   types.BulkDocument
+}
+
+// Import reads documents from r and bulk-indexes them. It returns how many were imported.
+func (i *Importer) Import(ctx context.Context, r io.Reader, opts ImportOptions) (int64, error) {
+	if opts.Index == "" && opts.Format != FormatNDJSONBulk {
+		return 0, errors.New("index is required")
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	var (
+		imported int64
+		batch    = make([]types.BulkDocument, 0, batchSize)
+		index    string
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := i.storage.BulkIndex(ctx, index, batch); err != nil {
+			return fmt.Errorf("failed to import batch into %s: %w", index, err)
+		}
+		imported += int64(len(batch))
+		i.logger.Debug("Imported batch", "index", index, "documents", imported)
+		batch = batch[:0]
+		return nil
+	}
+
+	emit := func(rec record) error {
+		target := opts.Index
+		if target == "" {
+			target = rec.index
+		}
+		if target == "" {
+			return errors.New("document has no target index, use --index")
+		}
+		if target != index || len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+			index = target
+		}
+		batch = append(batch, rec.doc)
+		return nil
+	}
+
+	var readErr error
+	switch opts.Format {
+	case FormatCSV:
+		fieldTypes, typesErr := i.fieldTypes(ctx, opts.Index)
+		if typesErr != nil {
+			return 0, typesErr
+		}
+		readErr = readCSV(r, fieldTypes, emit)
+	case FormatNDJSONBulk:
+		readErr = readBulk(r, emit)
+	case FormatJSONL:
+		readErr = readJSONL(r, emit)
+	default:
+		return 0, fmt.Errorf("unsupported format %q", opts.Format)
+	}
+	if readErr != nil {
+		return imported, readErr
+	}
+
+	if err := flush(); err != nil {
+		return imported, err
+	}
+
+	i.logger.Info("Import completed", "index", index, "format", opts.Format, "documents", imported)
+	return imported, nil
+}
+
+// newLineScanner returns a scanner for newline-delimited JSON.
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	return scanner
+}
+
+// readJSONL reads one document per line, taking the ID from the _id field.
+func readJSONL(r io.Reader, emit func(record) error) error {
+	scanner := newLineScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var source map[string]any
+		if err := json.Unmarshal(data, &source); err != nil {
+			return fmt.Errorf("line %d: invalid JSON: %w", line, err)
+		}
+
+		id, _ := source[IDField].(string)
+		delete(source, IDField)
+		if err := emit(record{doc: types.BulkDocument{ID: id, Document: source}}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// readBulk reads Elasticsearch bulk API action and source line pairs.
+func readBulk(r io.Reader, emit func(record) error) error {
+	scanner := newLineScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		actionLine := bytes.TrimSpace(scanner.Bytes())
+		if len(actionLine) == 0 {
+			continue
+		}
+
+		var action map[string]struct {
+			Index string `json:"_index"`
+			ID    string `json:"_id"`
+		}
+		if err := json.Unmarshal(actionLine, &action); err != nil {
+			return fmt.Errorf("line %d: invalid bulk action: %w", line, err)
+		}
+
+		meta, ok := action["index"]
+		if !ok {
+			meta, ok = action["create"]
+		}
+		if !ok {
+			return fmt.Errorf("line %d: unsupported bulk action, expected index or create", line)
+		}
+
+		if !scanner.Scan() {
+			return fmt.Errorf("line %d: bulk action without a document", line)
+		}
+		line++
+
+		var source map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &source); err != nil {
+			return fmt.Errorf("line %d: invalid JSON: %w", line, err)
+		}
+
+		if err := emit(record{index: meta.Index, doc: types.BulkDocument{ID: meta.ID, Document: source}}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// fieldTypes returns the mapped type of each top-level field of index. CSV cells carry no
+// type, so they are converted using the mapping and the index must already exist.
+func (i *Importer) fieldTypes(ctx context.Context, index string) (map[string]string, error) {
+	exists, err := i.storage.IndexExists(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check index %s: %w", index, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("index %s does not exist; CSV cells are untyped, so create the index "+
+			"with its mapping first (gocrawl index create) or import JSON lines", index)
+	}
+
+	mapping, err := i.storage.GetMapping(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mapping of %s: %w", index, err)
+	}
+
+	properties, _ := mapping["properties"].(map[string]any)
+	fieldTypes := make(map[string]string, len(properties))
+	for field, property := range properties {
+		definition, _ := property.(map[string]any)
+		fieldType, _ := definition["type"].(string)
+		fieldTypes[field] = fieldType
+	}
+	return fieldTypes, nil
+}
+
+// readCSV reads one document per row using the header row as field names.
+// Cells holding JSON objects or arrays are decoded, other cells are converted to the
+// field's mapped type; empty cells are omitted.
+func readCSV(r io.Reader, fieldTypes map[string]string, emit func(record) error) error {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	rowNum := 0
+	for {
+		rowNum++
+		row, readErr := reader.Read()
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read CSV row: %w", readErr)
+		}
+
+		var id string
+		source := make(map[string]any, len(header))
+		for col, field := range header {
+			if col >= len(row) || row[col] == "" {
+				continue
+			}
+			if field == IDField {
+				id = row[col]
+				continue
+			}
+			value, valueErr := parseCSVValue(row[col], fieldTypes[field])
+			if valueErr != nil {
+				return fmt.Errorf("row %d: field %s: %w", rowNum, field, valueErr)
+			}
+			source[field] = value
+		}
+
+		if emitErr := emit(record{doc: types.BulkDocument{ID: id, Document: source}}); emitErr != nil {
+			return emitErr
+		}
+	}
+}
+
+// parseCSVValue decodes JSON objects and arrays and converts other values to fieldType.
+// Dates and unmapped or string fields are returned as strings.
+func parseCSVValue(value, fieldType string) (any, error) {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var decoded any
+		if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+			return decoded, nil
+		}
+	}
+
+	switch fieldType {
+	case "long", "integer", "short", "byte", "unsigned_long":
+		n, err := strconv.ParseInt(trimmed, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid %s", value, fieldType)
+		}
+		return n, nil
+	case "double", "float", "half_float", "scaled_float":
+		f, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid %s", value, fieldType)
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid boolean", value)
+		}
+		return b, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
+)
+
+// NewAPISource creates a gosources API source from discovered selectors, with
+// the same name, indexes and defaults as GenerateSourceYAML. URL rules are not
+// part of the API source and are left out.
+func NewAPISource(sourceURL string, result DiscoveryResult) (*apiclient.APISource, error) {
+	parsedURL, err := url.Parse(sourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	return &apiclient.APISource{
+		Name:         generateSourceName(parsedURL.Hostname()),
+		URL:          sourceURL,
+		ArticleIndex: generateIndexName(parsedURL.Hostname(), "articles"),
+		PageIndex:    generateIndexName(parsedURL.Hostname(), "pages"),
+		RateLimit:    "1s",
+		MaxDepth:     2,
+		Time:         []string{"11:45", "23:45"},
+		Enabled:      true,
+		Selectors:    APISelectors(result),
+	}, nil
+}
+
+// APISelectors converts discovered selectors to gosources API selectors.
+func APISelectors(result DiscoveryResult) apiclient.APISelectors {
+	return apiclient.APISelectors{
+		Article: apiclient.APIArticleSelectors{
+			Title:         joinSelectors(result.Title),
+			Body:          joinSelectors(result.Body),
+			Author:        joinSelectors(result.Author),
+			PublishedTime: joinSelectors(result.PublishedTime),
+			Image:         joinSelectors(result.Image),
+			Link:          joinSelectors(result.Link),
+			Category:      joinSelectors(result.Category),
+			Exclude:       result.Exclusions,
+		},
+		List: apiclient.APIListSelectors{
+			ArticleCards: joinSelectors(result.ArticleCards),
+		},
+	}
+}
+
+// joinSelectors joins the selectors of a candidate into one selector list.
+func joinSelectors(candidate SelectorCandidate) string {
+	return strings.Join(candidate.Selectors, ", ")
+}
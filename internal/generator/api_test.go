@@ -0,0 +1,28 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/gocrawl/internal/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPISource(t *testing.T) {
+	t.Parallel()
+
+	source, err := generator.NewAPISource("https://www.example.com/", generator.DiscoveryResult{
+		Title:        generator.SelectorCandidate{Selectors: []string{"h1.headline", "h1"}},
+		Exclusions:   []string{".ad"},
+		ArticleCards: generator.SelectorCandidate{Selectors: []string{"li.story-card"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.example.com/", source.URL)
+	assert.Equal(t, "h1.headline, h1", source.Selectors.Article.Title)
+	assert.Equal(t, []string{".ad"}, source.Selectors.Article.Exclude)
+	assert.Equal(t, "li.story-card", source.Selectors.List.ArticleCards)
+	assert.True(t, source.Enabled)
+
+	_, err = generator.NewAPISource("://example.com", generator.DiscoveryResult{})
+	require.Error(t, err)
+}
@@ -31,6 +31,16 @@ const (
 	mediumContentBonus = 0.02
 )
 
+// articleLinkPatterns are URL path fragments that mark links to articles.
+var articleLinkPatterns = []string{
+	"/news/",
+	"/article/",
+	"/story/",
+	"/post/",
+	"/blog/",
+	"/local-news/",
+}
+
 // SelectorDiscovery analyzes HTML documents to discover CSS selectors
 // for extracting article content.
 type SelectorDiscovery struct {
@@ -471,20 +481,11 @@ func (sd *SelectorDiscovery) DiscoverLinks() SelectorCandidate {
 		Confidence: linkLowConfidence,
 	}
 
-	patterns := []string{
-		"/news/",
-		"/article/",
-		"/story/",
-		"/post/",
-		"/blog/",
-		"/local-news/",
-	}
-
-	linkSelectors, sampleHref := sd.collectLinkSelectors(patterns)
+	linkSelectors, sampleHref := sd.collectLinkSelectors(articleLinkPatterns)
 	candidate.Selectors = sd.getTopLinkSelectors(linkSelectors)
 
 	if len(candidate.Selectors) == 0 {
-		candidate.Selectors = sd.getGenericLinkSelectors(patterns)
+		candidate.Selectors = sd.getGenericLinkSelectors(articleLinkPatterns)
 	}
 
 	if sampleHref != "" {
@@ -494,6 +495,62 @@ func (sd *SelectorDiscovery) DiscoverLinks() SelectorCandidate {
 	return candidate
 }
 
+// SampleArticleURLs returns up to limit absolute URLs of articles linked from
+// the page, on the page's own host. Links under the usual article paths come
+// first, in the order they appear; when there are too few, links of the page's
+// most frequent slug and id path templates fill the rest.
+func (sd *SelectorDiscovery) SampleArticleURLs(limit int) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	sd.doc.Find("a[href]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		href, _ := s.Attr("href")
+		if !matchesArticlePattern(href, articleLinkPatterns) {
+			return true
+		}
+		link, err := sd.url.Parse(href)
+		if err != nil || link.Host != sd.url.Host {
+			return true
+		}
+		link.Fragment = ""
+		if absolute := link.String(); !seen[absolute] && absolute != sd.url.String() {
+			seen[absolute] = true
+			urls = append(urls, absolute)
+		}
+		return len(urls) < limit
+	})
+	if len(urls) < limit {
+		urls = sd.appendTemplateURLs(urls, seen, limit)
+	}
+	return urls
+}
+
+// appendTemplateURLs appends links whose path template ends in a slug or an id,
+// as article URLs do, taking the templates most frequent on the page first.
+func (sd *SelectorDiscovery) appendTemplateURLs(urls []string, seen map[string]bool, limit int) []string {
+	learner, err := NewURLPatternLearner(sd.url.String())
+	if err != nil {
+		return urls
+	}
+	learner.AddListing(sd.doc)
+
+	for _, pattern := range learner.Patterns() {
+		if !strings.HasSuffix(pattern.Template, "/"+slugPlaceholder) &&
+			!strings.HasSuffix(pattern.Template, "/"+idPlaceholder) {
+			continue
+		}
+		for _, link := range pattern.URLs {
+			if len(urls) == limit {
+				return urls
+			}
+			if !seen[link] && link != sd.url.String() {
+				seen[link] = true
+				urls = append(urls, link)
+			}
+		}
+	}
+	return urls
+}
+
 // collectLinkSelectors collects link selectors from the document.
 func (sd *SelectorDiscovery) collectLinkSelectors(patterns []string) (linkSelectors map[string]int, sampleHref string) {
 	linkSelectors = make(map[string]int)
@@ -0,0 +1,394 @@
+package generator
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	// Weights of the kinds of inferred selectors; ids and data attributes survive redesigns best
+	idSelectorWeight        = 1.0
+	attributeSelectorWeight = 0.95
+	classSelectorWeight     = 0.85
+	anchoredSelectorWeight  = 0.80
+	tagSelectorWeight       = 0.60
+	// ambiguousMatchScore is the page score of a selector whose first match is the
+	// target but that matches other elements too
+	ambiguousMatchScore = 0.8
+	// maxAnchorDepth is how far up the tree an anchor for a path selector is looked for
+	maxAnchorDepth = 5
+	// maxAttributeValueLength keeps content-bearing attribute values out of selectors
+	maxAttributeValueLength = 40
+	// minBodyTextLength is the paragraph text an element needs to be taken for the body
+	minBodyTextLength = 200
+	// maxBylineLength bounds the text of an element taken for a "By ..." byline
+	maxBylineLength = 80
+	// maxAlternatives is how many alternatives are kept per field
+	maxAlternatives = 5
+)
+
+var (
+	// utilityClassPattern matches utility CSS classes such as Tailwind's "mt-4" or "text-lg".
+	utilityClassPattern = regexp.MustCompile(
+		`^-?[mp][trblxy]?-\d|^(text|bg|font|flex|grid|w|h|min|max|gap|items|justify|self|rounded|border|` +
+			`leading|tracking|shadow|space|col|row|z|top|left|right|bottom|inset|overflow|hidden|block|inline|` +
+			`absolute|relative|sticky|fixed|opacity|order|basis|grow|shrink|align|sr|truncate)(-|$)`)
+	// generatedPrefixes are the prefixes of class names emitted by CSS-in-JS libraries and CSS modules.
+	generatedPrefixes = []string{"css-", "sc-", "jsx-", "svelte-", "emotion-", "_"}
+	// digitRunPattern matches per-article numbers such as the 12345 of "post-12345".
+	digitRunPattern = regexp.MustCompile(`\d{3,}`)
+	// identifierPattern matches names that can be used in a selector without escaping.
+	identifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+	// anchorTags are semantic elements that make good anchors for path selectors.
+	anchorTags = map[string]bool{"article": true, "main": true, "header": true}
+	// selectorAttributes are the non-data attributes worth selecting on.
+	selectorAttributes = map[string]bool{"itemprop": true, "rel": true, "role": true}
+)
+
+// fieldLocator finds the element holding a field in a sample article.
+type fieldLocator func(doc *goquery.Document) *goquery.Selection
+
+// scoredSelector is a candidate selector with its kind weight.
+type scoredSelector struct {
+	selector string
+	weight   float64
+}
+
+// StructuralInference infers selectors by aligning the DOMs of several sample
+// articles of a source. For every field it locates the element holding the
+// field on each page, derives candidate selectors from those elements, and
+// ranks the candidates by how consistently they pick the field across pages.
+// Unlike SelectorDiscovery it needs no known class names, so it also works on
+// sites with hashed or utility CSS classes.
+type StructuralInference struct {
+	docs []*goquery.Document
+}
+
+// NewStructuralInference creates a StructuralInference over sample article documents.
+func NewStructuralInference(docs []*goquery.Document) *StructuralInference {
+	return &StructuralInference{docs: docs}
+}
+
+// InferAll infers the title, body, author and published time selectors.
+func (si *StructuralInference) InferAll() DiscoveryResult {
+	return DiscoveryResult{
+		Title:         si.infer("title", locateTitle),
+		Body:          si.infer("body", locateBody),
+		Author:        si.infer("author", locateAuthor),
+		PublishedTime: si.infer("published_time", locatePublishedTime),
+	}
+}
+
+// infer ranks the candidate selectors of a field across the sample documents.
+func (si *StructuralInference) infer(field string, locate fieldLocator) SelectorCandidate {
+	candidate := SelectorCandidate{
+		Field:     field,
+		Selectors: []string{},
+	}
+	if len(si.docs) == 0 {
+		return candidate
+	}
+
+	targets := make([]*html.Node, len(si.docs))
+	weights := make(map[string]float64)
+	var order []string
+	located := 0
+	for i, doc := range si.docs {
+		target := locate(doc)
+		if target == nil || target.Length() == 0 {
+			continue
+		}
+		targets[i] = target.Nodes[0]
+		located++
+		if candidate.SampleText == "" {
+			candidate.SampleText = truncateText(normalizeText(target.Text()), sampleTextLength)
+		}
+		for _, sel := range candidateSelectors(target) {
+			if _, seen := weights[sel.selector]; !seen {
+				order = append(order, sel.selector)
+			}
+			weights[sel.selector] = math.Max(weights[sel.selector], sel.weight)
+		}
+	}
+	if located == 0 {
+		return candidate
+	}
+	coverage := float64(located) / float64(len(si.docs))
+
+	ranked := make([]RankedSelector, 0, len(order))
+	for _, selector := range order {
+		consistency := si.consistency(selector, targets, located)
+		confidence := math.Round(consistency*weights[selector]*coverage*100) / 100
+		if confidence > 0 {
+			ranked = append(ranked, RankedSelector{Selector: selector, Confidence: confidence})
+		}
+	}
+	if len(ranked) == 0 {
+		return candidate
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Confidence != ranked[j].Confidence {
+			return ranked[i].Confidence > ranked[j].Confidence
+		}
+		return len(ranked[i].Selector) < len(ranked[j].Selector)
+	})
+
+	candidate.Selectors = []string{ranked[0].Selector}
+	candidate.Confidence = ranked[0].Confidence
+	alternatives := ranked[1:]
+	if len(alternatives) > maxAlternatives {
+		alternatives = alternatives[:maxAlternatives]
+	}
+	candidate.Alternatives = alternatives
+	return candidate
+}
+
+// consistency is the average page score of a selector over the pages where the field was located.
+func (si *StructuralInference) consistency(selector string, targets []*html.Node, located int) float64 {
+	var total float64
+	for i, doc := range si.docs {
+		if targets[i] == nil {
+			continue
+		}
+		matches := doc.Find(selector)
+		if matches.Length() == 0 || matches.Nodes[0] != targets[i] {
+			continue
+		}
+		if matches.Length() == 1 {
+			total++
+		} else {
+			total += ambiguousMatchScore
+		}
+	}
+	return total / float64(located)
+}
+
+// candidateSelectors derives the candidate selectors of an element, preferring ids and data attributes.
+func candidateSelectors(target *goquery.Selection) []scoredSelector {
+	tag := goquery.NodeName(target)
+	var candidates []scoredSelector
+
+	if id, ok := target.Attr("id"); ok && isStableName(id) {
+		candidates = append(candidates, scoredSelector{"#" + id, idSelectorWeight})
+	}
+	for _, attr := range stableAttributes(target) {
+		candidates = append(candidates, scoredSelector{attr, attributeSelectorWeight})
+	}
+	for _, class := range stableClasses(target) {
+		candidates = append(candidates, scoredSelector{tag + "." + class, classSelectorWeight})
+	}
+	if anchor := findAnchor(target); anchor != "" {
+		candidates = append(candidates, scoredSelector{anchor + " " + tag, anchoredSelectorWeight})
+	}
+	candidates = append(candidates, scoredSelector{tag, tagSelectorWeight})
+
+	return candidates
+}
+
+// findAnchor returns a selector for the nearest ancestor with a stable id,
+// data attribute or semantic tag, or "" when there is none close by.
+func findAnchor(target *goquery.Selection) string {
+	ancestor := target.Parent()
+	for depth := 0; depth < maxAnchorDepth && ancestor.Length() > 0; depth++ {
+		tag := goquery.NodeName(ancestor)
+		if tag == "body" || tag == "html" {
+			return ""
+		}
+		if id, ok := ancestor.Attr("id"); ok && isStableName(id) {
+			return "#" + id
+		}
+		if attrs := stableAttributes(ancestor); len(attrs) > 0 {
+			return attrs[0]
+		}
+		if anchorTags[tag] {
+			return tag
+		}
+		ancestor = ancestor.Parent()
+	}
+	return ""
+}
+
+// stableAttributes returns attribute selectors for the data-*, itemprop, rel
+// and role attributes of an element whose values look stable.
+func stableAttributes(s *goquery.Selection) []string {
+	var attrs []string
+	for _, attr := range s.Nodes[0].Attr {
+		if !strings.HasPrefix(attr.Key, "data-") && !selectorAttributes[attr.Key] {
+			continue
+		}
+		value := strings.TrimSpace(attr.Val)
+		if value == "" || len(value) > maxAttributeValueLength ||
+			strings.ContainsAny(value, `'"\`) || digitRunPattern.MatchString(value) {
+			continue
+		}
+		attrs = append(attrs, "["+attr.Key+"='"+value+"']")
+	}
+	return attrs
+}
+
+// stableClasses returns the class names of an element that do not look generated.
+func stableClasses(s *goquery.Selection) []string {
+	class, _ := s.Attr("class")
+	var classes []string
+	for _, name := range strings.Fields(class) {
+		if isStableName(name) && !utilityClassPattern.MatchString(name) {
+			classes = append(classes, name)
+		}
+	}
+	return classes
+}
+
+// isStableName reports whether an id or class name looks hand-written rather
+// than generated by a build tool or tied to a single article.
+func isStableName(name string) bool {
+	if !identifierPattern.MatchString(name) || digitRunPattern.MatchString(name) {
+		return false
+	}
+	for _, prefix := range generatedPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	for _, segment := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' }) {
+		if looksHashed(segment) {
+			return false
+		}
+	}
+	return true
+}
+
+// looksHashed reports whether a name segment looks like a hash, e.g. "1x2y3z" or "bdVaJa".
+func looksHashed(segment string) bool {
+	const minHashLength = 5
+	var letters, digits, innerUpper int
+	for i, r := range segment {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case unicode.IsLetter(r):
+			letters++
+			if i > 0 && unicode.IsUpper(r) {
+				innerUpper++
+			}
+		}
+	}
+	return (len(segment) >= minHashLength && letters > 0 && digits > 0) || innerUpper >= 2
+}
+
+// locateTitle finds the headline: the element whose text is the page's og:title
+// or <title>, or else the first h1.
+func locateTitle(doc *goquery.Document) *goquery.Selection {
+	var anchors []string
+	for _, sel := range []string{"meta[property='og:title']", "meta[name='twitter:title']"} {
+		if content, ok := doc.Find(sel).First().Attr("content"); ok {
+			anchors = append(anchors, content)
+		}
+	}
+	// Page titles usually carry the site name after a separator
+	pageTitle := doc.Find("title").First().Text()
+	for _, separator := range []string{" | ", " - ", " – ", " — "} {
+		if before, _, found := strings.Cut(pageTitle, separator); found {
+			anchors = append(anchors, before)
+		}
+	}
+	anchors = append(anchors, pageTitle)
+
+	for _, anchor := range anchors {
+		anchor = normalizeText(anchor)
+		if anchor == "" {
+			continue
+		}
+		if target := deepestMatching(doc, func(text string) bool { return text == anchor }); target != nil {
+			return target
+		}
+	}
+
+	if h1 := doc.Find("body h1").First(); h1.Length() > 0 {
+		return h1
+	}
+	return nil
+}
+
+// locateBody finds the element whose own paragraphs hold the most text.
+func locateBody(doc *goquery.Document) *goquery.Selection {
+	var best *goquery.Selection
+	bestLength := minBodyTextLength - 1
+	doc.Find("body *").Each(func(_ int, s *goquery.Selection) {
+		length := 0
+		s.ChildrenFiltered("p").Each(func(_ int, p *goquery.Selection) {
+			length += len(normalizeText(p.Text()))
+		})
+		if length > bestLength {
+			best, bestLength = s, length
+		}
+	})
+	return best
+}
+
+// locateAuthor finds the byline: the element naming the author given in the
+// page's meta tags, or else a short "By ..." element.
+func locateAuthor(doc *goquery.Document) *goquery.Selection {
+	var author string
+	for _, sel := range []string{"meta[name='author']", "meta[property='article:author']"} {
+		content, ok := doc.Find(sel).First().Attr("content")
+		if ok && !strings.HasPrefix(content, "http") {
+			author = normalizeText(content)
+			break
+		}
+	}
+
+	if author != "" {
+		target := deepestMatching(doc, func(text string) bool {
+			return strings.TrimPrefix(text, "by ") == author
+		})
+		if target != nil {
+			return target
+		}
+	}
+
+	return deepestMatching(doc, func(text string) bool {
+		return strings.HasPrefix(text, "by ") && len(text) <= maxBylineLength
+	})
+}
+
+// locatePublishedTime finds the publication date: the first time element, or
+// else the first element carrying a datetime attribute.
+func locatePublishedTime(doc *goquery.Document) *goquery.Selection {
+	for _, sel := range []string{"body time", "body [datetime]", "body [itemprop='datePublished']"} {
+		if target := doc.Find(sel).First(); target.Length() > 0 {
+			return target
+		}
+	}
+	return nil
+}
+
+// deepestMatching returns the first element in the body whose normalized text
+// matches while none of its children's does, or nil.
+func deepestMatching(doc *goquery.Document, match func(text string) bool) *goquery.Selection {
+	var found *goquery.Selection
+	doc.Find("body *").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if !match(normalizeText(s.Text())) {
+			return true
+		}
+		childMatches := s.Children().FilterFunction(func(_ int, child *goquery.Selection) bool {
+			return match(normalizeText(child.Text()))
+		})
+		if childMatches.Length() > 0 {
+			return true
+		}
+		found = s
+		return false
+	})
+	return found
+}
+
+// normalizeText lowercases text and collapses its whitespace.
+func normalizeText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
@@ -0,0 +1,131 @@
+package generator_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/jonesrussell/gocrawl/internal/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hashedArticle renders an article page whose class names are generated, as
+// on sites built with CSS-in-JS or utility CSS.
+func hashedArticle(t *testing.T, n int, title, author string) *goquery.Document {
+	t.Helper()
+
+	paragraph := strings.Repeat("Council voted on the new transit plan for the downtown core. ", 5)
+	page := fmt.Sprintf(`<html><head>
+<title>%[2]s | Sudbury Star</title>
+<meta property="og:title" content="%[2]s">
+<meta name="author" content="%[3]s">
+</head><body>
+<div class="css-1x9k2z3 flex mt-4">
+  <nav class="sc-bdVaJa"><a href="/">Home</a></nav>
+  <div id="story-%[1]d00" data-testid="story">
+    <h1 class="css-a8f3k2x text-3xl">%[2]s</h1>
+    <span class="sc-htpNat">By %[3]s</span>
+    <time class="css-q2w3e4r" datetime="2026-03-0%[1]dT10:00:00Z">March %[1]d, 2026</time>
+    <div class="css-z9y8x7w">
+      <p>%[4]s</p><p>%[4]s</p><p>%[4]s</p>
+    </div>
+  </div>
+  <aside class="css-r4t5y6u"><p>Most read</p></aside>
+</div>
+</body></html>`, n, title, author, paragraph)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	require.NoError(t, err)
+	return doc
+}
+
+func TestStructuralInference_HashedClasses(t *testing.T) {
+	t.Parallel()
+
+	docs := []*goquery.Document{
+		hashedArticle(t, 1, "Transit plan approved", "Jane Doe"),
+		hashedArticle(t, 2, "New arena opens downtown", "John Smith"),
+		hashedArticle(t, 3, "Snow clearing budget rises", "Jane Doe"),
+	}
+
+	result := generator.NewStructuralInference(docs).InferAll()
+
+	assert.Equal(t, []string{"[data-testid='story'] h1"}, result.Title.Selectors)
+	assert.InDelta(t, 0.80, result.Title.Confidence, 0.001)
+	assert.Equal(t, "transit plan approved", result.Title.SampleText)
+
+	assert.Equal(t, []string{"[data-testid='story'] span"}, result.Author.Selectors)
+	assert.Equal(t, []string{"[data-testid='story'] time"}, result.PublishedTime.Selectors)
+	assert.Equal(t, []string{"[data-testid='story'] div"}, result.Body.Selectors)
+
+	// Generated class names and per-article ids never make it into a selector
+	for _, candidate := range []generator.SelectorCandidate{result.Title, result.Body, result.Author} {
+		for _, alt := range candidate.Alternatives {
+			assert.NotContains(t, alt.Selector, "css-")
+			assert.NotContains(t, alt.Selector, "story-")
+		}
+	}
+}
+
+func TestStructuralInference_PrefersIDsAndRanksAlternatives(t *testing.T) {
+	t.Parallel()
+
+	page := `<html><head><title>%s</title></head><body>
+<article><h1 id="headline" class="headline">%s</h1><h1 class="headline">Related</h1></article>
+</body></html>`
+	var docs []*goquery.Document
+	for _, title := range []string{"First story", "Second story"} {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(fmt.Sprintf(page, title, title)))
+		require.NoError(t, err)
+		docs = append(docs, doc)
+	}
+
+	title := generator.NewStructuralInference(docs).InferAll().Title
+
+	assert.Equal(t, []string{"#headline"}, title.Selectors)
+	assert.InDelta(t, 1.0, title.Confidence, 0.001)
+	require.NotEmpty(t, title.Alternatives)
+	assert.Equal(t, "h1.headline", title.Alternatives[0].Selector)
+	assert.InDelta(t, 0.68, title.Alternatives[0].Confidence, 0.001)
+	for i := 1; i < len(title.Alternatives); i++ {
+		assert.GreaterOrEqual(t, title.Alternatives[i-1].Confidence, title.Alternatives[i].Confidence)
+	}
+}
+
+func TestSampleArticleURLs(t *testing.T) {
+	t.Parallel()
+
+	page := `<a href="/news/one">One</a><a href="/news/one#comments">One</a>
+<a href="https://other.example.com/news/x">Other</a><a href="/about">About</a>
+<a href="/news/two">Two</a><a href="/news/three">Three</a>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	require.NoError(t, err)
+
+	sd, err := generator.NewSelectorDiscovery(doc, "https://example.com/news")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"https://example.com/news/one", "https://example.com/news/two"},
+		sd.SampleArticleURLs(2))
+}
+
+func TestSampleArticleURLsFallsBackToTemplates(t *testing.T) {
+	t.Parallel()
+
+	page := `<a href="/about">About</a><a href="/contact">Contact</a>
+<a href="/local/2026/10/council-approves-new-budget">Budget</a>
+<a href="/local/2026/10/arena-opens-next-spring">Arena</a>
+<a href="/local/2026/10/wolves-win-home-opener">Wolves</a>
+<a href="/obituaries/4821">Obituary</a>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	require.NoError(t, err)
+
+	sd, err := generator.NewSelectorDiscovery(doc, "https://example.com/")
+	require.NoError(t, err)
+
+	urls := sd.SampleArticleURLs(3)
+	assert.Len(t, urls, 3)
+	assert.NotContains(t, urls, "https://example.com/about")
+	assert.NotContains(t, urls, "https://example.com/obituaries/4821", "the most frequent template comes first")
+}
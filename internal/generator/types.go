@@ -13,6 +13,16 @@ type SelectorCandidate struct {
 	Confidence float64
 	// SampleText contains the first 100 characters of extracted text for verification
 	SampleText string
+	// Alternatives are further selectors for the field, ranked by confidence
+	Alternatives []RankedSelector
+}
+
+// RankedSelector is a CSS selector with the confidence it earned.
+type RankedSelector struct {
+	// Selector is the CSS selector
+	Selector string
+	// Confidence is a score from 0.0 to 1.0
+	Confidence float64
 }
 
 // DiscoveryResult holds all discovered selectors for a source.
@@ -33,4 +43,8 @@ type DiscoveryResult struct {
 	Category SelectorCandidate
 	// Exclusions is a list of CSS selectors for elements to exclude
 	Exclusions []string
+	// ArticleCards selector candidates (for article cards on listing pages)
+	ArticleCards SelectorCandidate
+	// URLPatterns are the learned URL templates of article pages
+	URLPatterns []URLPattern
 }
@@ -0,0 +1,469 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/jonesrussell/gocrawl/internal/common/dateparse"
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/content/articles"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+)
+
+// Placeholders of URL path templates.
+const (
+	yearPlaceholder    = "{yyyy}"
+	monthPlaceholder   = "{mm}"
+	dayPlaceholder     = "{dd}"
+	idPlaceholder      = "{id}"
+	slugPlaceholder    = "{slug}"
+	sectionPlaceholder = "{section}"
+)
+
+const (
+	// maxCardDepth is how far above an article link an article card is looked for
+	maxCardDepth = 4
+	// maxMonth and maxDay bound the numeric path segments taken for dates
+	maxMonth = 12
+	maxDay   = 31
+	// minSlugWords is the number of hyphenated words that make a path segment a slug
+	minSlugWords = 3
+	// ruleAction is the action of the rules emitted for article URL patterns
+	ruleAction = "allow"
+)
+
+var (
+	// yearPattern matches path segments that are years.
+	yearPattern = regexp.MustCompile(`^(19|20)\d{2}$`)
+	// numberPattern matches numeric path segments.
+	numberPattern = regexp.MustCompile(`^\d+$`)
+	// placeholderPatterns are the regular expressions placeholders stand for in rule patterns.
+	placeholderPatterns = map[string]string{
+		yearPlaceholder:    `\d{4}`,
+		monthPlaceholder:   `\d{1,2}`,
+		dayPlaceholder:     `\d{1,2}`,
+		idPlaceholder:      `\d+`,
+		slugPlaceholder:    `[^/]+`,
+		sectionPlaceholder: `[^/]+`,
+	}
+	// nonPageExtensions are the extensions of links that are not HTML pages.
+	nonPageExtensions = map[string]bool{
+		".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true, ".svg": true,
+		".pdf": true, ".xml": true, ".rss": true, ".css": true, ".js": true, ".zip": true, ".mp3": true, ".mp4": true,
+	}
+)
+
+// URLPattern is a cluster of the URLs of a source that share a path template.
+type URLPattern struct {
+	// Template is the path template, e.g. "/news/{section}/{yyyy}/{mm}/{slug}"
+	Template string
+	// Pattern is the regular expression matching the paths of the template
+	Pattern string
+	// URLs are the discovered URLs that follow the template
+	URLs []string
+	// Sampled is the number of pages of the template that were validated
+	Sampled int
+	// Valid is the number of sampled pages that passed article validation
+	Valid int
+	// Reason is why the first rejected sample failed validation
+	Reason string
+}
+
+// IsArticle reports whether most of the sampled pages of the template are articles.
+func (p URLPattern) IsArticle() bool {
+	return p.Sampled > 0 && p.Valid*2 > p.Sampled
+}
+
+// URLPatternLearner learns which URLs of a source lead to articles. It
+// clusters the links found on listing pages by path template, validates
+// sample pages of every template with the article validator, and keeps the
+// templates whose pages are articles.
+type URLPatternLearner struct {
+	base      *url.URL
+	listings  []*goquery.Document
+	links     []string
+	seen      map[string]bool
+	results   map[string]articles.ValidationResult
+	validator *articles.ArticleValidator
+}
+
+// NewURLPatternLearner creates a URLPatternLearner for a source.
+func NewURLPatternLearner(sourceURL string) (*URLPatternLearner, error) {
+	base, err := url.Parse(sourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	return &URLPatternLearner{
+		base:      base,
+		seen:      make(map[string]bool),
+		results:   make(map[string]articles.ValidationResult),
+		validator: articles.NewArticleValidator(logger.NewNoOp()),
+	}, nil
+}
+
+// AddListing collects the links of a page that stay on the source's host.
+func (l *URLPatternLearner) AddListing(doc *goquery.Document) {
+	l.listings = append(l.listings, doc)
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		link := l.resolve(href)
+		if link == nil || l.seen[link.String()] {
+			return
+		}
+		l.seen[link.String()] = true
+		l.links = append(l.links, link.String())
+	})
+}
+
+// AddPage validates a fetched page as an article and collects its links, so
+// the pages one level below the listing add to the clusters.
+func (l *URLPatternLearner) AddPage(pageURL string, doc *goquery.Document) articles.ValidationResult {
+	result := l.validator.ValidateArticle(pageArticle(pageURL, doc))
+	l.results[pageURL] = result
+	l.AddListing(doc)
+	return result
+}
+
+// Patterns clusters the collected links by path template, largest cluster first.
+func (l *URLPatternLearner) Patterns() []URLPattern {
+	templates := make(map[string][]string, len(l.links))
+	byShape := make(map[string][]string)
+	for _, link := range l.links {
+		u, err := url.Parse(link)
+		if err != nil {
+			continue
+		}
+		segments := templateSegments(u.Path)
+		if len(segments) == 0 {
+			continue
+		}
+		key := strings.Join(segments, "/")
+		if _, ok := templates[key]; !ok {
+			shape := shapeKey(segments)
+			byShape[shape] = append(byShape[shape], key)
+		}
+		templates[key] = append(templates[key], link)
+	}
+
+	clusters := make(map[string]*URLPattern)
+	var order []string
+	for _, keys := range byShape {
+		for _, key := range keys {
+			template := mergeSections(key, keys)
+			cluster, ok := clusters[template]
+			if !ok {
+				cluster = &URLPattern{Template: template, Pattern: templatePattern(template)}
+				clusters[template] = cluster
+				order = append(order, template)
+			}
+			cluster.URLs = append(cluster.URLs, templates[key]...)
+		}
+	}
+
+	patterns := make([]URLPattern, 0, len(order))
+	for _, template := range order {
+		cluster := clusters[template]
+		sort.Strings(cluster.URLs)
+		for _, link := range cluster.URLs {
+			result, sampled := l.results[link]
+			if !sampled {
+				continue
+			}
+			cluster.Sampled++
+			if result.IsValid {
+				cluster.Valid++
+			} else if cluster.Reason == "" {
+				cluster.Reason = result.Reason
+			}
+		}
+		patterns = append(patterns, *cluster)
+	}
+	sort.SliceStable(patterns, func(i, j int) bool {
+		if len(patterns[i].URLs) != len(patterns[j].URLs) {
+			return len(patterns[i].URLs) > len(patterns[j].URLs)
+		}
+		return patterns[i].Template < patterns[j].Template
+	})
+
+	return patterns
+}
+
+// ArticlePatterns returns the templates whose sampled pages are articles.
+func (l *URLPatternLearner) ArticlePatterns() []URLPattern {
+	var found []URLPattern
+	for _, pattern := range l.Patterns() {
+		if pattern.IsArticle() {
+			found = append(found, pattern)
+		}
+	}
+	return found
+}
+
+// ArticleCards infers the selector of the article cards of the listing pages:
+// the repeated element around each link to an article URL.
+func (l *URLPatternLearner) ArticleCards(patterns []URLPattern) SelectorCandidate {
+	candidate := SelectorCandidate{Field: "article_cards"}
+	if len(patterns) == 0 {
+		return candidate
+	}
+	matchers := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		matchers = append(matchers, regexp.MustCompile(pattern.Pattern))
+	}
+	isArticleLink := func(s *goquery.Selection) string {
+		href, _ := s.Attr("href")
+		link := l.resolve(href)
+		if link == nil {
+			return ""
+		}
+		for _, matcher := range matchers {
+			if matcher.MatchString(link.Path) {
+				return link.String()
+			}
+		}
+		return ""
+	}
+
+	// Count how many article links each candidate selector wraps
+	covered := make(map[string]int)
+	weights := make(map[string]float64)
+	var selectors []string
+	var total int
+	for _, doc := range l.listings {
+		doc.Find("a[href]").Each(func(_ int, link *goquery.Selection) {
+			if isArticleLink(link) == "" {
+				return
+			}
+			total++
+			seen := make(map[string]bool)
+			ancestor := link.Parent()
+			for depth := 0; depth < maxCardDepth && ancestor.Length() > 0; depth++ {
+				tag := goquery.NodeName(ancestor)
+				if tag == "body" || tag == "html" {
+					break
+				}
+				for _, scored := range candidateSelectors(ancestor) {
+					// Ids name a single element, never a repeated card
+					if strings.HasPrefix(scored.selector, "#") || seen[scored.selector] {
+						continue
+					}
+					seen[scored.selector] = true
+					if _, ok := covered[scored.selector]; !ok {
+						selectors = append(selectors, scored.selector)
+						weights[scored.selector] = scored.weight
+					}
+					covered[scored.selector]++
+				}
+				ancestor = ancestor.Parent()
+			}
+		})
+	}
+	if total == 0 {
+		return candidate
+	}
+
+	ranked := make([]RankedSelector, 0, len(selectors))
+	samples := make(map[string]string, len(selectors))
+	for _, selector := range selectors {
+		// Precision: the share of matched elements holding an article link.
+		// Granularity: one card per article rather than one list for all of them.
+		var cards, holding, articlesHeld int
+		for _, doc := range l.listings {
+			doc.Find(selector).Each(func(_ int, card *goquery.Selection) {
+				cards++
+				held := make(map[string]bool)
+				card.Find("a[href]").Each(func(_ int, link *goquery.Selection) {
+					if article := isArticleLink(link); article != "" {
+						held[article] = true
+					}
+				})
+				if len(held) == 0 {
+					return
+				}
+				holding++
+				articlesHeld += len(held)
+				if _, ok := samples[selector]; !ok {
+					samples[selector] = truncateText(normalizeText(card.Text()), sampleTextLength)
+				}
+			})
+		}
+		if holding == 0 {
+			continue
+		}
+		coverage := float64(covered[selector]) / float64(total)
+		precision := float64(holding) / float64(cards)
+		granularity := float64(holding) / float64(articlesHeld)
+		ranked = append(ranked, RankedSelector{
+			Selector:   selector,
+			Confidence: math.Round(coverage*precision*granularity*weights[selector]*100) / 100,
+		})
+	}
+	if len(ranked) == 0 {
+		return candidate
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Confidence > ranked[j].Confidence })
+
+	candidate.Selectors = []string{ranked[0].Selector}
+	candidate.Confidence = ranked[0].Confidence
+	candidate.SampleText = samples[ranked[0].Selector]
+	ranked = ranked[1:]
+	if len(ranked) > maxAlternatives {
+		ranked = ranked[:maxAlternatives]
+	}
+	candidate.Alternatives = ranked
+
+	return candidate
+}
+
+// ArticleRules turns article URL patterns into allow rules, the best supported first.
+func ArticleRules(patterns []URLPattern) configtypes.Rules {
+	rules := make(configtypes.Rules, 0, len(patterns))
+	for i, pattern := range patterns {
+		rules = append(rules, configtypes.Rule{
+			Pattern:  pattern.Pattern,
+			Action:   ruleAction,
+			Priority: len(patterns) - i,
+		})
+	}
+	return rules
+}
+
+// resolve resolves a link against the source URL and returns it without query
+// or fragment, or nil when it leaves the source's host or is not a page.
+func (l *URLPatternLearner) resolve(href string) *url.URL {
+	if href == "" || strings.HasPrefix(href, "#") {
+		return nil
+	}
+	link, err := l.base.Parse(href)
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") {
+		return nil
+	}
+	if !strings.EqualFold(link.Hostname(), l.base.Hostname()) {
+		return nil
+	}
+	if nonPageExtensions[strings.ToLower(path.Ext(link.Path))] {
+		return nil
+	}
+	link.RawQuery = ""
+	link.Fragment = ""
+	return link
+}
+
+// templateSegments turns the segments of a URL path into template segments:
+// dates, numeric ids and slugs become placeholders, other segments stay as they are.
+func templateSegments(urlPath string) []string {
+	var segments []string
+	for _, segment := range strings.Split(urlPath, "/") {
+		if segment == "" {
+			continue
+		}
+		previous := ""
+		if len(segments) > 0 {
+			previous = segments[len(segments)-1]
+		}
+		segments = append(segments, templateSegment(segment, previous))
+	}
+	return segments
+}
+
+// templateSegment returns the placeholder a path segment stands for, or the segment itself.
+func templateSegment(segment, previous string) string {
+	if yearPattern.MatchString(segment) {
+		return yearPlaceholder
+	}
+	if numberPattern.MatchString(segment) {
+		number, _ := strconv.Atoi(segment)
+		switch {
+		case previous == yearPlaceholder && number >= 1 && number <= maxMonth:
+			return monthPlaceholder
+		case previous == monthPlaceholder && number >= 1 && number <= maxDay:
+			return dayPlaceholder
+		default:
+			return idPlaceholder
+		}
+	}
+
+	stem := strings.TrimSuffix(segment, path.Ext(segment))
+	words := strings.FieldsFunc(stem, func(r rune) bool { return r == '-' || r == '_' })
+	if len(words) >= minSlugWords || digitRunPattern.MatchString(stem) {
+		return slugPlaceholder
+	}
+	return segment
+}
+
+// shapeKey groups templates that differ only in the sections below their first segment.
+func shapeKey(segments []string) string {
+	shape := make([]string, len(segments))
+	for i, segment := range segments {
+		if i > 0 && !strings.HasPrefix(segment, "{") {
+			segment = "*"
+		}
+		shape[i] = segment
+	}
+	return strings.Join(shape, "/")
+}
+
+// mergeSections replaces the segments of a template that vary among the
+// templates of its shape with the section placeholder. The first segment is
+// kept, as it usually tells articles from tags, authors and the like.
+func mergeSections(key string, shapeKeys []string) string {
+	segments := strings.Split(key, "/")
+	for i := 1; i < len(segments); i++ {
+		if strings.HasPrefix(segments[i], "{") {
+			continue
+		}
+		for _, other := range shapeKeys {
+			if strings.Split(other, "/")[i] != segments[i] {
+				segments[i] = sectionPlaceholder
+				break
+			}
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// templatePattern returns the regular expression matching the paths of a template.
+func templatePattern(template string) string {
+	segments := strings.Split(strings.TrimPrefix(template, "/"), "/")
+	for i, segment := range segments {
+		if expr, ok := placeholderPatterns[segment]; ok {
+			segments[i] = expr
+		} else {
+			segments[i] = regexp.QuoteMeta(segment)
+		}
+	}
+	return "^/" + strings.Join(segments, "/") + "/?$"
+}
+
+// pageArticle extracts the fields the article validator checks from a page.
+func pageArticle(pageURL string, doc *goquery.Document) *domain.Article {
+	article := &domain.Article{Source: pageURL}
+	if title := locateTitle(doc); title != nil {
+		article.Title = strings.Join(strings.Fields(title.Text()), " ")
+	}
+	if body := locateBody(doc); body != nil {
+		article.Body = strings.TrimSpace(body.Text())
+	}
+	for _, sel := range []string{"meta[property='article:published_time']", "meta[itemprop='datePublished']"} {
+		if content, ok := doc.Find(sel).First().Attr("content"); ok {
+			article.PublishedDate = dateparse.Parse(content)
+			break
+		}
+	}
+	if published := locatePublishedTime(doc); article.PublishedDate.IsZero() && published != nil {
+		value, ok := published.Attr("datetime")
+		if !ok {
+			value = published.Text()
+		}
+		article.PublishedDate = dateparse.Parse(strings.TrimSpace(value))
+	}
+	return article
+}
@@ -0,0 +1,90 @@
+package generator_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/jonesrussell/gocrawl/internal/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const learnHomepage = `<html><body>
+<nav><a href="/news/local/">Local</a><a href="/news/sports/">Sports</a><a href="/tag/transit">Transit</a></nav>
+<ul class="stories">
+  <li class="story-card"><a href="/news/local/2026/03/transit-plan-approved-by-council"><img src="/a.jpg"></a>
+    <h3><a href="/news/local/2026/03/transit-plan-approved-by-council">Transit plan approved</a></h3></li>
+  <li class="story-card"><h3><a href="/news/sports/2026/03/wolves-win-the-opening-game">Wolves win</a></h3></li>
+  <li class="story-card"><h3><a href="/news/local/2026/02/snow-clearing-budget-rises">Snow budget</a></h3></li>
+  <li class="ad"><a href="https://ads.example.net/click">Sponsored</a></li>
+</ul>
+</body></html>`
+
+func parseDocument(t *testing.T, page string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	require.NoError(t, err)
+	return doc
+}
+
+func learnArticle(t *testing.T, title string) *goquery.Document {
+	t.Helper()
+	paragraph := strings.Repeat("Council voted on the new transit plan for the downtown core. ", 5)
+	return parseDocument(t, fmt.Sprintf(`<html><head><title>%[1]s</title>
+<meta property="article:published_time" content="2026-03-02T10:00:00Z"></head>
+<body><article><h1>%[1]s</h1><div class="body"><p>%[2]s</p><p>%[2]s</p></div></article></body></html>`,
+		title, paragraph))
+}
+
+func TestURLPatternLearner(t *testing.T) {
+	t.Parallel()
+
+	learner, err := generator.NewURLPatternLearner("https://www.example.com/")
+	require.NoError(t, err)
+	learner.AddListing(parseDocument(t, learnHomepage))
+
+	templates := make(map[string]int)
+	for _, pattern := range learner.Patterns() {
+		templates[pattern.Template] = len(pattern.URLs)
+	}
+	assert.Equal(t, map[string]int{
+		"/news/{section}/{yyyy}/{mm}/{slug}": 3,
+		"/news/{section}":                    2,
+		"/tag/transit":                       1,
+	}, templates)
+
+	section := parseDocument(t, `<html><head><title>Local</title></head><body><h1>Local news</h1></body></html>`)
+	learner.AddPage("https://www.example.com/news/local/", section)
+	learner.AddPage("https://www.example.com/news/local/2026/03/transit-plan-approved-by-council",
+		learnArticle(t, "Transit plan approved"))
+	learner.AddPage("https://www.example.com/news/sports/2026/03/wolves-win-the-opening-game",
+		learnArticle(t, "Wolves win the opening game"))
+
+	patterns := learner.ArticlePatterns()
+	require.Len(t, patterns, 1)
+	assert.Equal(t, "/news/{section}/{yyyy}/{mm}/{slug}", patterns[0].Template)
+	assert.Equal(t, 2, patterns[0].Sampled)
+	assert.Equal(t, 2, patterns[0].Valid)
+	assert.Regexp(t, patterns[0].Pattern, "/news/arts/2025/12/gallery-opens-new-wing")
+	assert.NotRegexp(t, patterns[0].Pattern, "/news/local/")
+
+	rules := generator.ArticleRules(patterns)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "allow", rules[0].Action)
+	require.NoError(t, rules.Validate())
+
+	cards := learner.ArticleCards(patterns)
+	assert.Equal(t, []string{"li.story-card"}, cards.Selectors)
+	assert.InDelta(t, 0.85, cards.Confidence, 0.001)
+
+	yaml, err := generator.GenerateSourceYAML("https://www.example.com/", generator.DiscoveryResult{
+		ArticleCards: cards,
+		URLPatterns:  patterns,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, yaml, "      list:\n        article_cards: \"li.story-card\"")
+	assert.Contains(t, yaml, "    rules:\n      # Template: /news/{section}/{yyyy}/{mm}/{slug} (2/2 sampled pages are articles)\n")
+	assert.Contains(t, yaml, `      - pattern: "^/news/[^/]+/\\d{4}/\\d{1,2}/[^/]+/?$"`)
+}
@@ -22,6 +22,8 @@ func GenerateSourceYAML(
 	writeYAMLHeader(&builder, parsedURL, sourceURL)
 	writeYAMLSelectors(&builder, result)
 	writeYAMLExclusions(&builder, result)
+	writeYAMLList(&builder, result)
+	writeYAMLRules(&builder, result)
 
 	return builder.String(), nil
 }
@@ -88,6 +90,9 @@ func writeSelectorField(builder *strings.Builder, fieldName string, candidate Se
 		builder.WriteString(escapeYAMLString(candidate.SampleText))
 		builder.WriteString("\"\n")
 	}
+	for _, alt := range candidate.Alternatives {
+		fmt.Fprintf(builder, "        # Alternative (%.2f): %s\n", alt.Confidence, alt.Selector)
+	}
 }
 
 // writeYAMLExclusions writes the exclusions section.
@@ -105,6 +110,38 @@ func writeYAMLExclusions(builder *strings.Builder, result DiscoveryResult) {
 	builder.WriteString("        ]\n")
 }
 
+// writeYAMLList writes the list selectors section.
+func writeYAMLList(builder *strings.Builder, result DiscoveryResult) {
+	if len(result.ArticleCards.Selectors) == 0 {
+		return
+	}
+
+	builder.WriteString("      list:\n")
+	writeSelectorField(builder, "article_cards", result.ArticleCards)
+}
+
+// writeYAMLRules writes an allow rule for every learned article URL pattern.
+// Links no rule matches are still followed, so the rules only narrow the crawl
+// once disallow rules are added below them.
+func writeYAMLRules(builder *strings.Builder, result DiscoveryResult) {
+	if len(result.URLPatterns) == 0 {
+		return
+	}
+
+	builder.WriteString("    # Links matching no rule are followed; add lower priority disallow rules to skip them\n")
+	builder.WriteString("    rules:\n")
+	for i, rule := range ArticleRules(result.URLPatterns) {
+		pattern := result.URLPatterns[i]
+		fmt.Fprintf(builder, "      # Template: %s (%d/%d sampled pages are articles)\n",
+			pattern.Template, pattern.Valid, pattern.Sampled)
+		builder.WriteString("      - pattern: \"")
+		builder.WriteString(escapeYAMLString(rule.Pattern))
+		builder.WriteString("\"\n")
+		fmt.Fprintf(builder, "        action: %s\n", rule.Action)
+		fmt.Fprintf(builder, "        priority: %d\n", rule.Priority)
+	}
+}
+
 // generateSourceName converts a hostname to a title case source name.
 // Example: "www.example.com" -> "Example Com"
 func generateSourceName(hostname string) string {
@@ -129,6 +129,11 @@ func (s *Scheduler) runJobs(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
+			if !sourcesList[i].IsEnabled(time.Now()) {
+				s.logger.Info("Skipping disabled source",
+					"source", sourcesList[i].Name, "reason", sourcesList[i].DisabledReason)
+				continue
+			}
 			s.logger.Info("Starting crawler for source", "source", sourcesList[i].Name)
 			if err := s.crawler.Start(ctx, sourcesList[i].Name); err != nil {
 				s.logger.Error("Failed to start crawler for source", "source", sourcesList[i].Name, "error", err)
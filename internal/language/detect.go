@@ -0,0 +1,202 @@
+// Package language detects the language of extracted text and maps languages
+// to the Elasticsearch analyzers used for their sub-fields.
+//
+// Detection uses a naive Bayes classifier over character 1- to 3-grams, trained
+// at start-up on the news text embedded in corpus/. Support for another language
+// is added by placing a corpus file named after its ISO 639-1 code there and
+// registering its analyzer in Analyzers.
+package language
+
+import (
+	"embed"
+	"math"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Supported languages.
+const (
+	English = "en"
+	French  = "fr"
+)
+
+const (
+	// MinConfidence is the probability above which a detection is trusted for documents.
+	MinConfidence = 0.9
+	// maxGramLength is the longest n-gram used by the model.
+	maxGramLength = 3
+	// maxWords is the number of words of a text that are classified.
+	maxWords = 1000
+)
+
+//go:embed corpus/*.txt
+var corpora embed.FS
+
+// model holds the n-gram log-probabilities of each language.
+type model struct {
+	languages []string
+	// logProb maps an n-gram to its log-probability in each language, in the order of languages
+	logProb map[string][]float64
+	// unseen is the log-probability of an n-gram missing from each language's corpus
+	unseen []float64
+}
+
+var (
+	trained     *model
+	trainedOnce sync.Once
+)
+
+// Detect returns the language of a text and the probability that the detection
+// is right. It returns "" and 0 when the text contains no letters.
+func Detect(text string) (string, float64) {
+	m := getModel()
+
+	grams := ngrams(text, maxWords)
+	if len(grams) == 0 {
+		return "", 0
+	}
+
+	scores := make([]float64, len(m.languages))
+	for _, gram := range grams {
+		probs, known := m.logProb[gram]
+		for i := range scores {
+			if known {
+				scores[i] += probs[i]
+			} else {
+				scores[i] += m.unseen[i]
+			}
+		}
+	}
+
+	best := 0
+	for i := range scores {
+		if scores[i] > scores[best] {
+			best = i
+		}
+	}
+
+	// Softmax of the log-likelihoods gives the posterior with equal priors
+	var total float64
+	for i := range scores {
+		total += math.Exp(scores[i] - scores[best])
+	}
+	return m.languages[best], 1 / total
+}
+
+// DetectConfident returns the language of a text, or "" when the detection is
+// less certain than MinConfidence.
+func DetectConfident(text string) string {
+	lang, confidence := Detect(text)
+	if confidence < MinConfidence {
+		return ""
+	}
+	return lang
+}
+
+// DetectDocument returns the language of a document's text, falling back to the
+// language of the source's locale when the detection is uncertain.
+func DetectDocument(locale string, texts ...string) string {
+	if lang := DetectConfident(strings.Join(texts, "\n")); lang != "" {
+		return lang
+	}
+	return FromLocale(locale)
+}
+
+// FromLocale returns the supported language of a locale such as "fr-CA", or "".
+func FromLocale(locale string) string {
+	lang, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(locale, "_", "-")), "-")
+	if !IsSupported(lang) {
+		return ""
+	}
+	return lang
+}
+
+// IsSupported reports whether a language has a model and an analyzer.
+func IsSupported(lang string) bool {
+	_, ok := Analyzers[lang]
+	return ok && slices.Contains(getModel().languages, lang)
+}
+
+// getModel trains the model on first use.
+func getModel() *model {
+	trainedOnce.Do(func() {
+		trained = train()
+	})
+	return trained
+}
+
+// train builds the model from the embedded corpora with add-one smoothing.
+func train() *model {
+	entries, err := corpora.ReadDir("corpus")
+	if err != nil {
+		panic("language: corpus not embedded: " + err.Error())
+	}
+
+	m := &model{logProb: make(map[string][]float64)}
+	var counts []map[string]int
+	var totals []int
+	for _, entry := range entries {
+		data, readErr := corpora.ReadFile(path.Join("corpus", entry.Name()))
+		if readErr != nil {
+			panic("language: corpus not readable: " + readErr.Error())
+		}
+
+		count := make(map[string]int)
+		grams := ngrams(string(data), -1)
+		for _, gram := range grams {
+			count[gram]++
+		}
+		m.languages = append(m.languages, strings.TrimSuffix(entry.Name(), ".txt"))
+		counts = append(counts, count)
+		totals = append(totals, len(grams))
+	}
+
+	vocabulary := make(map[string]bool)
+	for _, count := range counts {
+		for gram := range count {
+			vocabulary[gram] = true
+		}
+	}
+
+	m.unseen = make([]float64, len(m.languages))
+	for i := range m.languages {
+		m.unseen[i] = math.Log(1 / float64(totals[i]+len(vocabulary)))
+	}
+	for gram := range vocabulary {
+		probs := make([]float64, len(m.languages))
+		for i, count := range counts {
+			probs[i] = math.Log(float64(count[gram]+1) / float64(totals[i]+len(vocabulary)))
+		}
+		m.logProb[gram] = probs
+	}
+	return m
+}
+
+// ngrams returns the 1- to 3-grams of the lowercased words of a text. Words are
+// padded with spaces so that prefixes and suffixes form their own n-grams.
+// A negative limit uses all words.
+func ngrams(text string, limit int) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if limit >= 0 && len(words) > limit {
+		words = words[:limit]
+	}
+
+	var grams []string
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for n := 1; n <= maxGramLength; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				gram := string(runes[i : i+n])
+				if gram != " " {
+					grams = append(grams, gram)
+				}
+			}
+		}
+	}
+	return grams
+}
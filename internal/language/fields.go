@@ -0,0 +1,92 @@
+package language
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrUnsupported is returned when a search asks for a language without an analyzer.
+var ErrUnsupported = errors.New("unsupported language")
+
+// Analyzers maps each supported language to the built-in Elasticsearch
+// analyzer used for its sub-fields.
+var Analyzers = map[string]string{
+	English: "english",
+	French:  "french",
+}
+
+// TextMapping returns the mapping of a full-text field with one sub-field per
+// supported language, e.g. title.en and title.fr.
+func TextMapping() map[string]any {
+	fields := make(map[string]any, len(Analyzers))
+	for lang, analyzer := range Analyzers {
+		fields[lang] = map[string]any{
+			"type":     "text",
+			"analyzer": analyzer,
+		}
+	}
+	return map[string]any{
+		"type":   "text",
+		"fields": fields,
+	}
+}
+
+// SearchFields returns the fields to query for a language. Fields may carry a
+// boost such as "title^2". The base fields are always searched; for a supported
+// language its sub-fields are added and for an empty one the sub-fields of all
+// languages are. Any other language returns ErrUnsupported.
+func SearchFields(lang string, fields ...string) ([]string, error) {
+	languages := []string{lang}
+	switch {
+	case lang == "":
+		languages = supported()
+	case !IsSupported(lang):
+		return nil, fmt.Errorf("%w %q, expected one of %s", ErrUnsupported, lang, strings.Join(supported(), ", "))
+	}
+
+	result := make([]string, 0, len(fields)*(len(languages)+1))
+	for _, field := range fields {
+		result = append(result, field)
+		name, boost, boosted := strings.Cut(field, "^")
+		for _, l := range languages {
+			subField := name + "." + l
+			if boosted {
+				subField += "^" + boost
+			}
+			result = append(result, subField)
+		}
+	}
+	return result, nil
+}
+
+// supported returns the supported languages in order.
+func supported() []string {
+	languages := make([]string, 0, len(Analyzers))
+	for lang := range Analyzers {
+		if IsSupported(lang) {
+			languages = append(languages, lang)
+		}
+	}
+	slices.Sort(languages)
+	return languages
+}
+
+// MatchQuery returns a multi_match clause for text in a language. When lang is
+// empty the language is detected from the text, falling back to all languages.
+func MatchQuery(text, lang string, fields ...string) (map[string]any, error) {
+	if lang == "" {
+		lang = DetectConfident(text)
+	}
+	searchFields, err := SearchFields(strings.ToLower(lang), fields...)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"multi_match": map[string]any{
+			"query":  text,
+			"fields": searchFields,
+		},
+	}, nil
+}
@@ -0,0 +1,77 @@
+package language_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/gocrawl/internal/language"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Firefighters battled a blaze at a warehouse near the harbour for most of the night.", language.English},
+		{"Les pompiers ont combattu un incendie dans un entrepôt près du port pendant la nuit.", language.French},
+		{"The school board will vote on the new calendar next month", language.English},
+		{"La commission scolaire votera sur le nouveau calendrier le mois prochain", language.French},
+		{"élections municipales", language.French},
+		{"weather forecast", language.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			t.Parallel()
+			got, confidence := language.Detect(tt.text)
+			assert.Equal(t, tt.want, got)
+			assert.Greater(t, confidence, 0.5)
+		})
+	}
+}
+
+func TestDetectWithoutLetters(t *testing.T) {
+	t.Parallel()
+
+	lang, confidence := language.Detect("2026 — 14:30 !!")
+	assert.Empty(t, lang)
+	assert.Zero(t, confidence)
+	assert.Empty(t, language.DetectConfident(""))
+}
+
+func TestFromLocale(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, language.French, language.FromLocale("fr-CA"))
+	assert.Equal(t, language.English, language.FromLocale("en_US"))
+	assert.Empty(t, language.FromLocale("de-DE"))
+	assert.Empty(t, language.FromLocale(""))
+}
+
+func TestSearchFields(t *testing.T) {
+	t.Parallel()
+
+	fields, err := language.SearchFields(language.French, "title^2", "body")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"title^2", "title.fr^2", "body", "body.fr"}, fields)
+
+	fields, err = language.SearchFields("", "title")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"title", "title.en", "title.fr"}, fields)
+
+	_, err = language.SearchFields("de", "title")
+	require.ErrorIs(t, err, language.ErrUnsupported)
+}
+
+func TestMatchQueryDetectsLanguage(t *testing.T) {
+	t.Parallel()
+
+	query, err := language.MatchQuery("les travaux du pont reprendront après les élections", "", "body")
+	require.NoError(t, err)
+	clause, ok := query["multi_match"].(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"body", "body.fr"}, clause["fields"])
+}
@@ -0,0 +1,155 @@
+package linkgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+// scrollBatchSize is the number of links fetched per page when loading a graph.
+const scrollBatchSize = 1000
+
+// Target is a page and the number of distinct pages linking to it.
+type Target struct {
+	// URL is the canonical URL of the page
+	URL string
+	// Inlinks is the number of pages linking to it
+	Inlinks int64
+}
+
+// Graph queries the links stored in an index.
+type Graph struct {
+	storage types.Interface
+	index   string
+}
+
+// NewGraph creates a graph backed by the given links index.
+func NewGraph(storage types.Interface, index string) *Graph {
+	return &Graph{storage: storage, index: index}
+}
+
+// TopLinked returns the pages of a source with the most internal inlinks, most linked first.
+func (g *Graph) TopLinked(ctx context.Context, source string, limit int) ([]Target, error) {
+	result, err := g.storage.Aggregate(ctx, g.index, map[string]any{
+		"links": map[string]any{
+			"filter": map[string]any{"bool": map[string]any{"filter": sourceFilters(source, time.Time{})}},
+			"aggs": map[string]any{
+				"targets": map[string]any{
+					"terms": map[string]any{"field": "to_url", "size": limit},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate links: %w", err)
+	}
+
+	var parsed struct {
+		Links struct {
+			Targets struct {
+				Buckets []struct {
+					Key      string `json:"key"`
+					DocCount int64  `json:"doc_count"`
+				} `json:"buckets"`
+			} `json:"targets"`
+		} `json:"links"`
+	}
+	if decodeErr := convert(result, &parsed); decodeErr != nil {
+		return nil, fmt.Errorf("failed to parse link aggregation: %w", decodeErr)
+	}
+
+	targets := make([]Target, 0, len(parsed.Links.Targets.Buckets))
+	for _, bucket := range parsed.Links.Targets.Buckets {
+		targets = append(targets, Target{URL: bucket.Key, Inlinks: bucket.DocCount})
+	}
+	return targets, nil
+}
+
+// Links returns the internal links of a source last seen at or after since.
+// A zero since returns all of them.
+func (g *Graph) Links(ctx context.Context, source string, since time.Time) ([]Link, error) {
+	query := map[string]any{
+		"query":   map[string]any{"bool": map[string]any{"filter": sourceFilters(source, since)}},
+		"_source": []string{"from_url", "to_url", "nofollow"},
+	}
+
+	var links []Link
+	err := g.storage.ScrollDocuments(ctx, g.index, query, scrollBatchSize, func(hits []any) error {
+		for _, hit := range hits {
+			var parsed struct {
+				Source Link `json:"_source"`
+			}
+			if decodeErr := convert(hit, &parsed); decodeErr != nil {
+				return fmt.Errorf("failed to parse link: %w", decodeErr)
+			}
+			links = append(links, parsed.Source)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load links: %w", err)
+	}
+	return links, nil
+}
+
+// Orphans returns the crawled pages that cannot be reached from the homepage by
+// following links, sorted. A page counts as crawled when links were recorded on
+// it. With direct set, only links on the homepage itself are followed.
+func Orphans(links []Link, homepage string, direct bool) []string {
+	outlinks := make(map[string][]string)
+	for _, link := range links {
+		outlinks[link.FromURL] = append(outlinks[link.FromURL], link.ToURL)
+	}
+
+	reached := map[string]bool{homepage: true}
+	queue := []string{homepage}
+	for len(queue) > 0 {
+		page := queue[0]
+		queue = queue[1:]
+		for _, target := range outlinks[page] {
+			if reached[target] {
+				continue
+			}
+			reached[target] = true
+			if !direct {
+				queue = append(queue, target)
+			}
+		}
+	}
+
+	var orphans []string
+	for page := range outlinks {
+		if !reached[page] {
+			orphans = append(orphans, page)
+		}
+	}
+	slices.Sort(orphans)
+	return orphans
+}
+
+// sourceFilters returns the filters selecting a source's internal links.
+func sourceFilters(source string, since time.Time) []any {
+	filters := []any{
+		map[string]any{"term": map[string]any{"source": source}},
+		map[string]any{"term": map[string]any{"internal": true}},
+	}
+	if !since.IsZero() {
+		filters = append(filters, map[string]any{
+			"range": map[string]any{"last_seen": map[string]any{"gte": since.UTC().Format(time.RFC3339)}},
+		})
+	}
+	return filters
+}
+
+// convert decodes a generic JSON value into a typed value.
+func convert(value, target any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
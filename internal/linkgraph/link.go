@@ -0,0 +1,58 @@
+// Package linkgraph records the links between crawled pages and answers
+// questions about the resulting graph, such as which pages are linked to most
+// and which pages cannot be reached from a source's homepage.
+package linkgraph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Link is one hyperlink from a crawled page. Each pair of pages is stored once;
+// recrawling a page updates its links.
+type Link struct {
+	// Source is the name of the source the page was crawled for
+	Source string `json:"source"`
+	// FromURL is the canonical URL of the page containing the link
+	FromURL string `json:"from_url"`
+	// ToURL is the canonical URL the link points to
+	ToURL string `json:"to_url"`
+	// ToHost is the host of ToURL
+	ToHost string `json:"to_host"`
+	// AnchorText is the visible text of the link
+	AnchorText string `json:"anchor_text,omitempty"`
+	// NoFollow reports whether the link has rel=nofollow, ugc or sponsored
+	NoFollow bool `json:"nofollow"`
+	// Internal reports whether the link points to the source's own site
+	Internal bool `json:"internal"`
+	// Depth is the crawl depth of the page containing the link
+	Depth int `json:"depth"`
+	// LastSeen is when the link was last found on the page
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// ID returns the document ID of the link between two pages.
+func (l *Link) ID() string {
+	hash := sha256.Sum256([]byte(l.FromURL + "\n" + l.ToURL))
+	return hex.EncodeToString(hash[:])
+}
+
+// Mapping returns the Elasticsearch mapping of the links index.
+func Mapping() map[string]any {
+	return map[string]any{
+		"mappings": map[string]any{
+			"properties": map[string]any{
+				"source":      map[string]any{"type": "keyword"},
+				"from_url":    map[string]any{"type": "keyword"},
+				"to_url":      map[string]any{"type": "keyword"},
+				"to_host":     map[string]any{"type": "keyword"},
+				"anchor_text": map[string]any{"type": "text"},
+				"nofollow":    map[string]any{"type": "boolean"},
+				"internal":    map[string]any{"type": "boolean"},
+				"depth":       map[string]any{"type": "integer"},
+				"last_seen":   map[string]any{"type": "date"},
+			},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package linkgraph_test
+
+import (
+	"context"
+	"testing"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/linkgraph"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+	"github.com/jonesrussell/gocrawl/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const home = "https://example.com/"
+
+func link(from, to string) linkgraph.Link {
+	return linkgraph.Link{Source: "example", FromURL: from, ToURL: to, Internal: true}
+}
+
+func TestOrphans(t *testing.T) {
+	t.Parallel()
+
+	links := []linkgraph.Link{
+		link(home, "https://example.com/news"),
+		link("https://example.com/news", "https://example.com/news/budget"),
+		link("https://example.com/news/budget", "https://example.com/news/transit"),
+		link("https://example.com/news/transit", home),
+		link("https://example.com/archive/2019", "https://example.com/archive/2019/old-story"),
+		link("https://example.com/archive/2019/old-story", home),
+	}
+
+	assert.Equal(t, []string{
+		"https://example.com/archive/2019",
+		"https://example.com/archive/2019/old-story",
+	}, linkgraph.Orphans(links, home, false))
+
+	assert.Equal(t, []string{
+		"https://example.com/archive/2019",
+		"https://example.com/archive/2019/old-story",
+		"https://example.com/news/budget",
+		"https://example.com/news/transit",
+	}, linkgraph.Orphans(links, home, true))
+}
+
+func TestRecorderBatchesLinks(t *testing.T) {
+	t.Parallel()
+
+	stor, ok := testutils.NewMockStorage(logger.NewNoOp()).(*testutils.MockStorage)
+	require.True(t, ok)
+
+	cfg := crawlerconfig.NewLinksConfig()
+	cfg.BatchSize = 2
+
+	stor.On("IndexExists", mock.Anything, "links").Return(false, nil).Once()
+	stor.On("CreateIndex", mock.Anything, "links", linkgraph.Mapping()).Return(nil).Once()
+	stor.On("BulkIndex", mock.Anything, "links", mock.Anything).Return(nil)
+
+	recorder := linkgraph.NewRecorder(logger.NewNoOp(), stor, cfg)
+	ctx := context.Background()
+
+	first := link(home, "https://example.com/news")
+	recorder.Record(ctx, first)
+	recorder.Record(ctx, first)
+	recorder.Record(ctx, link(home, home))
+	stor.AssertNotCalled(t, "BulkIndex", mock.Anything, mock.Anything, mock.Anything)
+
+	recorder.Record(ctx, link(home, "https://example.com/sports"))
+	recorder.Record(ctx, link("https://example.com/news", "https://example.com/news/budget"))
+	require.NoError(t, recorder.Flush(ctx))
+	require.NoError(t, recorder.Flush(ctx))
+
+	stor.AssertNumberOfCalls(t, "BulkIndex", 2)
+	batch, batchOK := stor.Calls[2].Arguments.Get(2).([]types.BulkDocument)
+	require.True(t, batchOK)
+	assert.Len(t, batch, 2, "repeated links are stored once and self-links are dropped")
+
+	ids := []string{batch[0].ID, batch[1].ID}
+	assert.Contains(t, ids, first.ID())
+}
@@ -0,0 +1,102 @@
+package linkgraph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+// Recorder buffers links found while crawling and bulk indexes them.
+// It is safe for concurrent use.
+type Recorder struct {
+	logger  logger.Interface
+	storage types.Interface
+	config  crawlerconfig.LinksConfig
+
+	mu         sync.Mutex
+	buffer     map[string]Link
+	indexReady bool
+}
+
+// NewRecorder creates a new link recorder.
+func NewRecorder(log logger.Interface, storage types.Interface, cfg crawlerconfig.LinksConfig) *Recorder {
+	return &Recorder{
+		logger:  log,
+		storage: storage,
+		config:  cfg,
+		buffer:  make(map[string]Link),
+	}
+}
+
+// Record adds a link to the buffer, flushing it once it holds a full batch.
+// Links are best effort: a failed flush is logged and does not stop the crawl.
+func (r *Recorder) Record(ctx context.Context, link Link) {
+	if r == nil || link.FromURL == "" || link.ToURL == "" || link.FromURL == link.ToURL {
+		return
+	}
+
+	r.mu.Lock()
+	r.buffer[link.ID()] = link
+	full := len(r.buffer) >= r.config.BatchSize
+	r.mu.Unlock()
+
+	if full {
+		if err := r.Flush(ctx); err != nil {
+			r.logger.Warn("Failed to record links", "error", err)
+		}
+	}
+}
+
+// Flush indexes all buffered links.
+func (r *Recorder) Flush(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	if len(r.buffer) == 0 {
+		r.mu.Unlock()
+		return nil
+	}
+	documents := make([]types.BulkDocument, 0, len(r.buffer))
+	for id, link := range r.buffer {
+		documents = append(documents, types.BulkDocument{ID: id, Document: link})
+	}
+	r.buffer = make(map[string]Link)
+	r.mu.Unlock()
+
+	if err := r.ensureIndex(ctx); err != nil {
+		return err
+	}
+	if err := r.storage.BulkIndex(ctx, r.config.Index, documents); err != nil {
+		return fmt.Errorf("failed to index links: %w", err)
+	}
+
+	r.logger.Debug("Recorded links", "index", r.config.Index, "count", len(documents))
+	return nil
+}
+
+// ensureIndex creates the links index on first use.
+func (r *Recorder) ensureIndex(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.indexReady {
+		return nil
+	}
+
+	exists, err := r.storage.IndexExists(ctx, r.config.Index)
+	if err != nil {
+		return fmt.Errorf("failed to check links index: %w", err)
+	}
+	if !exists {
+		if createErr := r.storage.CreateIndex(ctx, r.config.Index, Mapping()); createErr != nil {
+			return fmt.Errorf("failed to create links index: %w", createErr)
+		}
+	}
+	r.indexReady = true
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package monitor watches the health of sources between crawls: it checks
+// their selectors against recently indexed articles, records the results as
+// a time series and raises alerts when they regress.
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/crawler/events"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+)
+
+// webhookTimeout bounds a webhook delivery.
+const webhookTimeout = 10 * time.Second
+
+// Alert is a problem found while monitoring a source.
+type Alert struct {
+	// Kind names the check that raised the alert, e.g. "selector_regression"
+	Kind string `json:"kind"`
+	// Source is the name of the source
+	Source string `json:"source"`
+	// Message describes the problem
+	Message string `json:"message"`
+	// Values are the measurements behind the alert, e.g. success rates by field
+	Values map[string]float64 `json:"values,omitempty"`
+	// Time is when the alert was raised
+	Time time.Time `json:"time"`
+}
+
+// Error returns the alert message, so alerts can travel the event bus as error events.
+func (a *Alert) Error() string {
+	return a.Message
+}
+
+// Alerter sends alerts somewhere people will see them.
+type Alerter interface {
+	// Alert sends an alert.
+	Alert(ctx context.Context, alert *Alert) error
+}
+
+// NewAlerter creates an alerter sending to every sink of the configuration.
+// The bus is only used by the events sink and may be nil otherwise.
+func NewAlerter(cfg crawlerconfig.AlertsConfig, log logger.Interface, bus *events.EventBus) (Alerter, error) {
+	var alerters multiAlerter
+	for _, sink := range cfg.Sinks {
+		switch sink {
+		case crawlerconfig.AlertSinkLog:
+			alerters = append(alerters, NewLogAlerter(log))
+		case crawlerconfig.AlertSinkWebhook:
+			alerters = append(alerters, NewWebhookAlerter(cfg.WebhookURL))
+		case crawlerconfig.AlertSinkEvents:
+			if bus == nil {
+				return nil, errors.New("the events alert sink needs an event bus")
+			}
+			alerters = append(alerters, NewEventAlerter(bus))
+		default:
+			return nil, fmt.Errorf("unknown alert sink: %s", sink)
+		}
+	}
+	return alerters, nil
+}
+
+// multiAlerter sends alerts to several alerters.
+type multiAlerter []Alerter
+
+// Alert sends the alert to every alerter, even when some of them fail.
+func (m multiAlerter) Alert(ctx context.Context, alert *Alert) error {
+	var errs []error
+	for _, alerter := range m {
+		if err := alerter.Alert(ctx, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LogAlerter writes alerts to the log.
+type LogAlerter struct {
+	logger logger.Interface
+}
+
+// NewLogAlerter creates an alerter writing to the log.
+func NewLogAlerter(log logger.Interface) *LogAlerter {
+	return &LogAlerter{logger: log}
+}
+
+// Alert logs the alert as a warning.
+func (a *LogAlerter) Alert(_ context.Context, alert *Alert) error {
+	a.logger.Warn("Source alert",
+		"kind", alert.Kind,
+		"source", alert.Source,
+		"message", alert.Message,
+		"values", alert.Values,
+	)
+	return nil
+}
+
+// WebhookAlerter posts alerts as JSON to a URL.
+type WebhookAlerter struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookAlerter creates an alerter posting to a webhook URL.
+func NewWebhookAlerter(url string) *WebhookAlerter {
+	return &WebhookAlerter{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Alert posts the alert to the webhook.
+func (a *WebhookAlerter) Alert(ctx context.Context, alert *Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// EventAlerter publishes alerts as error events on the crawler event bus.
+// Handlers can tell them from other errors with errors.As.
+type EventAlerter struct {
+	bus *events.EventBus
+}
+
+// NewEventAlerter creates an alerter publishing to an event bus.
+func NewEventAlerter(bus *events.EventBus) *EventAlerter {
+	return &EventAlerter{bus: bus}
+}
+
+// Alert publishes the alert.
+func (a *EventAlerter) Alert(ctx context.Context, alert *Alert) error {
+	a.bus.PublishError(ctx, alert)
+	return nil
+}
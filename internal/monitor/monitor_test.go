@@ -0,0 +1,146 @@
+package monitor_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/monitor"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/jonesrussell/gocrawl/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingAlerter keeps the alerts it is sent.
+type recordingAlerter struct {
+	alerts []*monitor.Alert
+}
+
+func (a *recordingAlerter) Alert(_ context.Context, alert *monitor.Alert) error {
+	a.alerts = append(a.alerts, alert)
+	return nil
+}
+
+// articleServer serves articles whose headline moved from h1.headline to h2
+// on every page but the first.
+func articleServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	body := strings.Repeat("Council voted on the new transit plan for the downtown core. ", 5)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headline := `<h2>Transit plan approved</h2>`
+		if r.URL.Path == "/news/1" {
+			headline = `<h1 class="headline">Transit plan approved</h1>`
+		}
+		_, _ = w.Write([]byte(`<html><body><article>` + headline +
+			`<div class="story-body"><p>` + body + `</p></div></article></body></html>`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func hit(articleURL string) map[string]any {
+	return map[string]any{"_source": map[string]any{"source": articleURL}}
+}
+
+func TestSelectorMonitorAlertsOnRegression(t *testing.T) {
+	t.Parallel()
+
+	server := articleServer(t)
+	stor, ok := testutils.NewMockStorage(logger.NewNoOp()).(*testutils.MockStorage)
+	require.True(t, ok)
+
+	cfg := crawlerconfig.NewMonitorConfig()
+	cfg.Samples = 3
+	// The article index is shared, so the search is limited to the source's URLs
+	ownArticles := mock.MatchedBy(func(query map[string]any) bool {
+		encoded, err := json.Marshal(query["query"])
+		return err == nil && strings.Contains(string(encoded), `"prefix":{"source":"`+server.URL+`/"}`)
+	})
+	stor.On("Search", mock.Anything, "sudbury_articles", ownArticles).Return([]any{
+		hit(server.URL + "/news/1"), hit(server.URL + "/news/2"), hit(server.URL + "/news/3"),
+	}, nil)
+	stor.On("IndexExists", mock.Anything, cfg.Index).Return(false, nil).Once()
+	stor.On("CreateIndex", mock.Anything, cfg.Index, monitor.SelectorHealthMapping()).Return(nil).Once()
+	stor.On("IndexDocument", mock.Anything, cfg.Index, mock.Anything, mock.Anything).Return(nil)
+
+	source := &sources.Config{Name: "Sudbury Star", URL: server.URL, ArticleIndex: "sudbury_articles", Enabled: true}
+	source.Selectors.Article.Title = "h1.headline"
+	source.Selectors.Article.Body = ".story-body"
+
+	alerter := &recordingAlerter{}
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	health, err := monitor.NewSelectorMonitor(logger.NewNoOp(), stor, cfg, alerter).Check(
+		context.Background(), source, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, health.Articles)
+	assert.InDelta(t, 100.0/3, health.SuccessRates["title"], 0.01)
+	assert.InDelta(t, 100.0, health.SuccessRates["body"], 0.01)
+	stor.AssertCalled(t, "IndexDocument", mock.Anything, cfg.Index, "Sudbury Star-1772445600", health)
+
+	require.Len(t, alerter.alerts, 1)
+	alert := alerter.alerts[0]
+	assert.Equal(t, monitor.AlertKindSelectorRegression, alert.Kind)
+	assert.Equal(t, "Sudbury Star", alert.Source)
+	assert.Contains(t, alert.Values, "title")
+	assert.NotContains(t, alert.Values, "body")
+	assert.Equal(t, "selectors of Sudbury Star regressed: title found in 33% of articles (threshold 80%)",
+		alert.Message)
+}
+
+func TestSelectorMonitorSkipsSourcesWithoutArticles(t *testing.T) {
+	t.Parallel()
+
+	stor, ok := testutils.NewMockStorage(logger.NewNoOp()).(*testutils.MockStorage)
+	require.True(t, ok)
+	stor.On("Search", mock.Anything, "empty_articles", mock.Anything).Return([]any{}, nil)
+
+	source := &sources.Config{
+		Name: "Empty", URL: "https://empty.example.com", ArticleIndex: "empty_articles", Enabled: true,
+	}
+	_, err := monitor.NewSelectorMonitor(logger.NewNoOp(), stor, crawlerconfig.NewMonitorConfig(),
+		&recordingAlerter{}).Check(context.Background(), source, time.Now())
+	require.ErrorIs(t, err, monitor.ErrNoRecentArticles)
+}
+
+func TestWebhookAlerter(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan monitor.Alert, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert monitor.Alert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received <- alert
+	}))
+	defer server.Close()
+
+	alerter, err := monitor.NewAlerter(crawlerconfig.AlertsConfig{
+		Sinks:      []string{crawlerconfig.AlertSinkLog, crawlerconfig.AlertSinkWebhook},
+		WebhookURL: server.URL,
+	}, logger.NewNoOp(), nil)
+	require.NoError(t, err)
+
+	require.NoError(t, alerter.Alert(context.Background(), &monitor.Alert{
+		Kind:    monitor.AlertKindSelectorRegression,
+		Source:  "Sudbury Star",
+		Message: "selectors of Sudbury Star regressed",
+		Values:  map[string]float64{"body": 20},
+	}))
+
+	alert := <-received
+	assert.Equal(t, "Sudbury Star", alert.Source)
+	assert.InDelta(t, 20.0, alert.Values["body"], 0.001)
+
+	_, err = monitor.NewAlerter(crawlerconfig.AlertsConfig{Sinks: []string{crawlerconfig.AlertSinkEvents}},
+		logger.NewNoOp(), nil)
+	require.Error(t, err)
+}
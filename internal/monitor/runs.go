@@ -0,0 +1,28 @@
+package monitor
+
+import (
+	"strings"
+
+	"github.com/jonesrussell/gocrawl/internal/runhistory"
+)
+
+// AlertKindCrawlAnomaly is the kind of the alerts raised when a crawl run deviates from its baseline.
+const AlertKindCrawlAnomaly = "crawl_anomaly"
+
+// NewRunAlert creates the alert for a crawl run that deviated from its baseline.
+func NewRunAlert(run *runhistory.Run) *Alert {
+	parts := make([]string, 0, len(run.Anomalies))
+	values := make(map[string]float64, len(run.Anomalies))
+	for _, anomaly := range run.Anomalies {
+		parts = append(parts, anomaly.String())
+		values[anomaly.Metric] = anomaly.Value
+	}
+
+	return &Alert{
+		Kind:    AlertKindCrawlAnomaly,
+		Source:  run.Source,
+		Message: "crawl of " + run.Source + " deviates from its baseline: " + strings.Join(parts, "; "),
+		Values:  values,
+		Time:    run.FinishedAt,
+	}
+}
@@ -0,0 +1,258 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/generator"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	sourcetypes "github.com/jonesrussell/gocrawl/internal/sources/types"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+// AlertKindSelectorRegression is the kind of the alerts raised when a source's selectors stop matching.
+const AlertKindSelectorRegression = "selector_regression"
+
+// ErrNoRecentArticles is returned when a source has no indexed articles to check its selectors against.
+var ErrNoRecentArticles = errors.New("no recently indexed articles")
+
+// SelectorHealth is one check of a source's selectors, stored as a point of
+// the selector health time series.
+type SelectorHealth struct {
+	// Source is the name of the source
+	Source string `json:"source"`
+	// CheckedAt is when the selectors were checked
+	CheckedAt time.Time `json:"checked_at"`
+	// Articles is the number of articles the selectors were checked against
+	Articles int `json:"articles"`
+	// SuccessfulArticles is the number of articles where title and body were both found
+	SuccessfulArticles int `json:"successful_articles"`
+	// SuccessRates maps each field with a selector to the percentage of articles it was found in
+	SuccessRates map[string]float64 `json:"success_rates"`
+}
+
+// ID returns the document ID of the check.
+func (h *SelectorHealth) ID() string {
+	return h.Source + "-" + strconv.FormatInt(h.CheckedAt.Unix(), 10)
+}
+
+// SelectorHealthMapping returns the Elasticsearch mapping of the selector health index.
+func SelectorHealthMapping() map[string]any {
+	return map[string]any{
+		"mappings": map[string]any{
+			"properties": map[string]any{
+				"source":              map[string]any{"type": "keyword"},
+				"checked_at":          map[string]any{"type": "date"},
+				"articles":            map[string]any{"type": "integer"},
+				"successful_articles": map[string]any{"type": "integer"},
+				"success_rates":       map[string]any{"type": "object", "dynamic": true},
+			},
+		},
+	}
+}
+
+// SelectorMonitor checks the selectors of sources against their most recently
+// indexed articles, stores the success rates and alerts when the title or body
+// success rate falls below its threshold.
+type SelectorMonitor struct {
+	logger  logger.Interface
+	storage types.Interface
+	config  crawlerconfig.MonitorConfig
+	alerter Alerter
+
+	mu         sync.Mutex
+	indexReady bool
+}
+
+// NewSelectorMonitor creates a new selector monitor.
+func NewSelectorMonitor(
+	log logger.Interface,
+	storage types.Interface,
+	cfg crawlerconfig.MonitorConfig,
+	alerter Alerter,
+) *SelectorMonitor {
+	return &SelectorMonitor{
+		logger:  log,
+		storage: storage,
+		config:  cfg,
+		alerter: alerter,
+	}
+}
+
+// Interval returns how often the selectors should be checked.
+func (m *SelectorMonitor) Interval() time.Duration {
+	return m.config.Interval
+}
+
+// CheckAll checks every enabled source. A source that cannot be checked is
+// logged and skipped.
+func (m *SelectorMonitor) CheckAll(ctx context.Context, configs []sources.Config, now time.Time) []SelectorHealth {
+	var results []SelectorHealth
+	for i := range configs {
+		source := &configs[i]
+		if !source.IsEnabled(now) {
+			continue
+		}
+		health, err := m.Check(ctx, source, now)
+		if err != nil {
+			m.logger.Warn("Failed to check selectors", "source", source.Name, "error", err)
+			continue
+		}
+		results = append(results, *health)
+	}
+	return results
+}
+
+// Check validates a source's selectors against its most recently indexed
+// articles, records the result and raises an alert on a regression.
+func (m *SelectorMonitor) Check(ctx context.Context, source *sources.Config, now time.Time) (*SelectorHealth, error) {
+	urls, err := m.recentArticleURLs(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if len(urls) == 0 {
+		return nil, ErrNoRecentArticles
+	}
+
+	selectors := sourcetypes.ConvertToConfigSource(source).Selectors.Article
+	result, err := generator.ValidateSelectors(selectors, urls, m.config.Samples)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate selectors: %w", err)
+	}
+
+	health := &SelectorHealth{
+		Source:             source.Name,
+		CheckedAt:          now.UTC(),
+		Articles:           result.TotalArticles,
+		SuccessfulArticles: result.SuccessfulArticles,
+		SuccessRates:       make(map[string]float64, len(result.FieldResults)),
+	}
+	for field, fieldResult := range result.FieldResults {
+		health.SuccessRates[field] = fieldResult.SuccessRate
+	}
+
+	if recordErr := m.record(ctx, health); recordErr != nil {
+		return nil, recordErr
+	}
+	m.alert(ctx, health)
+
+	return health, nil
+}
+
+// alert raises an alert when the title or body success rate is below its threshold.
+func (m *SelectorMonitor) alert(ctx context.Context, health *SelectorHealth) {
+	thresholds := map[string]float64{
+		"title": m.config.TitleThreshold,
+		"body":  m.config.BodyThreshold,
+	}
+
+	failing := make(map[string]float64)
+	for _, field := range []string{"title", "body"} {
+		rate, ok := health.SuccessRates[field]
+		if ok && rate < thresholds[field] {
+			failing[field] = rate
+		}
+	}
+	if len(failing) == 0 {
+		return
+	}
+
+	alert := &Alert{
+		Kind:    AlertKindSelectorRegression,
+		Source:  health.Source,
+		Message: selectorRegressionMessage(health.Source, failing, thresholds),
+		Values:  failing,
+		Time:    health.CheckedAt,
+	}
+	if err := m.alerter.Alert(ctx, alert); err != nil {
+		m.logger.Error("Failed to send alert", "source", health.Source, "error", err)
+	}
+}
+
+// selectorRegressionMessage describes the fields whose success rates fell below their thresholds.
+func selectorRegressionMessage(source string, failing, thresholds map[string]float64) string {
+	var parts []string
+	for _, field := range []string{"title", "body"} {
+		if rate, ok := failing[field]; ok {
+			parts = append(parts, fmt.Sprintf("%s found in %.0f%% of articles (threshold %.0f%%)",
+				field, rate, thresholds[field]))
+		}
+	}
+	return "selectors of " + source + " regressed: " + strings.Join(parts, "; ")
+}
+
+// recentArticleURLs returns the URLs of the source's most recently indexed articles.
+// Article indices may be shared, so only articles under the source's URLs are used.
+func (m *SelectorMonitor) recentArticleURLs(ctx context.Context, source *sources.Config) ([]string, error) {
+	if source.ArticleIndex == "" {
+		return nil, errors.New("source has no article index")
+	}
+	scope := sources.URLFilter("source", sources.URLPrefixes(source))
+	if scope == nil {
+		return nil, errors.New("source has no URL to match its articles by")
+	}
+
+	hits, err := m.storage.Search(ctx, source.ArticleIndex, map[string]any{
+		"size":    m.config.Samples,
+		"_source": []string{"source"},
+		"sort": []map[string]any{
+			{"created_at": map[string]any{"order": "desc", "unmapped_type": "date"}},
+		},
+		"query": map[string]any{"bool": map[string]any{"filter": []any{scope}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recent articles: %w", err)
+	}
+
+	urls := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		hitMap, ok := hit.(map[string]any)
+		if !ok {
+			continue
+		}
+		document, _ := hitMap["_source"].(map[string]any)
+		if articleURL, urlOK := document["source"].(string); urlOK && articleURL != "" {
+			urls = append(urls, articleURL)
+		}
+	}
+	return urls, nil
+}
+
+// record stores a check in the selector health index, creating the index on first use.
+func (m *SelectorMonitor) record(ctx context.Context, health *SelectorHealth) error {
+	if err := m.ensureIndex(ctx); err != nil {
+		return err
+	}
+	if err := m.storage.IndexDocument(ctx, m.config.Index, health.ID(), health); err != nil {
+		return fmt.Errorf("failed to record selector health: %w", err)
+	}
+	return nil
+}
+
+// ensureIndex creates the selector health index on first use.
+func (m *SelectorMonitor) ensureIndex(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.indexReady {
+		return nil
+	}
+
+	exists, err := m.storage.IndexExists(ctx, m.config.Index)
+	if err != nil {
+		return fmt.Errorf("failed to check selector health index: %w", err)
+	}
+	if !exists {
+		if createErr := m.storage.CreateIndex(ctx, m.config.Index, SelectorHealthMapping()); createErr != nil {
+			return fmt.Errorf("failed to create selector health index: %w", createErr)
+		}
+	}
+	m.indexReady = true
+	return nil
+}
@@ -0,0 +1,382 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+const (
+	// DefaultBatchSize is the number of documents removed per request when enforcing max_docs.
+	DefaultBatchSize = 1000
+
+	// minValidDate separates real publication dates from zero values stored by the crawler.
+	minValidDate = "1970-01-01T00:00:00Z"
+
+	// articleURLField and pageURLField hold the URL of articles and pages.
+	articleURLField = "source"
+	pageURLField    = "url"
+)
+
+// Report describes what retention removed (or would remove) from a single index.
+type Report struct {
+	// Source is the source the index belongs to
+	Source string
+	// Index is the index or alias that was pruned
+	Index string
+	// Cutoff is the date before which documents were removed; zero when only max_docs applies
+	Cutoff time.Time
+	// DeletedDocs is the number of documents removed
+	DeletedDocs int64
+	// DeletedIndices lists rollover indices that were dropped entirely
+	DeletedIndices []string
+	// DryRun is true when nothing was actually deleted
+	DryRun bool
+}
+
+// Enforcer applies retention policies to storage.
+type Enforcer struct {
+	logger       logger.Interface
+	storage      types.Interface
+	indexManager types.IndexManager
+	dryRun       bool
+	now          func() time.Time
+}
+
+// NewEnforcer creates a new retention enforcer. In dry-run mode the enforcer
+// only counts what it would remove.
+func NewEnforcer(log logger.Interface, storage types.Interface, dryRun bool) *Enforcer {
+	return &Enforcer{
+		logger:       log,
+		storage:      storage,
+		indexManager: storage.GetIndexManager(),
+		dryRun:       dryRun,
+		now:          time.Now,
+	}
+}
+
+// Enforce applies the given policies and returns a report per pruned index.
+// It keeps going when a single index fails and returns the last error.
+func (e *Enforcer) Enforce(ctx context.Context, policies []Policy) ([]Report, error) {
+	reports := make([]Report, 0, len(policies))
+	var lastErr error
+
+	for _, policy := range policies {
+		if !policy.Enabled() {
+			continue
+		}
+
+		if policy.ArticleIndex != "" {
+			report, err := e.enforceIndex(ctx, policy, policy.ArticleIndex, articleURLField, articleAgeQuery, articleSort)
+			if err != nil {
+				e.logger.Error("Failed to enforce retention",
+					"source", policy.Source, "index", policy.ArticleIndex, "error", err)
+				lastErr = fmt.Errorf("failed to enforce retention on %s: %w", policy.ArticleIndex, err)
+			} else {
+				reports = append(reports, report)
+			}
+		}
+
+		if policy.PageIndex != "" && policy.PageIndex != policy.ArticleIndex {
+			report, err := e.enforceIndex(ctx, policy, policy.PageIndex, pageURLField, pageAgeQuery, pageSort)
+			if err != nil {
+				e.logger.Error("Failed to enforce retention",
+					"source", policy.Source, "index", policy.PageIndex, "error", err)
+				lastErr = fmt.Errorf("failed to enforce retention on %s: %w", policy.PageIndex, err)
+			} else {
+				reports = append(reports, report)
+			}
+		}
+	}
+
+	return reports, lastErr
+}
+
+// Rollover creates the current month's rollover index for an alias and makes it the write index.
+// It fails if a concrete index already uses the alias name.
+func (e *Enforcer) Rollover(ctx context.Context, alias string) (string, error) {
+	backing, err := e.indexManager.GetAliasIndices(ctx, alias)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve alias %s: %w", alias, err)
+	}
+	if len(backing) == 0 {
+		exists, existsErr := e.indexManager.IndexExists(ctx, alias)
+		if existsErr != nil {
+			return "", fmt.Errorf("failed to check index %s: %w", alias, existsErr)
+		}
+		if exists {
+			return "", fmt.Errorf("%s is a concrete index, not an alias; migrate it to an alias before "+
+				"enabling rollover, see gocrawl index prune --help", alias)
+		}
+	}
+
+	index := RolloverIndexName(alias, e.now())
+	if e.dryRun {
+		return index, nil
+	}
+
+	if ensureErr := e.indexManager.EnsureArticleIndex(ctx, index); ensureErr != nil {
+		return "", fmt.Errorf("failed to create rollover index %s: %w", index, ensureErr)
+	}
+	if aliasErr := e.indexManager.SetWriteIndex(ctx, alias, index); aliasErr != nil {
+		return "", fmt.Errorf("failed to point alias %s at %s: %w", alias, index, aliasErr)
+	}
+
+	e.logger.Info("Rolled over index", "alias", alias, "index", index)
+	return index, nil
+}
+
+// enforceIndex applies a policy to the documents of its source in one index
+// or alias, matched by the URL stored in urlField.
+func (e *Enforcer) enforceIndex(
+	ctx context.Context,
+	policy Policy,
+	index string,
+	urlField string,
+	ageQuery func(cutoff time.Time) map[string]any,
+	sort []map[string]any,
+) (Report, error) {
+	report := Report{
+		Source: policy.Source,
+		Index:  index,
+		DryRun: e.dryRun,
+	}
+
+	scope := sources.URLFilter(urlField, policy.URLPrefixes)
+	if scope == nil {
+		return report, fmt.Errorf("source %s has no URL to match its documents by", policy.Source)
+	}
+
+	exists, err := e.storage.IndexExists(ctx, index)
+	if err != nil {
+		return report, err
+	}
+	if !exists {
+		e.logger.Debug("Skipping retention for missing index", "index", index)
+		return report, nil
+	}
+
+	// kept matches the documents of the source that outlive the age limit
+	kept := scope
+	if policy.MaxAge > 0 {
+		report.Cutoff = e.now().Add(-policy.MaxAge).UTC()
+		aged := ageQuery(report.Cutoff)
+
+		dropped, dropErr := e.dropExpiredRolloverIndices(ctx, index, report.Cutoff, scope)
+		if dropErr != nil {
+			return report, dropErr
+		}
+		report.DeletedIndices = dropped
+
+		deleted, deleteErr := e.deleteOrCount(ctx, index, map[string]any{
+			"query": map[string]any{"bool": map[string]any{"filter": []any{scope, aged}}},
+		})
+		if deleteErr != nil {
+			return report, deleteErr
+		}
+		report.DeletedDocs += deleted
+
+		// In dry-run mode the aged documents are still there; leave them out of max_docs
+		kept = map[string]any{"bool": map[string]any{
+			"filter":   []any{scope},
+			"must_not": []any{aged},
+		}}
+	}
+
+	if policy.MaxDocs > 0 {
+		deleted, capErr := e.enforceMaxDocs(ctx, index, policy.MaxDocs, kept, sort)
+		if capErr != nil {
+			return report, capErr
+		}
+		report.DeletedDocs += deleted
+	}
+
+	e.logger.Info("Retention enforced",
+		"source", policy.Source,
+		"index", index,
+		"cutoff", report.Cutoff,
+		"deleted_docs", report.DeletedDocs,
+		"deleted_indices", report.DeletedIndices,
+		"dry_run", e.dryRun)
+
+	return report, nil
+}
+
+// dropExpiredRolloverIndices deletes rollover indices behind an alias whose whole month is older than the cutoff.
+// Indices that also hold documents outside scope, i.e. of other sources, are kept.
+func (e *Enforcer) dropExpiredRolloverIndices(
+	ctx context.Context,
+	alias string,
+	cutoff time.Time,
+	scope map[string]any,
+) ([]string, error) {
+	backing, err := e.indexManager.GetAliasIndices(ctx, alias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve alias %s: %w", alias, err)
+	}
+
+	var dropped []string
+	for _, index := range backing {
+		month, ok := ParseRolloverIndex(alias, index)
+		if !ok || month.AddDate(0, 1, 0).After(cutoff) {
+			continue
+		}
+
+		others, countErr := e.storage.Count(ctx, index, map[string]any{
+			"query": map[string]any{"bool": map[string]any{"must_not": []any{scope}}},
+		})
+		if countErr != nil {
+			return dropped, fmt.Errorf("failed to count documents of other sources in %s: %w", index, countErr)
+		}
+		if others > 0 {
+			e.logger.Debug("Keeping rollover index shared with other sources", "index", index, "documents", others)
+			continue
+		}
+
+		if !e.dryRun {
+			if deleteErr := e.storage.DeleteIndex(ctx, index); deleteErr != nil {
+				return dropped, fmt.Errorf("failed to delete rollover index %s: %w", index, deleteErr)
+			}
+		}
+		dropped = append(dropped, index)
+	}
+
+	return dropped, nil
+}
+
+// enforceMaxDocs removes the oldest documents matching query until the index holds at most maxDocs of them.
+func (e *Enforcer) enforceMaxDocs(
+	ctx context.Context,
+	index string,
+	maxDocs int,
+	query map[string]any,
+	sort []map[string]any,
+) (int64, error) {
+	total, err := e.storage.Count(ctx, index, map[string]any{"query": query})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	excess := total - int64(maxDocs)
+	if excess <= 0 || e.dryRun {
+		return max(excess, 0), nil
+	}
+
+	var deleted int64
+	for excess > 0 {
+		batch := min(excess, DefaultBatchSize)
+		hits, searchErr := e.storage.Search(ctx, index, map[string]any{
+			"size":    batch,
+			"_source": false,
+			"sort":    sort,
+			"query":   query,
+		})
+		if searchErr != nil {
+			return deleted, fmt.Errorf("failed to find oldest documents: %w", searchErr)
+		}
+
+		ids := hitIDs(hits)
+		if len(ids) == 0 {
+			break
+		}
+
+		removed, deleteErr := e.storage.DeleteByQuery(ctx, index, map[string]any{
+			"query": map[string]any{"ids": map[string]any{"values": ids}},
+		})
+		if deleteErr != nil {
+			return deleted, deleteErr
+		}
+		if removed == 0 {
+			return deleted, errors.New("no documents removed while enforcing max_docs")
+		}
+
+		deleted += removed
+		excess -= removed
+	}
+
+	return deleted, nil
+}
+
+// deleteOrCount deletes the documents matching a query, or only counts them in dry-run mode.
+func (e *Enforcer) deleteOrCount(ctx context.Context, index string, query map[string]any) (int64, error) {
+	if e.dryRun {
+		return e.storage.Count(ctx, index, query)
+	}
+	return e.storage.DeleteByQuery(ctx, index, query)
+}
+
+// hitIDs extracts document IDs from raw search hits.
+func hitIDs(hits []any) []string {
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		hitMap, ok := hit.(map[string]any)
+		if !ok {
+			continue
+		}
+		if id, idOK := hitMap["_id"].(string); idOK {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// articleAgeQuery matches articles published before the cutoff. Articles without
+// a publication date fall back to the time they were crawled.
+func articleAgeQuery(cutoff time.Time) map[string]any {
+	before := cutoff.Format(time.RFC3339)
+	return map[string]any{
+		"bool": map[string]any{
+			"should": []any{
+				map[string]any{
+					"range": map[string]any{
+						"published_date": map[string]any{"gte": minValidDate, "lt": before},
+					},
+				},
+				map[string]any{
+					"bool": map[string]any{
+						"must_not": []any{
+							map[string]any{
+								"range": map[string]any{
+									"published_date": map[string]any{"gte": minValidDate},
+								},
+							},
+						},
+						"filter": []any{
+							map[string]any{
+								"range": map[string]any{
+									"created_at": map[string]any{"lt": before},
+								},
+							},
+						},
+					},
+				},
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// pageAgeQuery matches pages crawled before the cutoff.
+func pageAgeQuery(cutoff time.Time) map[string]any {
+	return map[string]any{
+		"range": map[string]any{
+			"created_at": map[string]any{"lt": cutoff.Format(time.RFC3339)},
+		},
+	}
+}
+
+// articleSort orders articles oldest first.
+var articleSort = []map[string]any{
+	{"published_date": map[string]any{"order": "asc", "unmapped_type": "date"}},
+	{"created_at": map[string]any{"order": "asc", "unmapped_type": "date"}},
+}
+
+// pageSort orders pages oldest first.
+var pageSort = []map[string]any{
+	{"created_at": map[string]any{"order": "asc", "unmapped_type": "date"}},
+}
@@ -0,0 +1,257 @@
+package retention_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/retention"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/jonesrussell/gocrawl/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newMocks returns a mock storage whose index manager is the returned mock.
+func newMocks() (*testutils.MockStorage, *testutils.MockIndexManager) {
+	indexManager := &testutils.MockIndexManager{}
+	storage := &testutils.MockStorage{}
+	storage.On("GetIndexManager").Return(indexManager)
+	return storage, indexManager
+}
+
+// prefixes are the URL prefixes of the documents of source a.
+var prefixes = []string{"https://a.example.com/", "http://a.example.com/"}
+
+// boolQuery matches a bool query with exactly the given clauses, e.g. filter
+// and must_not, that is scoped to the documents of source a.
+func boolQuery(clauses ...string) any {
+	return mock.MatchedBy(func(query map[string]any) bool {
+		q, _ := query["query"].(map[string]any)
+		b, ok := q["bool"].(map[string]any)
+		if !ok || len(b) != len(clauses) {
+			return false
+		}
+		for _, clause := range clauses {
+			if _, set := b[clause]; !set {
+				return false
+			}
+		}
+		encoded, err := json.Marshal(b)
+		return err == nil && strings.Contains(string(encoded), `{"prefix":{`) &&
+			strings.Contains(string(encoded), prefixes[0])
+	})
+}
+
+// agedQuery matches the documents of source a older than the age limit.
+var agedQuery = boolQuery("filter")
+
+// keptQuery matches the documents of source a younger than the age limit.
+var keptQuery = boolQuery("filter", "must_not")
+
+// othersQuery matches the documents of other sources.
+var othersQuery = boolQuery("must_not")
+
+// scopeQuery matches all documents of source a.
+var scopeQuery = boolQuery("should", "minimum_should_match")
+
+// idsQuery matches a delete-by-IDs query for the given IDs.
+func idsQuery(ids ...string) any {
+	return mock.MatchedBy(func(query map[string]any) bool {
+		q, ok := query["query"].(map[string]any)
+		if !ok {
+			return false
+		}
+		values, ok := q["ids"].(map[string]any)
+		return ok && assert.ObjectsAreEqual(ids, values["values"])
+	})
+}
+
+func TestEnforceMaxAge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	current := retention.RolloverIndexName("a_articles", time.Now())
+	storage, indexManager := newMocks()
+	storage.On("IndexExists", ctx, "a_articles").Return(true, nil)
+	storage.On("IndexExists", ctx, "a_pages").Return(false, nil)
+	indexManager.On("GetAliasIndices", ctx, "a_articles").Return([]string{"a_articles-2020.01", current}, nil)
+	storage.On("Count", ctx, "a_articles-2020.01", othersQuery).Return(int64(0), nil)
+	storage.On("DeleteIndex", ctx, "a_articles-2020.01").Return(nil)
+	storage.On("DeleteByQuery", ctx, "a_articles", agedQuery).Return(int64(4), nil)
+
+	enforcer := retention.NewEnforcer(logger.NewNoOp(), storage, false)
+	reports, err := enforcer.Enforce(ctx, []retention.Policy{{
+		Source:       "a",
+		ArticleIndex: "a_articles",
+		PageIndex:    "a_pages",
+		MaxAge:       30 * 24 * time.Hour,
+		URLPrefixes:  prefixes,
+	}})
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+
+	assert.Equal(t, "a_articles", reports[0].Index)
+	assert.Equal(t, int64(4), reports[0].DeletedDocs)
+	assert.Equal(t, []string{"a_articles-2020.01"}, reports[0].DeletedIndices)
+	assert.False(t, reports[0].Cutoff.IsZero())
+	assert.Equal(t, "a_pages", reports[1].Index)
+	assert.Zero(t, reports[1].DeletedDocs, "missing indices are skipped")
+	storage.AssertExpectations(t)
+	indexManager.AssertExpectations(t)
+}
+
+func TestEnforceDryRun(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, indexManager := newMocks()
+	storage.On("IndexExists", ctx, "a_articles").Return(true, nil)
+	indexManager.On("GetAliasIndices", ctx, "a_articles").Return([]string{"a_articles-2020.01"}, nil)
+	storage.On("Count", ctx, "a_articles-2020.01", othersQuery).Return(int64(0), nil)
+	storage.On("Count", ctx, "a_articles", agedQuery).Return(int64(12), nil)
+	storage.On("Count", ctx, "a_articles", keptQuery).Return(int64(15), nil)
+
+	enforcer := retention.NewEnforcer(logger.NewNoOp(), storage, true)
+	reports, err := enforcer.Enforce(ctx, []retention.Policy{{
+		Source:       "a",
+		ArticleIndex: "a_articles",
+		MaxAge:       30 * 24 * time.Hour,
+		MaxDocs:      10,
+		URLPrefixes:  prefixes,
+	}})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	assert.True(t, reports[0].DryRun)
+	assert.Equal(t, []string{"a_articles-2020.01"}, reports[0].DeletedIndices)
+	assert.Equal(t, int64(12+5), reports[0].DeletedDocs,
+		"aged documents plus the excess of the other documents over max_docs")
+	storage.AssertNotCalled(t, "DeleteIndex", mock.Anything, mock.Anything)
+	storage.AssertNotCalled(t, "DeleteByQuery", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEnforceMaxDocs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, _ := newMocks()
+	storage.On("IndexExists", ctx, "a_pages").Return(true, nil)
+	storage.On("Count", ctx, "a_pages", mock.MatchedBy(func(query map[string]any) bool {
+		return assert.ObjectsAreEqual(sources.URLFilter("url", prefixes), query["query"])
+	})).Return(int64(10), nil)
+	storage.On("Search", ctx, "a_pages", mock.MatchedBy(func(query map[string]any) bool {
+		return assert.ObjectsAreEqual(sources.URLFilter("url", prefixes), query["query"])
+	})).Return([]any{
+		map[string]any{"_id": "oldest"},
+		map[string]any{"_id": "older"},
+	}, nil)
+	storage.On("DeleteByQuery", ctx, "a_pages", idsQuery("oldest", "older")).Return(int64(2), nil)
+
+	enforcer := retention.NewEnforcer(logger.NewNoOp(), storage, false)
+	reports, err := enforcer.Enforce(ctx, []retention.Policy{{
+		Source: "a", PageIndex: "a_pages", MaxDocs: 8, URLPrefixes: prefixes,
+	}})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, int64(2), reports[0].DeletedDocs)
+	storage.AssertExpectations(t)
+}
+
+func TestEnforceContinuesAfterFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, _ := newMocks()
+	storage.On("IndexExists", ctx, "a_articles").Return(false, errors.New("unreachable"))
+	storage.On("IndexExists", ctx, "b_articles").Return(true, nil)
+	storage.On("Count", ctx, "b_articles", mock.Anything).Return(int64(3), nil)
+
+	enforcer := retention.NewEnforcer(logger.NewNoOp(), storage, false)
+	reports, err := enforcer.Enforce(ctx, []retention.Policy{
+		{Source: "a", ArticleIndex: "a_articles", MaxDocs: 5, URLPrefixes: prefixes},
+		{Source: "b", ArticleIndex: "b_articles", MaxDocs: 5, URLPrefixes: []string{"https://b.example.com/"}},
+		{Source: "disabled", ArticleIndex: "c_articles"},
+	})
+	require.ErrorContains(t, err, "a_articles")
+	require.Len(t, reports, 1)
+	assert.Equal(t, "b", reports[0].Source)
+}
+
+func TestEnforceSharedIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, indexManager := newMocks()
+	storage.On("IndexExists", ctx, "articles").Return(true, nil)
+	indexManager.On("GetAliasIndices", ctx, "articles").Return([]string{"articles-2020.01"}, nil)
+	storage.On("Count", ctx, "articles-2020.01", othersQuery).Return(int64(7), nil)
+	storage.On("DeleteByQuery", ctx, "articles", agedQuery).Return(int64(3), nil)
+
+	enforcer := retention.NewEnforcer(logger.NewNoOp(), storage, false)
+	reports, err := enforcer.Enforce(ctx, []retention.Policy{{
+		Source:       "a",
+		ArticleIndex: "articles",
+		MaxAge:       30 * 24 * time.Hour,
+		URLPrefixes:  prefixes,
+	}})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, int64(3), reports[0].DeletedDocs, "only the documents of the source are deleted")
+	assert.Empty(t, reports[0].DeletedIndices, "indices holding documents of other sources are kept")
+	storage.AssertNotCalled(t, "DeleteIndex", mock.Anything, mock.Anything)
+
+	// A source without a URL cannot be told apart from the others
+	_, err = enforcer.Enforce(ctx, []retention.Policy{{Source: "b", ArticleIndex: "articles", MaxDocs: 5}})
+	require.ErrorContains(t, err, "no URL")
+	storage.AssertExpectations(t)
+}
+
+func TestRollover(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("points the alias at the current month", func(t *testing.T) {
+		t.Parallel()
+		storage, indexManager := newMocks()
+		indexManager.On("GetAliasIndices", ctx, "a_articles").Return([]string{"a_articles-2020.01"}, nil)
+		indexManager.On("EnsureArticleIndex", ctx, mock.Anything).Return(nil)
+		indexManager.On("SetWriteIndex", ctx, "a_articles", mock.Anything).Return(nil)
+
+		index, err := retention.NewEnforcer(logger.NewNoOp(), storage, false).Rollover(ctx, "a_articles")
+		require.NoError(t, err)
+		month, ok := retention.ParseRolloverIndex("a_articles", index)
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now(), month, 32*24*time.Hour)
+		indexManager.AssertCalled(t, "SetWriteIndex", ctx, "a_articles", index)
+	})
+
+	t.Run("refuses a concrete index", func(t *testing.T) {
+		t.Parallel()
+		storage, indexManager := newMocks()
+		indexManager.On("GetAliasIndices", ctx, "a_articles").Return([]string(nil), nil)
+		indexManager.On("IndexExists", ctx, "a_articles").Return(true, nil)
+
+		_, err := retention.NewEnforcer(logger.NewNoOp(), storage, false).Rollover(ctx, "a_articles")
+		require.ErrorContains(t, err, "concrete index")
+		indexManager.AssertNotCalled(t, "SetWriteIndex", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("dry run creates nothing", func(t *testing.T) {
+		t.Parallel()
+		storage, indexManager := newMocks()
+		indexManager.On("GetAliasIndices", ctx, "a_articles").Return([]string(nil), nil)
+		indexManager.On("IndexExists", ctx, "a_articles").Return(false, nil)
+
+		index, err := retention.NewEnforcer(logger.NewNoOp(), storage, true).Rollover(ctx, "a_articles")
+		require.NoError(t, err)
+		assert.Equal(t, retention.RolloverIndexName("a_articles", time.Now()), index)
+		indexManager.AssertNotCalled(t, "EnsureArticleIndex", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,87 @@
+// Package retention enforces per-source retention policies on the indices
+// that hold crawled content. Policies come from the source configuration
+// (retention and max_docs) and are applied with delete-by-query or, for
+// time-based rollover indices behind an alias, by dropping whole indices.
+package retention
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/sources"
+)
+
+// RolloverDateLayout is the date suffix used for monthly rollover indices, e.g. articles-2026.10.
+const RolloverDateLayout = "2006.01"
+
+// Policy describes how long the documents of a single source are kept.
+type Policy struct {
+	// Source is the name of the source the policy belongs to
+	Source string
+	// ArticleIndex is the article index or alias the policy applies to
+	ArticleIndex string
+	// PageIndex is the page index or alias the policy applies to
+	PageIndex string
+	// MaxAge is the maximum document age; zero disables age-based deletion
+	MaxAge time.Duration
+	// MaxDocs is the maximum number of documents kept per index; zero means unlimited
+	MaxDocs int
+	// URLPrefixes are the prefixes of the URLs of the source's documents. Indices
+	// may be shared by several sources, so only documents under them are removed.
+	URLPrefixes []string
+}
+
+// Enabled reports whether the policy removes anything at all.
+func (p Policy) Enabled() bool {
+	return p.MaxAge > 0 || p.MaxDocs > 0
+}
+
+// PolicyFromSource builds the retention policy for a source.
+func PolicyFromSource(source *sources.Config) Policy {
+	pageIndex := source.PageIndex
+	if pageIndex == "" {
+		pageIndex = source.Index
+	}
+
+	return Policy{
+		Source:       source.Name,
+		ArticleIndex: source.ArticleIndex,
+		PageIndex:    pageIndex,
+		MaxAge:       source.Retention,
+		MaxDocs:      source.MaxDocs,
+		URLPrefixes:  sources.URLPrefixes(source),
+	}
+}
+
+// PoliciesFromSources returns the enabled retention policies of the given sources.
+func PoliciesFromSources(configs []sources.Config) []Policy {
+	policies := make([]Policy, 0, len(configs))
+	for i := range configs {
+		policy := PolicyFromSource(&configs[i])
+		if policy.Enabled() {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
+// RolloverIndexName returns the name of the monthly rollover index for an alias.
+func RolloverIndexName(alias string, t time.Time) string {
+	return alias + "-" + t.UTC().Format(RolloverDateLayout)
+}
+
+// ParseRolloverIndex returns the month covered by a rollover index of the given alias.
+// It reports false for indices that do not follow the alias-YYYY.MM naming scheme.
+func ParseRolloverIndex(alias, index string) (time.Time, bool) {
+	suffix, found := strings.CutPrefix(index, alias+"-")
+	if !found {
+		return time.Time{}, false
+	}
+
+	month, err := time.Parse(RolloverDateLayout, suffix)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return month, true
+}
@@ -0,0 +1,88 @@
+package retention_test
+
+import (
+	"testing"
+	"time"
+
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/retention"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoliciesFromSources(t *testing.T) {
+	t.Parallel()
+
+	configs := []sources.Config{
+		{Name: "no-retention", ArticleIndex: "a_articles", Index: "a_pages"},
+		{Name: "aged", ArticleIndex: "b_articles", Index: "b_pages", Retention: 180 * 24 * time.Hour},
+		{
+			Name: "capped", URL: "https://www.Capped.example.com/news", AllowedDomains: []string{"capped.example.com"},
+			ArticleIndex: "c_articles", PageIndex: "c_content", Index: "c_pages", MaxDocs: 500,
+		},
+	}
+
+	policies := retention.PoliciesFromSources(configs)
+	require.Len(t, policies, 2)
+
+	assert.Equal(t, "aged", policies[0].Source)
+	assert.Equal(t, "b_articles", policies[0].ArticleIndex)
+	assert.Equal(t, "b_pages", policies[0].PageIndex)
+	assert.Equal(t, 180*24*time.Hour, policies[0].MaxAge)
+
+	assert.Equal(t, "capped", policies[1].Source)
+	assert.Equal(t, "c_content", policies[1].PageIndex)
+	assert.Equal(t, 500, policies[1].MaxDocs)
+	assert.Equal(t, []string{
+		"https://www.capped.example.com/", "http://www.capped.example.com/",
+		"https://capped.example.com/", "http://capped.example.com/",
+	}, policies[1].URLPrefixes)
+}
+
+func TestRolloverIndexName(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+	index := retention.RolloverIndexName("articles", now)
+	assert.Equal(t, "articles-2026.10", index)
+
+	month, ok := retention.ParseRolloverIndex("articles", index)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), month)
+
+	_, ok = retention.ParseRolloverIndex("articles", "articles_backup")
+	assert.False(t, ok)
+	_, ok = retention.ParseRolloverIndex("articles", "pages-2026.10")
+	assert.False(t, ok)
+}
+
+func TestParseRetention(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "", want: 0},
+		{input: "180d", want: 180 * 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "36h", want: 36 * time.Hour},
+		{input: "0d", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			got, err := configtypes.ParseRetention(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package runhistory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+// AnomalyHandler is told about every finished run that deviated from its baseline.
+type AnomalyHandler func(ctx context.Context, run *Run)
+
+// Recorder counts the pages, errors and articles of the current crawl run and
+// saves the run, checked against its baseline, when it finishes. All methods
+// are safe for concurrent use and do nothing on a nil Recorder.
+type Recorder struct {
+	logger    logger.Interface
+	store     *Store
+	config    crawlerconfig.RunsConfig
+	onAnomaly AnomalyHandler
+
+	mu        sync.Mutex
+	run       *Run
+	bodyChars int64
+}
+
+// NewRecorder creates a new run recorder.
+func NewRecorder(log logger.Interface, storage types.Interface, cfg crawlerconfig.RunsConfig) *Recorder {
+	return &Recorder{
+		logger: log,
+		store:  NewStore(storage, cfg.Index),
+		config: cfg,
+	}
+}
+
+// OnAnomaly sets the handler told about anomalous runs. Without one,
+// anomalous runs are logged.
+func (r *Recorder) OnAnomaly(handler AnomalyHandler) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onAnomaly = handler
+}
+
+// Begin starts a run of a source, discarding any run left unfinished.
+func (r *Recorder) Begin(source string, now time.Time) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.run = &Run{Source: source, StartedAt: now.UTC()}
+	r.bodyChars = 0
+}
+
+// PageVisited counts a visited page.
+func (r *Recorder) PageVisited() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.run != nil {
+		r.run.PagesVisited++
+	}
+}
+
+// Error counts a failed request or processing error.
+func (r *Recorder) Error() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.run != nil {
+		r.run.Errors++
+	}
+}
+
+// ArticleIndexed counts an indexed article and its body length.
+func (r *Recorder) ArticleIndexed(article *domain.Article) {
+	if r == nil || article == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.run != nil {
+		r.run.ArticlesIndexed++
+		r.bodyChars += int64(len([]rune(article.Body)))
+	}
+}
+
+// Finish ends the current run, checks it against the previous runs of its
+// source and saves it. It returns nil when no run was started.
+func (r *Recorder) Finish(ctx context.Context, now time.Time, cancelled bool) (*Run, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	run := r.run
+	r.run = nil
+	if run != nil {
+		run.FinishedAt = now.UTC()
+		run.Cancelled = cancelled
+		if requests := run.PagesVisited + run.Errors; requests > 0 {
+			run.ErrorRate = float64(run.Errors) / float64(requests)
+		}
+		if run.ArticlesIndexed > 0 {
+			run.MeanBodyLength = float64(r.bodyChars) / float64(run.ArticlesIndexed)
+		}
+	}
+	onAnomaly := r.onAnomaly
+	r.mu.Unlock()
+	if run == nil {
+		return nil, nil
+	}
+
+	history, err := r.store.Recent(ctx, run.Source, r.config.BaselineRuns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load run history: %w", err)
+	}
+	run.Anomalies = Detect(run, history, r.config)
+
+	if saveErr := r.store.Save(ctx, run); saveErr != nil {
+		return nil, saveErr
+	}
+
+	switch {
+	case !run.Anomalous():
+	case onAnomaly != nil:
+		onAnomaly(ctx, run)
+	default:
+		r.logger.Warn("Crawl run deviates from its baseline",
+			"source", run.Source,
+			"anomalies", run.Anomalies,
+		)
+	}
+	return run, nil
+}
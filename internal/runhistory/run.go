@@ -0,0 +1,176 @@
+// Package runhistory records every crawl run of a source and flags runs that
+// deviate from the source's rolling baseline, which is how sources that fail
+// quietly (paywalls, blocks, articles classified as pages) are noticed.
+package runhistory
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+)
+
+// Metrics compared against the baseline
+const (
+	// MetricArticlesIndexed is the number of articles indexed by a run
+	MetricArticlesIndexed = "articles_indexed"
+	// MetricPagesVisited is the number of pages visited by a run
+	MetricPagesVisited = "pages_visited"
+	// MetricErrorRate is the share of the requests of a run that failed
+	MetricErrorRate = "error_rate"
+	// MetricMeanBodyLength is the mean body length, in characters, of the articles indexed by a run
+	MetricMeanBodyLength = "mean_body_length"
+)
+
+// percent converts a rate to a percentage.
+const percent = 100
+
+// Run is one finished crawl of a source.
+type Run struct {
+	// Source is the name of the source
+	Source string `json:"source"`
+	// StartedAt is when the run started
+	StartedAt time.Time `json:"started_at"`
+	// FinishedAt is when the run finished
+	FinishedAt time.Time `json:"finished_at"`
+	// Cancelled reports whether the run was cut short; cancelled runs are not part of baselines
+	Cancelled bool `json:"cancelled"`
+	// ArticlesIndexed is the number of articles indexed
+	ArticlesIndexed int64 `json:"articles_indexed"`
+	// PagesVisited is the number of pages visited
+	PagesVisited int64 `json:"pages_visited"`
+	// Errors is the number of failed requests and processing errors
+	Errors int64 `json:"errors"`
+	// ErrorRate is Errors over the pages visited and failed, from 0 to 1
+	ErrorRate float64 `json:"error_rate"`
+	// MeanBodyLength is the mean body length, in characters, of the articles indexed
+	MeanBodyLength float64 `json:"mean_body_length"`
+	// Anomalies are the metrics that deviated from the baseline
+	Anomalies []Anomaly `json:"anomalies,omitempty"`
+}
+
+// Anomaly is a metric of a run that deviated from the baseline.
+type Anomaly struct {
+	// Metric is the name of the metric
+	Metric string `json:"metric"`
+	// Value is the value of the run
+	Value float64 `json:"value"`
+	// Baseline is the mean of the previous runs
+	Baseline float64 `json:"baseline"`
+}
+
+// String describes the anomaly, e.g. "articles_indexed 2 (baseline 40)".
+func (a Anomaly) String() string {
+	if a.Metric == MetricErrorRate {
+		return fmt.Sprintf("%s %.0f%% (baseline %.0f%%)", a.Metric, a.Value*percent, a.Baseline*percent)
+	}
+	return fmt.Sprintf("%s %.0f (baseline %.0f)", a.Metric, a.Value, a.Baseline)
+}
+
+// ID returns the document ID of the run.
+func (r *Run) ID() string {
+	return r.Source + "-" + strconv.FormatInt(r.StartedAt.UnixNano(), 10)
+}
+
+// Anomalous reports whether the run deviated from the baseline.
+func (r *Run) Anomalous() bool {
+	return len(r.Anomalies) > 0
+}
+
+// metric returns the value of a metric of the run.
+func (r *Run) metric(name string) float64 {
+	switch name {
+	case MetricArticlesIndexed:
+		return float64(r.ArticlesIndexed)
+	case MetricPagesVisited:
+		return float64(r.PagesVisited)
+	case MetricErrorRate:
+		return r.ErrorRate
+	case MetricMeanBodyLength:
+		return r.MeanBodyLength
+	default:
+		return 0
+	}
+}
+
+// metricFloors are the smallest baselines changes are measured against, so a
+// metric whose baseline is zero is not flagged for a tiny change.
+var metricFloors = []struct {
+	name  string
+	floor float64
+}{
+	{MetricArticlesIndexed, 1},
+	{MetricPagesVisited, 1},
+	{MetricErrorRate, 0.05},
+	{MetricMeanBodyLength, 1},
+}
+
+// Detect compares a run against the previous runs of its source and returns
+// the metrics that deviate from their baseline by more than the threshold in
+// standard deviations and by more than the minimum relative change. Runs are
+// only checked once enough completed previous runs exist.
+func Detect(run *Run, history []Run, cfg crawlerconfig.RunsConfig) []Anomaly {
+	if run.Cancelled {
+		return nil
+	}
+
+	var baseline []Run
+	for i := range history {
+		if !history[i].Cancelled && len(baseline) < cfg.BaselineRuns {
+			baseline = append(baseline, history[i])
+		}
+	}
+	if len(baseline) < cfg.MinBaselineRuns {
+		return nil
+	}
+
+	var anomalies []Anomaly
+	for _, m := range metricFloors {
+		var sum float64
+		for i := range baseline {
+			sum += baseline[i].metric(m.name)
+		}
+		mean := sum / float64(len(baseline))
+
+		var squares float64
+		for i := range baseline {
+			squares += math.Pow(baseline[i].metric(m.name)-mean, 2)
+		}
+		stddev := math.Sqrt(squares / float64(len(baseline)))
+
+		value := run.metric(m.name)
+		deviation := math.Abs(value - mean)
+		if deviation > cfg.Threshold*stddev && deviation > cfg.MinChange*math.Max(mean, m.floor) {
+			anomalies = append(anomalies, Anomaly{Metric: m.name, Value: value, Baseline: mean})
+		}
+	}
+	return anomalies
+}
+
+// Mapping returns the Elasticsearch mapping of the runs index.
+func Mapping() map[string]any {
+	return map[string]any{
+		"mappings": map[string]any{
+			"properties": map[string]any{
+				"source":           map[string]any{"type": "keyword"},
+				"started_at":       map[string]any{"type": "date"},
+				"finished_at":      map[string]any{"type": "date"},
+				"cancelled":        map[string]any{"type": "boolean"},
+				"articles_indexed": map[string]any{"type": "long"},
+				"pages_visited":    map[string]any{"type": "long"},
+				"errors":           map[string]any{"type": "long"},
+				"error_rate":       map[string]any{"type": "float"},
+				"mean_body_length": map[string]any{"type": "float"},
+				"anomalies": map[string]any{
+					"properties": map[string]any{
+						"metric":   map[string]any{"type": "keyword"},
+						"value":    map[string]any{"type": "float"},
+						"baseline": map[string]any{"type": "float"},
+					},
+				},
+			},
+		},
+	}
+}
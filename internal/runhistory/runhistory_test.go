@@ -0,0 +1,159 @@
+package runhistory_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/domain"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/runhistory"
+	"github.com/jonesrussell/gocrawl/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// baseline builds a run history of steady runs.
+func baseline(articles ...int64) []runhistory.Run {
+	runs := make([]runhistory.Run, 0, len(articles))
+	for i, count := range articles {
+		runs = append(runs, runhistory.Run{
+			Source:          "example",
+			StartedAt:       time.Date(2026, 3, 10-i, 6, 0, 0, 0, time.UTC),
+			ArticlesIndexed: count,
+			PagesVisited:    count * 3,
+			ErrorRate:       0.02,
+			MeanBodyLength:  2400,
+		})
+	}
+	return runs
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	cfg := crawlerconfig.NewRunsConfig()
+	history := baseline(40, 42, 38, 41, 39)
+
+	tests := []struct {
+		name    string
+		run     runhistory.Run
+		history []runhistory.Run
+		want    []string
+	}{
+		{
+			name: "steady run",
+			run: runhistory.Run{
+				ArticlesIndexed: 43, PagesVisited: 120, ErrorRate: 0.03, MeanBodyLength: 2350,
+			},
+			history: history,
+		},
+		{
+			name: "paywalled run",
+			run: runhistory.Run{
+				ArticlesIndexed: 2, PagesVisited: 118, ErrorRate: 0.02, MeanBodyLength: 310,
+			},
+			history: history,
+			want:    []string{runhistory.MetricArticlesIndexed, runhistory.MetricMeanBodyLength},
+		},
+		{
+			name: "blocked run",
+			run: runhistory.Run{
+				PagesVisited: 3, ErrorRate: 0.9,
+			},
+			history: history,
+			want: []string{
+				runhistory.MetricArticlesIndexed,
+				runhistory.MetricPagesVisited,
+				runhistory.MetricErrorRate,
+				runhistory.MetricMeanBodyLength,
+			},
+		},
+		{
+			name:    "too little history",
+			run:     runhistory.Run{},
+			history: history[:2],
+		},
+		{
+			name:    "cancelled run",
+			run:     runhistory.Run{Cancelled: true},
+			history: history,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			anomalies := runhistory.Detect(&tt.run, tt.history, cfg)
+			metrics := make([]string, 0, len(anomalies))
+			for _, anomaly := range anomalies {
+				metrics = append(metrics, anomaly.Metric)
+			}
+			if tt.want == nil {
+				assert.Empty(t, metrics)
+				return
+			}
+			assert.Equal(t, tt.want, metrics)
+		})
+	}
+}
+
+func TestRecorderFlagsAnomalousRun(t *testing.T) {
+	t.Parallel()
+
+	stor, ok := testutils.NewMockStorage(logger.NewNoOp()).(*testutils.MockStorage)
+	require.True(t, ok)
+
+	cfg := crawlerconfig.NewRunsConfig()
+	hits := make([]map[string]any, 0)
+	for _, run := range baseline(40, 42, 38, 41, 39) {
+		hits = append(hits, map[string]any{"_source": run})
+	}
+	response, err := json.Marshal(map[string]any{"hits": map[string]any{"hits": hits}})
+	require.NoError(t, err)
+
+	stor.On("IndexExists", mock.Anything, cfg.Index).Return(true, nil)
+	stor.On("SearchDocuments", mock.Anything, cfg.Index, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			require.NoError(t, json.Unmarshal(response, args.Get(3)))
+		}).Return(nil)
+	stor.On("IndexDocument", mock.Anything, cfg.Index, mock.Anything, mock.Anything).Return(nil)
+
+	recorder := runhistory.NewRecorder(logger.NewNoOp(), stor, cfg)
+	var flagged *runhistory.Run
+	recorder.OnAnomaly(func(_ context.Context, run *runhistory.Run) {
+		flagged = run
+	})
+
+	started := time.Date(2026, 3, 11, 6, 0, 0, 0, time.UTC)
+	recorder.Begin("example", started)
+	for range 100 {
+		recorder.PageVisited()
+	}
+	recorder.Error()
+	recorder.ArticleIndexed(&domain.Article{Body: "Subscribe to keep reading."})
+	recorder.ArticleIndexed(&domain.Article{Body: "Subscribe to keep reading."})
+
+	run, err := recorder.Finish(t.Context(), started.Add(5*time.Minute), false)
+	require.NoError(t, err)
+	require.NotNil(t, run)
+
+	assert.Equal(t, int64(2), run.ArticlesIndexed)
+	assert.Equal(t, int64(100), run.PagesVisited)
+	assert.InDelta(t, 1.0/101, run.ErrorRate, 0.0001)
+	assert.InDelta(t, 26, run.MeanBodyLength, 0.001)
+	require.True(t, run.Anomalous())
+	assert.Equal(t, runhistory.MetricArticlesIndexed, run.Anomalies[0].Metric)
+	assert.Equal(t, "articles_indexed 2 (baseline 40)", run.Anomalies[0].String())
+	assert.Same(t, run, flagged)
+	stor.AssertCalled(t, "IndexDocument", mock.Anything, cfg.Index, run.ID(), run)
+
+	var nilRecorder *runhistory.Recorder
+	nilRecorder.Begin("example", started)
+	run, err = nilRecorder.Finish(t.Context(), started, false)
+	require.NoError(t, err)
+	assert.Nil(t, run)
+}
@@ -0,0 +1,89 @@
+package runhistory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+// Store persists crawl runs in Elasticsearch.
+type Store struct {
+	storage types.Interface
+	index   string
+
+	mu         sync.Mutex
+	indexReady bool
+}
+
+// NewStore creates a run store on an index.
+func NewStore(storage types.Interface, index string) *Store {
+	return &Store{storage: storage, index: index}
+}
+
+// Save stores a run, creating the runs index on first use.
+func (s *Store) Save(ctx context.Context, run *Run) error {
+	if err := s.ensureIndex(ctx); err != nil {
+		return err
+	}
+	if err := s.storage.IndexDocument(ctx, s.index, run.ID(), run); err != nil {
+		return fmt.Errorf("failed to save run: %w", err)
+	}
+	return nil
+}
+
+// Recent returns up to limit runs of a source, newest first. A missing runs
+// index means there are no runs yet.
+func (s *Store) Recent(ctx context.Context, source string, limit int) ([]Run, error) {
+	exists, err := s.storage.IndexExists(ctx, s.index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check runs index: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				Source Run `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	query := map[string]any{
+		"size":  limit,
+		"query": map[string]any{"term": map[string]any{"source": source}},
+		"sort":  []map[string]any{{"started_at": map[string]any{"order": "desc"}}},
+	}
+	if searchErr := s.storage.SearchDocuments(ctx, s.index, query, &response); searchErr != nil {
+		return nil, fmt.Errorf("failed to search runs: %w", searchErr)
+	}
+
+	runs := make([]Run, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		runs = append(runs, hit.Source)
+	}
+	return runs, nil
+}
+
+// ensureIndex creates the runs index on first use.
+func (s *Store) ensureIndex(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.indexReady {
+		return nil
+	}
+
+	exists, err := s.storage.IndexExists(ctx, s.index)
+	if err != nil {
+		return fmt.Errorf("failed to check runs index: %w", err)
+	}
+	if !exists {
+		if createErr := s.storage.CreateIndex(ctx, s.index, Mapping()); createErr != nil {
+			return fmt.Errorf("failed to create runs index: %w", createErr)
+		}
+	}
+	s.indexReady = true
+	return nil
+}
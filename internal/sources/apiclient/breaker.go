@@ -0,0 +1,85 @@
+// Package apiclient provides HTTP client functionality for interacting with the gosources API.
+package apiclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// circuitBreaker stops requests to an API that keeps failing. After threshold
+// failures in a row the circuit opens and requests are rejected until the
+// cooldown has passed; then a single trial request is let through, and its
+// outcome closes or reopens the circuit. A nil breaker lets every request through.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// newCircuitBreaker creates a breaker, or nil when threshold is 0.
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a request may be sent.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	remaining := b.cooldown - b.now().Sub(b.openedAt)
+	if remaining > 0 || b.trial {
+		if remaining < 0 {
+			remaining = 0
+		}
+		return fmt.Errorf("%w after %d failed requests, retrying in %s",
+			ErrCircuitOpen, b.failures, remaining.Round(time.Second))
+	}
+	b.trial = true
+	return nil
+}
+
+// record counts the outcome of a request.
+func (b *circuitBreaker) record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
+
+// release ends a request without counting its outcome, e.g. when the caller
+// cancelled it. A trial request may be sent again.
+func (b *circuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
@@ -10,7 +10,10 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
 )
 
 const (
@@ -20,10 +23,17 @@ const (
 	DefaultTimeout = 30 * time.Second
 )
 
-// Client is an HTTP client for interacting with the gosources API.
+// Client is an HTTP client for interacting with the gosources API. Requests
+// that fail to connect or get a 5xx response are retried with backoff, and a
+// circuit breaker stops requests to an API that keeps failing.
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	token      string
+	apiKey     string
+	retry      retryPolicy
+	breaker    *circuitBreaker
+	pageSize   int
 }
 
 // Option is a function that configures a Client.
@@ -50,7 +60,66 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
-// NewClient creates a new gosources API client.
+// WithBearerToken authenticates requests with a bearer token.
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithAPIKey authenticates requests with an API key in the X-API-Key header.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithRetry retries requests that failed to connect or got a 5xx response up
+// to maxRetries times, waiting from initialWait up to maxWait between attempts.
+func WithRetry(maxRetries int, initialWait, maxWait time.Duration) Option {
+	return func(c *Client) {
+		c.retry = retryPolicy{maxRetries: maxRetries, initialWait: initialWait, maxWait: maxWait}
+	}
+}
+
+// WithCircuitBreaker rejects requests for cooldown after threshold failed
+// requests in a row. A threshold of 0 disables the breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		c.breaker = newCircuitBreaker(threshold, cooldown)
+	}
+}
+
+// WithPageSize lists sources pageSize at a time. A page size of 0 lists them
+// all in one request.
+func WithPageSize(pageSize int) Option {
+	return func(c *Client) {
+		c.pageSize = pageSize
+	}
+}
+
+// WithConfig applies the sources API settings of the crawler configuration.
+// A zero timeout keeps the default one.
+func WithConfig(cfg crawlerconfig.SourcesAPIConfig) Option {
+	return func(c *Client) {
+		opts := []Option{
+			WithBearerToken(cfg.Token),
+			WithAPIKey(cfg.APIKey),
+			WithRetry(cfg.MaxRetries, cfg.RetryInitialWait, cfg.RetryMaxWait),
+			WithCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+			WithPageSize(cfg.PageSize),
+		}
+		if cfg.Timeout > 0 {
+			opts = append(opts, WithTimeout(cfg.Timeout))
+		}
+		for _, opt := range opts {
+			opt(c)
+		}
+	}
+}
+
+// NewClient creates a new gosources API client. Without options it sends
+// unauthenticated requests and neither retries nor breaks the circuit.
 func NewClient(opts ...Option) *Client {
 	client := &Client{
 		baseURL: DefaultBaseURL,
@@ -66,19 +135,104 @@ func NewClient(opts ...Option) *Client {
 	return client
 }
 
+// BaseURL returns the URL of the sources endpoint.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
 // ListSources retrieves all sources from the API.
 func (c *Client) ListSources(ctx context.Context) ([]APISource, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL, http.NoBody)
+	apiSources, _, _, err := c.listSources(ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to list sources: %w", err)
 	}
+	return apiSources, nil
+}
 
-	var response ListSourcesResponse
-	if doErr := c.doRequest(req, &response); doErr != nil {
-		return nil, fmt.Errorf("failed to list sources: %w", doErr)
+// ListSourcesIfModified retrieves all sources unless they are unchanged since the
+// response that carried etag. It returns the ETag of the current set, and
+// modified is false when the API answered 304 Not Modified.
+func (c *Client) ListSourcesIfModified(
+	ctx context.Context,
+	etag string,
+) (apiSources []APISource, currentETag string, modified bool, err error) {
+	apiSources, currentETag, modified, err = c.listSources(ctx, etag)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to list sources: %w", err)
+	}
+	if !modified {
+		return nil, etag, false, nil
 	}
+	return apiSources, currentETag, true, nil
+}
+
+// listSources retrieves the sources page by page when a page size is set. The
+// ETag is checked and returned for the first page. Paging stops at a short
+// page, at the total the API reports, or at a page without new sources, so an
+// API that ignores paging is listed once.
+func (c *Client) listSources(
+	ctx context.Context,
+	etag string,
+) (apiSources []APISource, currentETag string, modified bool, err error) {
+	seen := make(map[string]bool)
+	for offset := 0; ; {
+		pageURL, urlErr := c.pageURL(offset)
+		if urlErr != nil {
+			return nil, "", false, urlErr
+		}
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, http.NoBody)
+		if reqErr != nil {
+			return nil, "", false, fmt.Errorf("failed to create request: %w", reqErr)
+		}
+		if offset == 0 && etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
 
-	return response.Sources, nil
+		var response ListSourcesResponse
+		resp, doErr := c.do(req, &response)
+		if doErr != nil {
+			return nil, "", false, doErr
+		}
+		if offset == 0 {
+			if resp.StatusCode == http.StatusNotModified {
+				return nil, etag, false, nil
+			}
+			currentETag = resp.Header.Get("ETag")
+		}
+
+		added := 0
+		for i := range response.Sources {
+			id := response.Sources[i].ID
+			if id != "" && seen[id] {
+				continue
+			}
+			seen[id] = true
+			apiSources = append(apiSources, response.Sources[i])
+			added++
+		}
+		offset += len(response.Sources)
+
+		if c.pageSize == 0 || len(response.Sources) < c.pageSize || added == 0 ||
+			(response.Total > 0 && len(apiSources) >= response.Total) {
+			return apiSources, currentETag, true, nil
+		}
+	}
+}
+
+// pageURL returns the URL of the page of sources starting at offset.
+func (c *Client) pageURL(offset int) (string, error) {
+	if c.pageSize == 0 {
+		return c.baseURL, nil
+	}
+	pageURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to construct URL: %w", err)
+	}
+	query := pageURL.Query()
+	query.Set("limit", strconv.Itoa(c.pageSize))
+	query.Set("offset", strconv.Itoa(offset))
+	pageURL.RawQuery = query.Encode()
+	return pageURL.String(), nil
 }
 
 // GetSource retrieves a specific source by ID.
@@ -169,45 +323,106 @@ func (c *Client) DeleteSource(ctx context.Context, id string) error {
 
 // doRequest executes an HTTP request and decodes the response.
 func (c *Client) doRequest(req *http.Request, result any) error {
-	resp, err := c.httpClient.Do(req)
+	_, err := c.do(req, result)
+	return err
+}
+
+// do executes an HTTP request, retrying it as the retry policy allows, and
+// decodes the response. The returned response carries the status and headers;
+// its body has already been consumed. Error statuses are returned as *APIError.
+func (c *Client) do(req *http.Request, result any) (*http.Response, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	c.authenticate(req)
+
+	resp, body, err := c.send(req)
+	for attempt := 0; attempt < c.retry.maxRetries && retryable(req, resp, err); attempt++ {
+		timer := time.NewTimer(c.retry.backoff(attempt))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			c.breaker.release()
+			return nil, fmt.Errorf("request cancelled while retrying: %w", req.Context().Err())
+		case <-timer.C:
+		}
+		resp, body, err = c.send(req)
+	}
+	if err != nil && req.Context().Err() != nil {
+		// The caller gave up on the request; that says nothing about the API
+		c.breaker.release()
+	} else {
+		c.breaker.record(err != nil || resp.StatusCode >= http.StatusInternalServerError)
+	}
+
 	if err != nil {
 		// Provide more helpful error message for connection issues
 		var urlErr *url.Error
 		if errors.As(err, &urlErr) {
 			if urlErr.Op == "dial" || urlErr.Err != nil {
-				return fmt.Errorf("failed to connect to sources API at %s: %w. "+
-					"Ensure the gosources service is running and accessible", c.baseURL, err)
+				return nil, fmt.Errorf("%w: failed to connect to sources API at %s: %w. "+
+					"Ensure the gosources service is running and accessible", ErrUnavailable, c.baseURL, err)
 			}
 		}
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read the response body
-	body, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		return fmt.Errorf("failed to read response body: %w", readErr)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 
 	// Check for error status codes
 	const minErrorStatusCode = 400
 	if resp.StatusCode >= minErrorStatusCode {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(body)}
 		var errResp ErrorResponse
-		if jsonErr := json.Unmarshal(body, &errResp); jsonErr == nil && errResp.Error != "" {
-			return fmt.Errorf("API error (status %d): %s - %s", resp.StatusCode, errResp.Error, errResp.Message)
+		if jsonErr := json.Unmarshal(body, &errResp); jsonErr == nil {
+			apiErr.Response = errResp
 		}
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, apiErr
 	}
 
-	// For DELETE requests with 204 No Content, don't try to decode
-	if resp.StatusCode == http.StatusNoContent || result == nil {
-		return nil
+	// For DELETE requests with 204 No Content and unchanged resources, don't try to decode
+	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified || result == nil {
+		return resp, nil
 	}
 
 	// Decode the response
 	if unmarshalErr := json.Unmarshal(body, result); unmarshalErr != nil {
-		return fmt.Errorf("failed to decode response: %w", unmarshalErr)
+		return nil, fmt.Errorf("failed to decode response: %w", unmarshalErr)
 	}
 
-	return nil
+	return resp, nil
+}
+
+// send sends one attempt of a request and reads the response body.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	attempt := req
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		attempt = req.Clone(req.Context())
+		attempt.Body = body
+	}
+
+	resp, err := c.httpClient.Do(attempt)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read the response body
+	body, readErr := io.ReadAll(resp.Body)
+	if readErr != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", readErr)
+	}
+	return resp, body, nil
+}
+
+// authenticate adds the configured credentials to a request.
+func (c *Client) authenticate(req *http.Request) {
+	switch {
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	case c.apiKey != "":
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
 }
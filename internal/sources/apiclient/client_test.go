@@ -0,0 +1,174 @@
+package apiclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientAuthenticatesAndReportsTypedErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(apiclient.ErrorResponse{Error: "invalid token"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(apiclient.ListSourcesResponse{
+			Sources: []apiclient.APISource{{ID: "1", Name: "Sudbury Star"}},
+		})
+	}))
+	defer server.Close()
+
+	client := apiclient.NewClient(apiclient.WithBaseURL(server.URL), apiclient.WithBearerToken("secret"))
+	apiSources, err := client.ListSources(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, apiSources, 1)
+
+	client = apiclient.NewClient(apiclient.WithBaseURL(server.URL), apiclient.WithBearerToken("wrong"))
+	_, err = client.ListSources(t.Context())
+	require.ErrorIs(t, err, apiclient.ErrUnauthorized)
+	assert.Contains(t, err.Error(), "unauthorized (status 401): invalid token")
+
+	var apiErr *apiclient.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
+
+func TestClientRetriesServerErrors(t *testing.T) {
+	t.Parallel()
+
+	var gets, posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			posts.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if gets.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "key", r.Header.Get("X-API-Key"))
+		_ = json.NewEncoder(w).Encode(apiclient.APISource{ID: "1", Name: "Sudbury Star"})
+	}))
+	defer server.Close()
+
+	client := apiclient.NewClient(
+		apiclient.WithBaseURL(server.URL),
+		apiclient.WithAPIKey("key"),
+		apiclient.WithRetry(3, time.Millisecond, 5*time.Millisecond),
+	)
+	source, err := client.GetSource(t.Context(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, "Sudbury Star", source.Name)
+	assert.Equal(t, int32(3), gets.Load())
+
+	// Creating a source is not retried, as it may already have been created
+	_, err = client.CreateSource(t.Context(), &apiclient.APISource{Name: "Timmins Today"})
+	require.ErrorIs(t, err, apiclient.ErrServer)
+	assert.Equal(t, int32(1), posts.Load())
+}
+
+func TestClientListsSourcesPageByPage(t *testing.T) {
+	t.Parallel()
+
+	const total = 5
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		response := apiclient.ListSourcesResponse{Total: total}
+		for i := offset; i < total && i < offset+limit; i++ {
+			response.Sources = append(response.Sources, apiclient.APISource{ID: strconv.Itoa(i)})
+		}
+		response.Count = len(response.Sources)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := apiclient.NewClient(apiclient.WithBaseURL(server.URL), apiclient.WithPageSize(2))
+	apiSources, err := client.ListSources(t.Context())
+	require.NoError(t, err)
+	require.Len(t, apiSources, total)
+	assert.Equal(t, "4", apiSources[4].ID)
+}
+
+func TestClientOpensCircuitAfterRepeatedFailures(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(apiclient.ListSourcesResponse{})
+	}))
+	defer server.Close()
+
+	const cooldown = 50 * time.Millisecond
+	client := apiclient.NewClient(apiclient.WithBaseURL(server.URL), apiclient.WithCircuitBreaker(2, cooldown))
+	for range 2 {
+		_, err := client.ListSources(t.Context())
+		require.ErrorIs(t, err, apiclient.ErrServer)
+	}
+
+	_, err := client.ListSources(t.Context())
+	require.ErrorIs(t, err, apiclient.ErrCircuitOpen)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// After the cooldown a trial request is let through and closes the circuit
+	healthy.Store(true)
+	time.Sleep(cooldown)
+	_, err = client.ListSources(t.Context())
+	require.NoError(t, err)
+	_, err = client.ListSources(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), requests.Load())
+}
+
+func TestClientDoesNotCountCancelledRequests(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		// The caller gives up while the request is being handled
+		cancel()
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := apiclient.NewClient(
+		apiclient.WithBaseURL(server.URL),
+		apiclient.WithRetry(1, time.Millisecond, time.Millisecond),
+		apiclient.WithCircuitBreaker(1, time.Minute),
+	)
+	_, err := client.ListSources(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The circuit is still closed, so the next request reaches the API
+	_, err = client.ListSources(t.Context())
+	require.ErrorIs(t, err, apiclient.ErrServer)
+	assert.Equal(t, int32(3), requests.Load())
+
+	_, err = client.ListSources(t.Context())
+	require.ErrorIs(t, err, apiclient.ErrCircuitOpen)
+}
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"time"
 
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
 	"github.com/jonesrussell/gocrawl/internal/sources/types"
 )
 
@@ -26,6 +27,12 @@ func ConvertAPISourceToConfig(apiSource *APISource) (*types.SourceConfig, error)
 		}
 	}
 
+	// Parse retention period
+	retention, err := configtypes.ParseRetention(apiSource.Retention)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse URL to get domain
 	parsedURL, err := url.Parse(apiSource.URL)
 	if err != nil {
@@ -44,6 +51,7 @@ func ConvertAPISourceToConfig(apiSource *APISource) (*types.SourceConfig, error)
 	}
 
 	return &types.SourceConfig{
+		ID:             apiSource.ID,
 		Name:           apiSource.Name,
 		URL:            apiSource.URL,
 		AllowedDomains: []string{domain},
@@ -54,6 +62,24 @@ func ConvertAPISourceToConfig(apiSource *APISource) (*types.SourceConfig, error)
 		Index:          apiSource.PageIndex, // For backward compatibility
 		ArticleIndex:   apiSource.ArticleIndex,
 		PageIndex:      apiSource.PageIndex,
+		Retention:      retention,
+		MaxDocs:        apiSource.MaxDocs,
+		URLRewrites:    convertAPIURLRewrites(apiSource.URLRewrites),
+		Extraction:     apiSource.Extraction,
+		StructuredData: apiSource.StructuredData,
+		Locale:         apiSource.Locale,
+		Timezone:       apiSource.Timezone,
+		CityName:       apiSource.CityName,
+		Region:         apiSource.Region,
+		Latitude:       apiSource.Latitude,
+		Longitude:      apiSource.Longitude,
+		Group:          apiSource.GroupID,
+		Tags:           apiSource.Tags,
+		Enrichers:      apiSource.Enrichers,
+		Crawler:        apiSource.Crawler,
+		Enabled:        apiSource.Enabled,
+		DisabledReason: apiSource.DisabledReason,
+		DisabledUntil:  apiSource.DisabledUntil,
 		Selectors: types.SelectorConfig{
 			Article: convertAPIArticleSelectors(apiSource.Selectors.Article),
 			List:    convertAPIListSelectors(apiSource.Selectors.List),
@@ -126,14 +152,32 @@ func ConvertConfigToAPISource(config *types.SourceConfig) *APISource {
 	}
 
 	return &APISource{
-		Name:         config.Name,
-		URL:          config.URL,
-		ArticleIndex: config.ArticleIndex,
-		PageIndex:    config.PageIndex,
-		RateLimit:    config.RateLimit.String(),
-		MaxDepth:     config.MaxDepth,
-		Time:         config.Time,
-		Enabled:      true,
+		ID:             config.ID,
+		Name:           config.Name,
+		URL:            config.URL,
+		ArticleIndex:   config.ArticleIndex,
+		PageIndex:      config.PageIndex,
+		RateLimit:      config.RateLimit.String(),
+		MaxDepth:       config.MaxDepth,
+		Time:           config.Time,
+		Enabled:        config.Enabled,
+		DisabledReason: config.DisabledReason,
+		DisabledUntil:  config.DisabledUntil,
+		Retention:      configtypes.FormatRetention(config.Retention),
+		MaxDocs:        config.MaxDocs,
+		URLRewrites:    convertURLRewritesToAPI(config.URLRewrites),
+		Extraction:     config.Extraction,
+		StructuredData: config.StructuredData,
+		Locale:         config.Locale,
+		Timezone:       config.Timezone,
+		CityName:       config.CityName,
+		Region:         config.Region,
+		Latitude:       config.Latitude,
+		Longitude:      config.Longitude,
+		GroupID:        config.Group,
+		Tags:           config.Tags,
+		Enrichers:      config.Enrichers,
+		Crawler:        config.Crawler,
 		Selectors: APISelectors{
 			Article: convertArticleSelectorsToAPI(config.Selectors.Article),
 			List:    convertListSelectorsToAPI(config.Selectors.List),
@@ -198,3 +242,33 @@ func convertPageSelectorsToAPI(sel types.PageSelectors) APIPageSelectors {
 		Exclude:       sel.Exclude,
 	}
 }
+
+// convertAPIURLRewrites converts API URL rewrite rules to config rewrite rules.
+func convertAPIURLRewrites(rewrites []APIURLRewrite) configtypes.URLRewrites {
+	if len(rewrites) == 0 {
+		return nil
+	}
+	result := make(configtypes.URLRewrites, 0, len(rewrites))
+	for _, rewrite := range rewrites {
+		result = append(result, configtypes.URLRewrite{
+			Pattern:     rewrite.Pattern,
+			Replacement: rewrite.Replacement,
+		})
+	}
+	return result
+}
+
+// convertURLRewritesToAPI converts config URL rewrite rules to API rewrite rules.
+func convertURLRewritesToAPI(rewrites configtypes.URLRewrites) []APIURLRewrite {
+	if len(rewrites) == 0 {
+		return nil
+	}
+	result := make([]APIURLRewrite, 0, len(rewrites))
+	for _, rewrite := range rewrites {
+		result = append(result, APIURLRewrite{
+			Pattern:     rewrite.Pattern,
+			Replacement: rewrite.Replacement,
+		})
+	}
+	return result
+}
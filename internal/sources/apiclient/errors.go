@@ -0,0 +1,80 @@
+// Package apiclient provides HTTP client functionality for interacting with the gosources API.
+package apiclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrBadRequest indicates the API rejected a malformed request
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized indicates missing or invalid credentials
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden indicates credentials without access to the resource
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound indicates the resource does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrConflict indicates the request conflicts with the current state of the resource
+	ErrConflict = errors.New("conflict")
+	// ErrRateLimited indicates the client sent too many requests
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServer indicates the API failed to handle the request
+	ErrServer = errors.New("server error")
+	// ErrUnavailable indicates the API could not be reached
+	ErrUnavailable = errors.New("sources API unavailable")
+	// ErrCircuitOpen indicates requests are rejected after repeated failures
+	ErrCircuitOpen = errors.New("sources API circuit breaker open")
+)
+
+// APIError is an error response of the API. It wraps the sentinel error of its
+// status, so callers can test for ErrUnauthorized and the like with errors.Is.
+type APIError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Response is the decoded error body, empty when the body was not JSON
+	Response ErrorResponse
+	// Body is the raw response body
+	Body string
+}
+
+// Error returns the kind of failure followed by the details of the API.
+func (e *APIError) Error() string {
+	kind := http.StatusText(e.StatusCode)
+	if sentinel := e.Unwrap(); sentinel != nil {
+		kind = sentinel.Error()
+	}
+	switch {
+	case e.Response.Error != "" && e.Response.Message != "":
+		return fmt.Sprintf("%s (status %d): %s - %s", kind, e.StatusCode, e.Response.Error, e.Response.Message)
+	case e.Response.Error != "":
+		return fmt.Sprintf("%s (status %d): %s", kind, e.StatusCode, e.Response.Error)
+	case e.Body != "":
+		return fmt.Sprintf("%s (status %d): %s", kind, e.StatusCode, e.Body)
+	default:
+		return fmt.Sprintf("%s (status %d)", kind, e.StatusCode)
+	}
+}
+
+// Unwrap returns the sentinel error of the status, or nil for other statuses.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrConflict
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrServer
+	default:
+		return nil
+	}
+}
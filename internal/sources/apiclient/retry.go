@@ -0,0 +1,60 @@
+// Package apiclient provides HTTP client functionality for interacting with the gosources API.
+package apiclient
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// retryPolicy retries requests that failed to connect or got a 5xx response,
+// waiting exponentially longer between attempts.
+type retryPolicy struct {
+	maxRetries  int
+	initialWait time.Duration
+	maxWait     time.Duration
+}
+
+// backoff returns the wait before retry number attempt, counted from 0. The
+// wait doubles with every attempt up to maxWait, and a random half of it is
+// dropped so that clients failing together do not retry together.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	wait := p.initialWait
+	for i := 0; i < attempt && wait < p.maxWait; i++ {
+		wait *= 2
+	}
+	wait = min(wait, p.maxWait)
+	if half := int64(wait / 2); half > 0 {
+		wait -= time.Duration(rand.Int64N(half)) //nolint:gosec // jitter needs no cryptographic randomness
+	}
+	return wait
+}
+
+// retryable reports whether a request is worth retrying after its response or
+// error. Requests that create sources are only retried when they never reached
+// the API, as retrying them after a 5xx could create a source twice.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		if req.Method == http.MethodPost {
+			return isDialError(err)
+		}
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError && req.Method != http.MethodPost
+}
+
+// isDialError reports whether err happened while connecting.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && urlErr.Op == "dial"
+}
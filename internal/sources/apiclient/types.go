@@ -5,20 +5,41 @@ import "time"
 
 // APISource represents a source as returned by the gosources API.
 type APISource struct {
-	ID           string       `json:"id,omitempty"`
-	Name         string       `json:"name"`
-	URL          string       `json:"url"`
-	ArticleIndex string       `json:"article_index"`
-	PageIndex    string       `json:"page_index"`
-	RateLimit    string       `json:"rate_limit,omitempty"`
-	MaxDepth     int          `json:"max_depth,omitempty"`
-	Time         []string     `json:"time,omitempty"`
-	Enabled      bool         `json:"enabled"`
-	CityName     string       `json:"city_name,omitempty"`
-	GroupID      string       `json:"group_id,omitempty"`
-	Selectors    APISelectors `json:"selectors"`
-	CreatedAt    *time.Time   `json:"created_at,omitempty"`
-	UpdatedAt    *time.Time   `json:"updated_at,omitempty"`
+	ID             string          `json:"id,omitempty"`
+	Name           string          `json:"name"`
+	URL            string          `json:"url"`
+	ArticleIndex   string          `json:"article_index"`
+	PageIndex      string          `json:"page_index"`
+	RateLimit      string          `json:"rate_limit,omitempty"`
+	MaxDepth       int             `json:"max_depth,omitempty"`
+	Time           []string        `json:"time,omitempty"`
+	Enabled        bool            `json:"enabled"`
+	DisabledReason string          `json:"disabled_reason,omitempty"`
+	DisabledUntil  *time.Time      `json:"disabled_until,omitempty"`
+	CityName       string          `json:"city_name,omitempty"`
+	Region         string          `json:"region,omitempty"`
+	Latitude       *float64        `json:"latitude,omitempty"`
+	Longitude      *float64        `json:"longitude,omitempty"`
+	GroupID        string          `json:"group_id,omitempty"`
+	Retention      string          `json:"retention,omitempty"`
+	MaxDocs        int             `json:"max_docs,omitempty"`
+	URLRewrites    []APIURLRewrite `json:"url_rewrites,omitempty"`
+	Extraction     string          `json:"extraction,omitempty"`
+	StructuredData string          `json:"structured_data,omitempty"`
+	Locale         string          `json:"locale,omitempty"`
+	Timezone       string          `json:"timezone,omitempty"`
+	Tags           []string        `json:"tags,omitempty"`
+	Enrichers      []string        `json:"enrichers,omitempty"`
+	Crawler        map[string]any  `json:"crawler,omitempty"`
+	Selectors      APISelectors    `json:"selectors"`
+	CreatedAt      *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time      `json:"updated_at,omitempty"`
+}
+
+// APIURLRewrite represents a URL rewrite rule in the API.
+type APIURLRewrite struct {
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement"`
 }
 
 // APISelectors represents the selectors structure in the API.
@@ -83,6 +104,8 @@ type APIPageSelectors struct {
 type ListSourcesResponse struct {
 	Sources []APISource `json:"sources"`
 	Count   int         `json:"count"`
+	// Total is the number of sources across all pages, when the API pages
+	Total int `json:"total,omitempty"`
 }
 
 // ErrorResponse represents an error response from the API.
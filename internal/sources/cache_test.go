@@ -0,0 +1,124 @@
+package sources_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
+	"github.com/jonesrussell/gocrawl/internal/sources/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPILoaderFallsBackToCache(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(apiclient.ListSourcesResponse{
+			Sources: []apiclient.APISource{{
+				ID: "42", Name: "Sudbury Star", URL: "https://www.thesudburystar.com", Enabled: true,
+			}},
+			Count: 1,
+		})
+	}))
+
+	cache := filepath.Join(t.TempDir(), "cache", "sources.json")
+	apiLoader := loader.NewAPILoader(server.URL, logger.NewNoOp())
+	apiLoader.SetCache(cache, time.Hour)
+	configs, err := apiLoader.LoadSources()
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+
+	// The API goes away; the cached response stands in for it
+	server.Close()
+	configs, err = apiLoader.LoadSources()
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "Sudbury Star", configs[0].Name)
+
+	configs, snapshot, err := loader.LoadSnapshot(cache, nil)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, server.URL, snapshot.APIURL)
+	assert.WithinDuration(t, time.Now(), snapshot.FetchedAt, time.Minute)
+
+	// A snapshot older than the allowed staleness is refused
+	apiSources, err := snapshot.APISources()
+	require.NoError(t, err)
+	old, err := loader.NewSnapshot(server.URL, apiSources, time.Now().Add(-2*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, loader.WriteSnapshot(cache, old))
+	_, err = apiLoader.LoadSources()
+	require.ErrorIs(t, err, loader.ErrSnapshotStale)
+
+	// So is one whose sources were changed by hand
+	data, err := os.ReadFile(cache)
+	require.NoError(t, err)
+	tampered := strings.Replace(string(data), "Sudbury Star", "Sudbury Stars", 1)
+	require.NoError(t, os.WriteFile(cache, []byte(tampered), 0o600))
+	_, err = loader.ReadSnapshot(cache)
+	require.ErrorIs(t, err, loader.ErrSnapshotChecksum)
+}
+
+func TestAPILoaderCacheFallbackErrors(t *testing.T) {
+	t.Parallel()
+
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if code := int(status.Load()); code != http.StatusOK {
+			w.WriteHeader(code)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(apiclient.ListSourcesResponse{
+			Sources: []apiclient.APISource{{
+				ID: "42", Name: "Sudbury Star", URL: "https://www.thesudburystar.com", Enabled: true,
+			}},
+			Count: 1,
+		})
+	}))
+	defer server.Close()
+
+	cache := filepath.Join(t.TempDir(), "sources.json")
+	apiLoader := loader.NewAPILoader(server.URL, logger.NewNoOp(), apiclient.WithRetry(0, 0, 0))
+	apiLoader.SetCache(cache, 0)
+	_, err := apiLoader.LoadSources()
+	require.NoError(t, err)
+
+	// Server errors fall back to the cache
+	status.Store(http.StatusInternalServerError)
+	configs, err := apiLoader.LoadSources()
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+
+	// Errors about the request itself are returned
+	status.Store(http.StatusUnauthorized)
+	_, err = apiLoader.LoadSources()
+	require.ErrorIs(t, err, apiclient.ErrUnauthorized)
+	status.Store(http.StatusNotFound)
+	_, err = apiLoader.LoadSources()
+	require.ErrorIs(t, err, apiclient.ErrNotFound)
+
+	// A snapshot of another API is refused
+	status.Store(http.StatusInternalServerError)
+	_, snapshot, err := loader.LoadSnapshot(cache, nil)
+	require.NoError(t, err)
+	apiSources, err := snapshot.APISources()
+	require.NoError(t, err)
+	other, err := loader.NewSnapshot("https://sources.staging.example.com", apiSources, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, loader.WriteSnapshot(cache, other))
+	_, err = apiLoader.LoadSources()
+	require.ErrorIs(t, err, loader.ErrSnapshotAPIURL)
+	require.ErrorIs(t, err, apiclient.ErrServer)
+}
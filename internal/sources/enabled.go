@@ -0,0 +1,174 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
+	"gopkg.in/yaml.v3"
+)
+
+// sourcesFileIndent is the indentation of rewritten sources files.
+const sourcesFileIndent = 2
+
+// Toggle is a change of the enabled state of a source.
+type Toggle struct {
+	// Enabled is the new state of the source
+	Enabled bool
+	// Reason records why the source is disabled
+	Reason string
+	// Until ends a disable at the given time; nil disables the source until it is re-enabled
+	Until *time.Time
+}
+
+// FilterEnabled returns the sources that may be crawled at the given time.
+func FilterEnabled(configs []Config, now time.Time) []Config {
+	var filtered []Config
+	for i := range configs {
+		if configs[i].IsEnabled(now) {
+			filtered = append(filtered, configs[i])
+		}
+	}
+	return filtered
+}
+
+// SetEnabledInAPI applies a toggle to a source through the gosources API.
+func SetEnabledInAPI(ctx context.Context, client *apiclient.Client, name string, toggle Toggle) error {
+	apiSources, err := client.ListSources(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list sources: %w", err)
+	}
+
+	for i := range apiSources {
+		source := &apiSources[i]
+		if source.Name != name {
+			continue
+		}
+		if source.ID == "" {
+			return fmt.Errorf("source %s has no ID", name)
+		}
+
+		source.Enabled = toggle.Enabled
+		source.DisabledReason = ""
+		source.DisabledUntil = nil
+		if !toggle.Enabled {
+			source.DisabledReason = toggle.Reason
+			source.DisabledUntil = toggle.Until
+		}
+
+		if _, updateErr := client.UpdateSource(ctx, source.ID, source); updateErr != nil {
+			return fmt.Errorf("failed to update source %s: %w", name, updateErr)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+}
+
+// SetEnabledInFile applies a toggle to a source of a YAML sources file. The
+// rest of the file, comments included, is left as it is.
+func SetEnabledInFile(path, name string, toggle Toggle) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat sources file: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read sources file: %w", err)
+	}
+
+	var doc yaml.Node
+	if unmarshalErr := yaml.Unmarshal(data, &doc); unmarshalErr != nil {
+		return fmt.Errorf("failed to parse sources file: %w", unmarshalErr)
+	}
+
+	source, err := findSourceNode(&doc, name)
+	if err != nil {
+		return err
+	}
+
+	setMappingValue(source, "enabled", yamlScalar("!!bool", fmt.Sprint(toggle.Enabled)))
+	if toggle.Enabled || toggle.Reason == "" {
+		deleteMappingValue(source, "disabled_reason")
+	} else {
+		setMappingValue(source, "disabled_reason", yamlScalar("!!str", toggle.Reason))
+	}
+	if toggle.Enabled || toggle.Until == nil {
+		deleteMappingValue(source, "disabled_until")
+	} else {
+		setMappingValue(source, "disabled_until", yamlScalar("!!timestamp", toggle.Until.Format(time.RFC3339)))
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(sourcesFileIndent)
+	if encodeErr := encoder.Encode(&doc); encodeErr != nil {
+		return fmt.Errorf("failed to encode sources file: %w", encodeErr)
+	}
+	if writeErr := os.WriteFile(path, out.Bytes(), info.Mode().Perm()); writeErr != nil {
+		return fmt.Errorf("failed to write sources file: %w", writeErr)
+	}
+	return nil
+}
+
+// findSourceNode returns the mapping node of a named source in a sources file.
+func findSourceNode(doc *yaml.Node, name string) (*yaml.Node, error) {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, errors.New("sources file is empty")
+	}
+
+	sourcesNode := mappingValue(doc.Content[0], "sources")
+	if sourcesNode == nil || sourcesNode.Kind != yaml.SequenceNode {
+		return nil, errors.New("sources file has no sources list")
+	}
+
+	for _, source := range sourcesNode.Content {
+		if nameNode := mappingValue(source, "name"); nameNode != nil && nameNode.Value == name {
+			return source, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+}
+
+// mappingValue returns the value of a key of a mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets the value of a key of a mapping node, adding the key when it is missing.
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, yamlScalar("!!str", key), value)
+}
+
+// deleteMappingValue removes a key from a mapping node.
+func deleteMappingValue(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// yamlScalar creates a scalar node.
+func yamlScalar(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
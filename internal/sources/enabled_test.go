@@ -0,0 +1,111 @@
+package sources_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
+	"github.com/jonesrussell/gocrawl/internal/sources/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterEnabled(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Hour)
+	pending := now.Add(time.Hour)
+	configs := []sources.Config{
+		{Name: "Sudbury Star", Enabled: true},
+		{Name: "Timmins Today", DisabledReason: "paywall"},
+		{Name: "Elliot Lake Standard", DisabledUntil: &expired},
+		{Name: "Mid-North Monitor", DisabledUntil: &pending},
+	}
+
+	enabled := sources.FilterEnabled(configs, now)
+	require.Len(t, enabled, 2)
+	assert.Equal(t, "Sudbury Star", enabled[0].Name)
+	assert.Equal(t, "Elliot Lake Standard", enabled[1].Name)
+}
+
+const sourcesFile = `# Northern Ontario sources
+sources:
+  - name: Sudbury Star
+    url: https://www.thesudburystar.com
+  - name: Timmins Today # local news
+    url: https://www.timminstoday.com
+`
+
+func TestSetEnabledInFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sources.yml")
+	require.NoError(t, os.WriteFile(path, []byte(sourcesFile), 0o600))
+
+	until := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, sources.SetEnabledInFile(path, "Timmins Today",
+		sources.Toggle{Reason: "site redesign", Until: &until}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Northern Ontario sources")
+	assert.Contains(t, string(data), "# local news")
+
+	l, err := loader.NewLoader(path)
+	require.NoError(t, err)
+	configs, err := l.LoadSources()
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.True(t, configs[0].Enabled)
+	assert.False(t, configs[1].Enabled)
+	assert.Equal(t, "site redesign", configs[1].DisabledReason)
+	require.NotNil(t, configs[1].DisabledUntil)
+	assert.True(t, until.Equal(*configs[1].DisabledUntil))
+
+	require.NoError(t, sources.SetEnabledInFile(path, "Timmins Today", sources.Toggle{Enabled: true}))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "disabled_reason")
+	assert.NotContains(t, string(data), "disabled_until")
+
+	err = sources.SetEnabledInFile(path, "Unknown", sources.Toggle{})
+	assert.ErrorIs(t, err, sources.ErrSourceNotFound)
+}
+
+func TestSetEnabledInAPI(t *testing.T) {
+	t.Parallel()
+
+	var updated apiclient.APISource
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(apiclient.ListSourcesResponse{
+				Sources: []apiclient.APISource{{ID: "42", Name: "Sudbury Star", Enabled: true}},
+				Count:   1,
+			})
+		case http.MethodPut:
+			assert.Equal(t, "/42", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+			_ = json.NewEncoder(w).Encode(updated)
+		}
+	}))
+	defer server.Close()
+
+	client := apiclient.NewClient(apiclient.WithBaseURL(server.URL))
+	require.NoError(t, sources.SetEnabledInAPI(context.Background(), client, "Sudbury Star",
+		sources.Toggle{Reason: "robots.txt changed"}))
+	assert.False(t, updated.Enabled)
+	assert.Equal(t, "robots.txt changed", updated.DisabledReason)
+
+	err := sources.SetEnabledInAPI(context.Background(), client, "Unknown", sources.Toggle{Enabled: true})
+	assert.ErrorIs(t, err, sources.ErrSourceNotFound)
+}
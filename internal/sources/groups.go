@@ -0,0 +1,52 @@
+package sources
+
+import (
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+)
+
+// FilterByGroup returns the sources that belong to a group.
+func FilterByGroup(configs []Config, group string) []Config {
+	var filtered []Config
+	for i := range configs {
+		if configs[i].InGroup(group) {
+			filtered = append(filtered, configs[i])
+		}
+	}
+	return filtered
+}
+
+// ApplyGroups applies the settings of the groups each source belongs to. A
+// group's minimum rate limit raises the delay of each faster source on its own;
+// sources in several groups get the slowest minimum.
+func ApplyGroups(configs []Config, groups map[string]crawlerconfig.GroupConfig) {
+	for i := range configs {
+		for _, name := range configs[i].Groups() {
+			group, ok := groups[name]
+			if ok && group.MinRateLimit > configs[i].RateLimit {
+				configs[i].RateLimit = group.MinRateLimit
+			}
+		}
+	}
+}
+
+// ScheduledTimes returns the times of day at which a source is crawled: its
+// own times followed by those of its groups, without repeats.
+func ScheduledTimes(source *Config, groups map[string]crawlerconfig.GroupConfig) []string {
+	times := make([]string, 0, len(source.Time))
+	seen := make(map[string]bool)
+	add := func(t string) {
+		if !seen[t] {
+			seen[t] = true
+			times = append(times, t)
+		}
+	}
+	for _, t := range source.Time {
+		add(t)
+	}
+	for _, name := range source.Groups() {
+		for _, t := range groups[name].Time {
+			add(t)
+		}
+	}
+	return times
+}
@@ -0,0 +1,52 @@
+package sources_test
+
+import (
+	"testing"
+	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/stretchr/testify/assert"
+)
+
+func groupedSources() []sources.Config {
+	return []sources.Config{
+		{Name: "Sudbury Star", Group: "ontario-news", Tags: []string{"northern"}, RateLimit: time.Second},
+		{Name: "Timmins Today", Tags: []string{"northern", "ontario-news"}, RateLimit: 5 * time.Second},
+		{Name: "Winnipeg Free Press", Group: "prairies", RateLimit: time.Second},
+	}
+}
+
+func TestFilterByGroup(t *testing.T) {
+	t.Parallel()
+
+	configs := groupedSources()
+	assert.Equal(t, []string{"ontario-news", "northern"}, configs[0].Groups())
+
+	members := sources.FilterByGroup(configs, "ontario-news")
+	assert.Len(t, members, 2)
+	assert.Equal(t, "Timmins Today", members[1].Name)
+
+	assert.Len(t, sources.FilterByGroup(configs, "northern"), 2)
+	assert.Empty(t, sources.FilterByGroup(configs, "atlantic"))
+	assert.Empty(t, sources.FilterByGroup(configs, ""))
+}
+
+func TestApplyGroups(t *testing.T) {
+	t.Parallel()
+
+	configs := groupedSources()
+	groups := map[string]crawlerconfig.GroupConfig{
+		"ontario-news": {MinRateLimit: 2 * time.Second, Time: []string{"06:00"}},
+		"northern":     {MinRateLimit: 3 * time.Second, Time: []string{"06:00", "18:00"}},
+	}
+	sources.ApplyGroups(configs, groups)
+
+	assert.Equal(t, 3*time.Second, configs[0].RateLimit, "the slowest group minimum applies")
+	assert.Equal(t, 5*time.Second, configs[1].RateLimit, "slower sources keep their own limit")
+	assert.Equal(t, time.Second, configs[2].RateLimit)
+
+	configs[0].Time = []string{"12:00"}
+	assert.Equal(t, []string{"12:00", "06:00", "18:00"}, sources.ScheduledTimes(&configs[0], groups))
+	assert.Empty(t, sources.ScheduledTimes(&configs[2], groups))
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
 	"github.com/jonesrussell/gocrawl/internal/logger"
 	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
 )
@@ -13,27 +15,128 @@ import (
 // APILoader handles loading source configurations from the gosources API.
 type APILoader struct {
 	client *apiclient.Client
+	apiURL string
 	logger logger.Interface
+
+	cachePath    string
+	maxStaleness time.Duration
 }
 
-// NewAPILoader creates a new APILoader instance.
-func NewAPILoader(apiURL string, log logger.Interface) *APILoader {
-	client := apiclient.NewClient(apiclient.WithBaseURL(apiURL))
+// NewAPILoader creates a new APILoader instance. The options configure its API client.
+func NewAPILoader(apiURL string, log logger.Interface, opts ...apiclient.Option) *APILoader {
+	client := apiclient.NewClient(append([]apiclient.Option{apiclient.WithBaseURL(apiURL)}, opts...)...)
 	return &APILoader{
 		client: client,
+		apiURL: apiURL,
 		logger: log,
 	}
 }
 
-// LoadSources loads all sources from the gosources API.
+// SetCache keeps the last successful response in a snapshot file, used in
+// place of the API while it cannot be reached and the snapshot is no older
+// than maxStaleness. A maxStaleness of 0 accepts snapshots of any age.
+func (l *APILoader) SetCache(path string, maxStaleness time.Duration) {
+	l.cachePath = path
+	l.maxStaleness = maxStaleness
+}
+
+// LoadSources loads all sources from the gosources API, falling back to the
+// cache when one is set and the API cannot serve the request. Errors the API
+// reports about the request itself, such as bad credentials, are returned.
 func (l *APILoader) LoadSources() ([]Config, error) {
 	ctx := context.Background()
 
 	apiSources, err := l.client.ListSources(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list sources from API: %w", err)
+		if l.cachePath == "" || !unavailable(err) {
+			return nil, fmt.Errorf("failed to list sources from API: %w", err)
+		}
+		return l.loadCache(err)
+	}
+
+	l.saveCache(apiSources)
+	return l.convertAPISources(apiSources)
+}
+
+// LoadSourcesIfModified loads all sources from the gosources API unless they are
+// unchanged since the response that carried etag. It returns the ETag of the
+// current set, and modified is false when the sources are unchanged.
+func (l *APILoader) LoadSourcesIfModified(
+	ctx context.Context,
+	etag string,
+) (configs []Config, currentETag string, modified bool, err error) {
+	var apiSources []apiclient.APISource
+	apiSources, currentETag, modified, err = l.client.ListSourcesIfModified(ctx, etag)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to list sources from API: %w", err)
+	}
+	if !modified {
+		return nil, currentETag, false, nil
+	}
+
+	l.saveCache(apiSources)
+	configs, err = l.convertAPISources(apiSources)
+	if err != nil {
+		return nil, "", false, err
+	}
+	return configs, currentETag, true, nil
+}
+
+// unavailable reports whether an API error means the API could not serve the
+// request, so that cached sources may stand in for it.
+func unavailable(err error) bool {
+	return errors.Is(err, apiclient.ErrUnavailable) ||
+		errors.Is(err, apiclient.ErrServer) ||
+		errors.Is(err, apiclient.ErrCircuitOpen)
+}
+
+// loadCache loads the cached sources after the API failed with apiErr. The
+// snapshot must have been fetched from the same API.
+func (l *APILoader) loadCache(apiErr error) ([]Config, error) {
+	configs, snapshot, err := LoadSnapshot(l.cachePath, l.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources from API: %w (no usable cache: %w)", apiErr, err)
+	}
+	if snapshot.APIURL != l.apiURL {
+		return nil, fmt.Errorf("failed to list sources from API: %w (%w: fetched from %s, not %s)",
+			apiErr, ErrSnapshotAPIURL, snapshot.APIURL, l.apiURL)
+	}
+
+	age := snapshot.Age(time.Now())
+	if l.maxStaleness > 0 && age > l.maxStaleness {
+		return nil, fmt.Errorf("failed to list sources from API: %w (%w: fetched %s ago, max_staleness is %s)",
+			apiErr, ErrSnapshotStale, age.Round(time.Second), l.maxStaleness)
+	}
+
+	if l.logger != nil {
+		l.logger.Warn("SOURCES API UNREACHABLE: crawling with cached sources, changes made since are missing",
+			"url", l.apiURL,
+			"cache", l.cachePath,
+			"fetched_at", snapshot.FetchedAt,
+			"age", age.Round(time.Second).String(),
+			"error", apiErr,
+		)
+	}
+	return configs, nil
+}
+
+// saveCache caches a successful response. Failing to cache is not fatal.
+func (l *APILoader) saveCache(apiSources []apiclient.APISource) {
+	if l.cachePath == "" || len(apiSources) == 0 {
+		return
+	}
+
+	snapshot, err := NewSnapshot(l.apiURL, apiSources, time.Now())
+	if err == nil {
+		err = WriteSnapshot(l.cachePath, snapshot)
 	}
+	if err != nil && l.logger != nil {
+		l.logger.Warn("Failed to cache sources", "cache", l.cachePath, "error", err)
+	}
+}
 
+// convertAPISources converts API sources to Config structs, skipping those that fail to convert.
+func (l *APILoader) convertAPISources(apiSources []apiclient.APISource) ([]Config, error) {
 	if len(apiSources) == 0 {
 		return nil, ErrNoSources
 	}
@@ -75,14 +178,33 @@ func convertAPISourceToConfig(apiSource *apiclient.APISource) (Config, error) {
 	}
 
 	return Config{
-		Name:         apiSource.Name,
-		URL:          apiSource.URL,
-		RateLimit:    apiSource.RateLimit,
-		MaxDepth:     apiSource.MaxDepth,
-		Time:         apiSource.Time,
-		ArticleIndex: apiSource.ArticleIndex,
-		PageIndex:    apiSource.PageIndex,
-		Index:        apiSource.PageIndex, // For backward compatibility
+		ID:             apiSource.ID,
+		Name:           apiSource.Name,
+		URL:            apiSource.URL,
+		RateLimit:      apiSource.RateLimit,
+		MaxDepth:       apiSource.MaxDepth,
+		Time:           apiSource.Time,
+		ArticleIndex:   apiSource.ArticleIndex,
+		PageIndex:      apiSource.PageIndex,
+		Index:          apiSource.PageIndex, // For backward compatibility
+		Retention:      apiSource.Retention,
+		MaxDocs:        apiSource.MaxDocs,
+		URLRewrites:    convertAPIURLRewrites(apiSource.URLRewrites),
+		Extraction:     apiSource.Extraction,
+		StructuredData: apiSource.StructuredData,
+		Locale:         apiSource.Locale,
+		Timezone:       apiSource.Timezone,
+		CityName:       apiSource.CityName,
+		Region:         apiSource.Region,
+		Latitude:       apiSource.Latitude,
+		Longitude:      apiSource.Longitude,
+		Group:          apiSource.GroupID,
+		Tags:           apiSource.Tags,
+		Enrichers:      apiSource.Enrichers,
+		Crawler:        apiSource.Crawler,
+		Enabled:        apiSource.Enabled,
+		DisabledReason: apiSource.DisabledReason,
+		DisabledUntil:  apiSource.DisabledUntil,
 		Selectors: SourceSelectors{
 			Article: convertAPIArticleSelectors(apiSource.Selectors.Article),
 			List:    convertAPIListSelectors(apiSource.Selectors.List),
@@ -147,3 +269,18 @@ func convertAPIPageSelectors(api apiclient.APIPageSelectors) PageSelectors {
 		Exclude:       api.Exclude,
 	}
 }
+
+// convertAPIURLRewrites converts API URL rewrite rules to loader rewrite rules.
+func convertAPIURLRewrites(rewrites []apiclient.APIURLRewrite) configtypes.URLRewrites {
+	if len(rewrites) == 0 {
+		return nil
+	}
+	result := make(configtypes.URLRewrites, 0, len(rewrites))
+	for _, rewrite := range rewrites {
+		result = append(result, configtypes.URLRewrite{
+			Pattern:     rewrite.Pattern,
+			Replacement: rewrite.Replacement,
+		})
+	}
+	return result
+}
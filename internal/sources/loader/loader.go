@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"time"
 
+	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
@@ -22,17 +23,38 @@ var (
 
 // Config represents a source configuration loaded from a file.
 type Config struct {
-	Name         string            `mapstructure:"name"`
-	URL          string            `mapstructure:"url"`
-	RateLimit    any               `mapstructure:"rate_limit"` // Can be string or number
-	MaxDepth     int               `mapstructure:"max_depth"`
-	Time         []string          `mapstructure:"time"`
-	ArticleIndex string            `mapstructure:"article_index"`
-	PageIndex    string            `mapstructure:"page_index"`
-	Index        string            `mapstructure:"index"`
-	Selectors    SourceSelectors   `mapstructure:"selectors"`
-	UserAgent    string            `mapstructure:"user_agent"`
-	Headers      map[string]string `mapstructure:"headers"`
+	ID             string                  `mapstructure:"id"`
+	Name           string                  `mapstructure:"name"`
+	URL            string                  `mapstructure:"url"`
+	RateLimit      any                     `mapstructure:"rate_limit"` // Can be string or number
+	MaxDepth       int                     `mapstructure:"max_depth"`
+	Time           []string                `mapstructure:"time"`
+	ArticleIndex   string                  `mapstructure:"article_index"`
+	PageIndex      string                  `mapstructure:"page_index"`
+	Index          string                  `mapstructure:"index"`
+	Selectors      SourceSelectors         `mapstructure:"selectors"`
+	UserAgent      string                  `mapstructure:"user_agent"`
+	Headers        map[string]string       `mapstructure:"headers"`
+	Retention      string                  `mapstructure:"retention"`
+	MaxDocs        int                     `mapstructure:"max_docs"`
+	URLRewrites    configtypes.URLRewrites `mapstructure:"url_rewrites"`
+	Rules          configtypes.Rules       `mapstructure:"rules"`
+	Extraction     string                  `mapstructure:"extraction"`
+	StructuredData string                  `mapstructure:"structured_data"`
+	Locale         string                  `mapstructure:"locale"`
+	Timezone       string                  `mapstructure:"timezone"`
+	CityName       string                  `mapstructure:"city_name"`
+	Region         string                  `mapstructure:"region"`
+	Latitude       *float64                `mapstructure:"latitude"`
+	Longitude      *float64                `mapstructure:"longitude"`
+	Group          string                  `mapstructure:"group"`
+	GroupID        string                  `mapstructure:"group_id"` // group as named by the sources API
+	Tags           []string                `mapstructure:"tags"`
+	Enrichers      []string                `mapstructure:"enrichers"`
+	Crawler        map[string]any          `mapstructure:"crawler"`
+	Enabled        bool                    `mapstructure:"enabled"`
+	DisabledReason string                  `mapstructure:"disabled_reason"`
+	DisabledUntil  *time.Time              `mapstructure:"disabled_until"`
 }
 
 // SourceSelectors defines the selectors for a source.
@@ -180,14 +202,30 @@ func (l *Loader) validateAndConvertSources(sources []map[string]any) ([]Config,
 	return configs, nil
 }
 
+// ValidateSource converts and validates a raw source as LoadSources does. A
+// source it returns an error for is skipped when the sources are loaded.
+func ValidateSource(src map[string]any) (Config, error) {
+	l := &Loader{}
+	cfg, err := l.convertToConfig(src)
+	if err != nil {
+		return Config{}, err
+	}
+	if validateErr := l.validateConfig(&cfg); validateErr != nil {
+		return Config{}, validateErr
+	}
+	return cfg, nil
+}
+
 // convertToConfig converts a raw source map to a Config struct.
 func (l *Loader) convertToConfig(src map[string]any) (Config, error) {
-	var cfg Config
+	// Sources are enabled unless the file says otherwise
+	cfg := Config{Enabled: true}
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		Result:           &cfg,
 		WeaklyTypedInput: true,
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToTimeHookFunc(time.RFC3339),
 			mapstructure.StringToSliceHookFunc(","),
 		),
 	})
@@ -198,6 +236,10 @@ func (l *Loader) convertToConfig(src map[string]any) (Config, error) {
 	if decodeErr := decoder.Decode(src); decodeErr != nil {
 		return Config{}, fmt.Errorf("failed to decode source: %w", decodeErr)
 	}
+	// Files written by sources pull name the group as the API does
+	if cfg.Group == "" {
+		cfg.Group = cfg.GroupID
+	}
 
 	return cfg, nil
 }
@@ -230,6 +272,34 @@ func (l *Loader) validateConfig(cfg *Config) error {
 		return fmt.Errorf("invalid time: %w", err)
 	}
 
+	if err := l.validateRetention(cfg); err != nil {
+		return fmt.Errorf("invalid retention: %w", err)
+	}
+
+	if err := cfg.URLRewrites.Validate(); err != nil {
+		return fmt.Errorf("invalid url_rewrites: %w", err)
+	}
+	if err := cfg.Rules.Validate(); err != nil {
+		return fmt.Errorf("invalid rules: %w", err)
+	}
+	if err := configtypes.ValidateExtraction(cfg.Extraction); err != nil {
+		return fmt.Errorf("invalid extraction: %w", err)
+	}
+	if err := configtypes.ValidateStructuredData(cfg.StructuredData); err != nil {
+		return fmt.Errorf("invalid structured_data: %w", err)
+	}
+	if err := configtypes.ValidateEnrichers(cfg.Enrichers); err != nil {
+		return fmt.Errorf("invalid enrichers: %w", err)
+	}
+	if err := configtypes.ValidateCoordinates(cfg.Latitude, cfg.Longitude); err != nil {
+		return fmt.Errorf("invalid location: %w", err)
+	}
+	if cfg.Timezone != "" {
+		if _, err := time.LoadLocation(cfg.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+		}
+	}
+
 	return nil
 }
 
@@ -286,6 +356,17 @@ func (l *Loader) validateTime(cfg *Config) error {
 	return nil
 }
 
+// validateRetention validates the retention period and document cap.
+func (l *Loader) validateRetention(cfg *Config) error {
+	if cfg.MaxDocs < 0 {
+		return errors.New("max_docs must be non-negative")
+	}
+	if _, err := configtypes.ParseRetention(cfg.Retention); err != nil {
+		return err
+	}
+	return nil
+}
+
 // newConfigLoader creates a new Viper instance for loading configuration.
 func newConfigLoader(path string) (*viper.Viper, error) {
 	v := viper.New()
@@ -0,0 +1,150 @@
+package loader
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
+)
+
+var (
+	// ErrSnapshotChecksum indicates a snapshot whose sources do not match its checksum
+	ErrSnapshotChecksum = errors.New("sources snapshot checksum mismatch")
+	// ErrSnapshotStale indicates a snapshot older than the allowed staleness
+	ErrSnapshotStale = errors.New("sources snapshot is too old")
+	// ErrSnapshotAPIURL indicates a snapshot fetched from another API
+	ErrSnapshotAPIURL = errors.New("sources snapshot is of another API")
+)
+
+// Snapshot is a saved ListSources response of the gosources API. The checksum
+// covers the sources, so a truncated or hand-edited snapshot is detected.
+type Snapshot struct {
+	// APIURL is the API the sources were fetched from
+	APIURL string `json:"api_url"`
+	// FetchedAt is when the sources were fetched
+	FetchedAt time.Time `json:"fetched_at"`
+	// Checksum is the SHA-256 of the compact JSON of the sources
+	Checksum string `json:"checksum"`
+	// Sources are the sources as the API returned them
+	Sources json.RawMessage `json:"sources"`
+}
+
+// NewSnapshot creates a snapshot of API sources.
+func NewSnapshot(apiURL string, apiSources []apiclient.APISource, fetchedAt time.Time) (*Snapshot, error) {
+	data, err := json.Marshal(apiSources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sources: %w", err)
+	}
+	checksum, err := snapshotChecksum(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{
+		APIURL:    apiURL,
+		FetchedAt: fetchedAt.UTC(),
+		Checksum:  checksum,
+		Sources:   data,
+	}, nil
+}
+
+// Age returns how long ago the sources were fetched.
+func (s *Snapshot) Age(now time.Time) time.Duration {
+	return now.Sub(s.FetchedAt)
+}
+
+// APISources decodes the sources of the snapshot.
+func (s *Snapshot) APISources() ([]apiclient.APISource, error) {
+	var apiSources []apiclient.APISource
+	if err := json.Unmarshal(s.Sources, &apiSources); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot sources: %w", err)
+	}
+	return apiSources, nil
+}
+
+// ReadSnapshot reads a snapshot file and verifies its checksum.
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sources snapshot: %w", err)
+	}
+
+	var snapshot Snapshot
+	if unmarshalErr := json.Unmarshal(data, &snapshot); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse sources snapshot: %w", unmarshalErr)
+	}
+	checksum, err := snapshotChecksum(snapshot.Sources)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != snapshot.Checksum {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotChecksum, path)
+	}
+	return &snapshot, nil
+}
+
+// WriteSnapshot writes a snapshot file. The file is replaced in one step, so
+// readers never see a partly written snapshot.
+func WriteSnapshot(path string, snapshot *Snapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sources snapshot: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if mkdirErr := os.MkdirAll(dir, 0o755); mkdirErr != nil {
+		return fmt.Errorf("failed to create sources snapshot directory: %w", mkdirErr)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create sources snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write sources snapshot: %w", writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("failed to write sources snapshot: %w", closeErr)
+	}
+	if renameErr := os.Rename(tmp.Name(), path); renameErr != nil {
+		return fmt.Errorf("failed to replace sources snapshot: %w", renameErr)
+	}
+	return nil
+}
+
+// LoadSnapshot loads the sources of a snapshot file. The logger parameter is
+// optional and can be nil.
+func LoadSnapshot(path string, log logger.Interface) ([]Config, *Snapshot, error) {
+	snapshot, err := ReadSnapshot(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	apiSources, err := snapshot.APISources()
+	if err != nil {
+		return nil, nil, err
+	}
+	configs, err := (&APILoader{logger: log}).convertAPISources(apiSources)
+	if err != nil {
+		return nil, nil, err
+	}
+	return configs, snapshot, nil
+}
+
+// snapshotChecksum hashes the compact form of JSON, so that indentation does not matter.
+func snapshotChecksum(data json.RawMessage) (string, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return "", fmt.Errorf("failed to checksum sources: %w", err)
+	}
+	sum := sha256.Sum256(compact.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
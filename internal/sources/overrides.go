@@ -0,0 +1,18 @@
+package sources
+
+import (
+	"fmt"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+)
+
+// ValidateCrawlerOverrides checks that the crawler overrides of every source
+// give a valid crawler configuration when merged with the global one.
+func ValidateCrawlerOverrides(configs []Config, crawlerCfg *crawlerconfig.Config) error {
+	for i := range configs {
+		if _, err := crawlerCfg.WithOverrides(configs[i].Crawler); err != nil {
+			return fmt.Errorf("source %s: %w", configs[i].Name, err)
+		}
+	}
+	return nil
+}
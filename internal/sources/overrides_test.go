@@ -0,0 +1,64 @@
+package sources_test
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/jonesrussell/gocrawl/internal/sources/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const overriddenSources = `sources:
+  - name: Sudbury Star
+    url: https://www.thesudburystar.com
+    article_index: sudbury_star_articles
+    page_index: sudbury_star_pages
+    enabled: true
+    crawler:
+      user_agent: star-bot/1.0
+      request_timeout: 10s
+      parallelism: 1
+      tls:
+        min_version: 772
+`
+
+func TestCrawlerOverrides(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sources.yml")
+	require.NoError(t, os.WriteFile(path, []byte(overriddenSources), 0o600))
+	l, err := loader.NewLoader(path)
+	require.NoError(t, err)
+	configs, err := l.LoadSources()
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+
+	global := crawlerconfig.New(crawlerconfig.WithUserAgent("gocrawl/1.0"))
+	global.Groups = map[string]crawlerconfig.GroupConfig{"ontario-news": {MinRateLimit: time.Second}}
+	merged, err := global.WithOverrides(configs[0].Crawler)
+	require.NoError(t, err)
+
+	assert.Equal(t, "star-bot/1.0", merged.UserAgent)
+	assert.Equal(t, 10*time.Second, merged.RequestTimeout)
+	assert.Equal(t, 1, merged.MaxConcurrency, "parallelism is an alias of max_concurrency")
+	assert.Equal(t, uint16(tls.VersionTLS13), merged.TLS.MinVersion)
+	assert.True(t, merged.TLS.PreferServerCipherSuites, "unset TLS settings are kept")
+	assert.Equal(t, crawlerconfig.DefaultMaxRetries, merged.MaxRetries)
+	assert.Equal(t, "gocrawl/1.0", global.UserAgent, "the global configuration is not changed")
+	assert.Equal(t, []string{
+		"max_concurrency", "request_timeout", "tls.min_version", "user_agent",
+	}, crawlerconfig.OverrideKeys(configs[0].Crawler))
+
+	// Unknown settings and invalid merged values are rejected
+	_, err = global.WithOverrides(map[string]any{"user_agnet": "typo"})
+	require.ErrorContains(t, err, "user_agnet")
+	invalid := []sources.Config{{Name: "Timmins Today", Crawler: map[string]any{"max_concurrency": 0}}}
+	require.ErrorContains(t, sources.ValidateCrawlerOverrides(invalid, global),
+		"source Timmins Today: invalid crawler overrides: max_concurrency must be positive")
+}
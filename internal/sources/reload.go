@@ -0,0 +1,228 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/sources/types"
+)
+
+// fileReloadDelay lets a burst of writes to the sources file settle before it is reloaded.
+const fileReloadDelay = 200 * time.Millisecond
+
+// Changes summarises how a reload changed the sources, by source name.
+type Changes struct {
+	Added   []string
+	Removed []string
+	Updated []string
+}
+
+// Empty reports whether nothing changed.
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Updated) == 0
+}
+
+// Diff compares two sets of sources by name. A source is updated when any of
+// its settings, selectors included, differ.
+func Diff(old, updated []Config) Changes {
+	var changes Changes
+	previous := make(map[string]*Config, len(old))
+	for i := range old {
+		previous[old[i].Name] = &old[i]
+	}
+
+	seen := make(map[string]bool, len(updated))
+	for i := range updated {
+		name := updated[i].Name
+		seen[name] = true
+		before, ok := previous[name]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, name)
+		case !reflect.DeepEqual(*before, updated[i]):
+			changes.Updated = append(changes.Updated, name)
+		}
+	}
+	for i := range old {
+		if !seen[old[i].Name] {
+			changes.Removed = append(changes.Removed, old[i].Name)
+		}
+	}
+
+	return changes
+}
+
+// Replace swaps in a new set of sources and returns what changed. Crawls in
+// flight keep the configuration they started with; later runs use the new one.
+func (s *Sources) Replace(configs []Config) Changes {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes := Diff(s.sources, configs)
+	s.sources = configs
+	if s.metrics == nil {
+		s.metrics = types.NewSourcesMetrics()
+	}
+	s.metrics.SourceCount = int64(len(configs))
+	s.metrics.LastUpdated = time.Now()
+
+	return changes
+}
+
+// Reloader keeps a source manager in step with the gosources API or a sources file.
+type Reloader struct {
+	sources *Sources
+	logger  logger.Interface
+	crawler *crawlerconfig.Config
+	api     crawlerconfig.SourcesAPIConfig
+	cache   crawlerconfig.SourcesCacheConfig
+}
+
+// NewReloader creates a reloader for a source manager. The group settings of
+// the crawler configuration are applied to every reloaded set and its crawler
+// overrides validated, as LoadSources does. crawlerCfg may be nil.
+func NewReloader(
+	sourceManager *Sources,
+	log logger.Interface,
+	crawlerCfg *crawlerconfig.Config,
+) *Reloader {
+	return &Reloader{
+		sources: sourceManager,
+		logger:  log,
+		crawler: crawlerCfg,
+	}
+}
+
+// SetAPIConfig applies the API client settings to polling and caches the
+// polled sources, as LoadSources does.
+func (r *Reloader) SetAPIConfig(api crawlerconfig.SourcesAPIConfig, cache crawlerconfig.SourcesCacheConfig) {
+	r.api = api
+	r.cache = cache
+}
+
+// PollAPI reloads the sources from the gosources API at every interval until
+// the context is done. The ETag of the last response is sent along, so an
+// unchanged set costs the API a 304.
+func (r *Reloader) PollAPI(ctx context.Context, apiURL string, interval time.Duration) {
+	apiLoader := newAPILoader(apiURL, r.api, r.cache, r.logger)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var etag string
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			configs, currentETag, modified, err := apiLoader.LoadSourcesIfModified(ctx, etag)
+			if err != nil {
+				r.logger.Error("Failed to reload sources from API", "url", apiURL, "error", err)
+				continue
+			}
+			etag = currentETag
+			if modified {
+				r.apply(convertLoaderConfigs(configs), apiURL)
+			}
+		}
+	}()
+}
+
+// WatchFile reloads the sources from a YAML sources file or sources snapshot whenever it changes,
+// until the context is done. A file that fails to load leaves the sources as
+// they are.
+func (r *Reloader) WatchFile(ctx context.Context, path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve sources file path: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	// Watch the directory: editors often replace the file rather than write to it
+	if addErr := watcher.Add(filepath.Dir(path)); addErr != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch sources file: %w", addErr)
+	}
+
+	// A file mounted from a Kubernetes ConfigMap or Secret is a symlink whose
+	// target is swapped rather than written to, so re-resolve it on every event
+	target, _ := filepath.EvalSymlinks(path)
+
+	go func() {
+		defer watcher.Close()
+
+		var reload <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				resolved, resolveErr := filepath.EvalSymlinks(path)
+				swapped := resolveErr == nil && resolved != target
+				if swapped {
+					target = resolved
+				}
+				written := (event.Name == path || event.Name == target) &&
+					event.Op&(fsnotify.Write|fsnotify.Create) != 0
+				if swapped || written {
+					reload = time.After(fileReloadDelay)
+				}
+			case watchErr, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				r.logger.Error("Failed to watch sources file", "path", path, "error", watchErr)
+			case <-reload:
+				reload = nil
+				configs, loadErr := loadSourcesFromFile(path)
+				if loadErr != nil {
+					r.logger.Error("Failed to reload sources file", "path", path, "error", loadErr)
+					continue
+				}
+				r.apply(configs, path)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// apply swaps in a reloaded set of sources and logs what changed. A set with
+// invalid crawler overrides leaves the sources as they are.
+func (r *Reloader) apply(configs []Config, origin string) {
+	if r.crawler != nil {
+		ApplyGroups(configs, r.crawler.Groups)
+		if err := ValidateCrawlerOverrides(configs, r.crawler); err != nil {
+			r.logger.Error("Failed to reload sources", "origin", origin, "error", err)
+			return
+		}
+	}
+	changes := r.sources.Replace(configs)
+	if changes.Empty() {
+		r.logger.Debug("Sources reloaded without changes", "origin", origin)
+		return
+	}
+
+	r.logger.Info("Sources reloaded",
+		"origin", origin,
+		"sources", len(configs),
+		"added", changes.Added,
+		"removed", changes.Removed,
+		"updated", changes.Updated,
+	)
+}
@@ -0,0 +1,234 @@
+package sources_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/common/urlnorm"
+	"github.com/jonesrussell/gocrawl/internal/config"
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	old := []sources.Config{
+		{Name: "Sudbury Star", URL: "https://www.thesudburystar.com"},
+		{Name: "Timmins Today", URL: "https://www.timminstoday.com"},
+		{Name: "Mid-North Monitor", URL: "https://www.midnorthmonitor.com"},
+	}
+	updated := []sources.Config{
+		{Name: "Sudbury Star", URL: "https://www.thesudburystar.com"},
+		{Name: "Timmins Today", URL: "https://www.timminstoday.com"},
+		{Name: "Elliot Lake Standard", URL: "https://www.elliotlakestandard.ca"},
+	}
+	updated[1].Selectors.Article.Title = "h1.headline"
+
+	changes := sources.Diff(old, updated)
+	assert.Equal(t, []string{"Elliot Lake Standard"}, changes.Added)
+	assert.Equal(t, []string{"Mid-North Monitor"}, changes.Removed)
+	assert.Equal(t, []string{"Timmins Today"}, changes.Updated)
+	assert.True(t, sources.Diff(updated, updated).Empty())
+}
+
+func TestReplaceKeepsConfigInFlight(t *testing.T) {
+	t.Parallel()
+
+	manager := &sources.Sources{}
+	manager.Replace([]sources.Config{{Name: "Sudbury Star", MaxDepth: 2}})
+	inFlight := manager.FindByName("Sudbury Star")
+	require.NotNil(t, inFlight)
+
+	changes := manager.Replace([]sources.Config{{Name: "Sudbury Star", MaxDepth: 3}})
+	assert.Equal(t, []string{"Sudbury Star"}, changes.Updated)
+	assert.Equal(t, 2, inFlight.MaxDepth)
+	assert.Equal(t, 3, manager.FindByName("Sudbury Star").MaxDepth)
+	assert.Equal(t, int64(1), manager.GetMetrics().SourceCount)
+}
+
+const reloadFile = `sources:
+  - name: Sudbury Star
+    url: https://www.thesudburystar.com
+`
+
+func TestReloaderWatchFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sources.yml")
+	require.NoError(t, os.WriteFile(path, []byte(reloadFile), 0o600))
+
+	cfg := &config.Config{Crawler: crawlerconfig.New()}
+	manager, err := sources.LoadSourcesFromFile(cfg, path, logger.NewNoOp())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, sources.NewReloader(manager, logger.NewNoOp(), nil).WatchFile(ctx, path))
+
+	updated := reloadFile + `  - name: Timmins Today
+    url: https://www.timminstoday.com
+`
+	require.NoError(t, os.WriteFile(path, []byte(updated), 0o600))
+
+	assert.Eventually(t, func() bool {
+		return manager.FindByName("Timmins Today") != nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+// mountSourcesFile updates a sources file laid out as Kubernetes mounts a
+// ConfigMap: the file is a symlink through ..data, and ..data is swapped to a new directory.
+func mountSourcesFile(t *testing.T, dir, version, data string) {
+	t.Helper()
+	versionDir := filepath.Join(dir, version)
+	require.NoError(t, os.Mkdir(versionDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(versionDir, "sources.yml"), []byte(data), 0o600))
+
+	tmpLink := filepath.Join(dir, "..data_tmp")
+	require.NoError(t, os.Symlink(version, tmpLink))
+	require.NoError(t, os.Rename(tmpLink, filepath.Join(dir, "..data")))
+}
+
+func TestReloaderWatchFileFollowsSymlinkSwap(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	mountSourcesFile(t, dir, "..2026_10_01", reloadFile)
+	path := filepath.Join(dir, "sources.yml")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "sources.yml"), path))
+
+	cfg := &config.Config{Crawler: crawlerconfig.New()}
+	manager, err := sources.LoadSourcesFromFile(cfg, path, logger.NewNoOp())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, sources.NewReloader(manager, logger.NewNoOp(), nil).WatchFile(ctx, path))
+
+	// Nothing is written to sources.yml itself; only its target changes
+	mountSourcesFile(t, dir, "..2026_10_02", reloadFile+`  - name: Timmins Today
+    url: https://www.timminstoday.com
+`)
+	assert.Eventually(t, func() bool {
+		return manager.FindByName("Timmins Today") != nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestReloaderWatchFileKeepsSourcesOnInvalidOverrides(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sources.yml")
+	require.NoError(t, os.WriteFile(path, []byte(reloadFile), 0o600))
+
+	crawlerCfg := crawlerconfig.New()
+	manager, err := sources.LoadSourcesFromFile(&config.Config{Crawler: crawlerCfg}, path, logger.NewNoOp())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, sources.NewReloader(manager, logger.NewNoOp(), crawlerCfg).WatchFile(ctx, path))
+
+	const added = reloadFile + `  - name: Timmins Today
+    url: https://www.timminstoday.com
+`
+	require.NoError(t, os.WriteFile(path, []byte(added+"    crawler:\n      max_concurrency: 0\n"), 0o600))
+	assert.Never(t, func() bool {
+		return manager.FindByName("Timmins Today") != nil
+	}, time.Second, 50*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte(added+"    crawler:\n      max_concurrency: 1\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		return manager.FindByName("Timmins Today") != nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestReloaderWatchFileRewrites(t *testing.T) {
+	t.Parallel()
+
+	const rewrites = reloadFile + `    url_rewrites:
+      - pattern: "/print/(\\d+)$"
+        replacement: "%s"
+`
+	path := filepath.Join(t.TempDir(), "sources.yml")
+	require.NoError(t, os.WriteFile(path, fmt.Appendf(nil, rewrites, "/news/$1"), 0o600))
+
+	cfg := &config.Config{Crawler: crawlerconfig.New()}
+	manager, err := sources.LoadSourcesFromFile(cfg, path, logger.NewNoOp())
+	require.NoError(t, err)
+
+	// canonicalize looks the source up for every URL, as the content services do
+	var cache urlnorm.Cache
+	canonicalize := func() string {
+		source := manager.FindByName("Sudbury Star")
+		require.NotNil(t, source)
+		canonical, canonErr := cache.ForSource(source.Name, source.URLRewrites).
+			Canonicalize("https://www.thesudburystar.com/print/42")
+		require.NoError(t, canonErr)
+		return canonical
+	}
+	assert.Equal(t, "https://www.thesudburystar.com/news/42", canonicalize())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, sources.NewReloader(manager, logger.NewNoOp(), nil).WatchFile(ctx, path))
+
+	require.NoError(t, os.WriteFile(path, fmt.Appendf(nil, rewrites, "/article/$1"), 0o600))
+	assert.Eventually(t, func() bool {
+		return canonicalize() == "https://www.thesudburystar.com/article/42"
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestReloaderPollAPI(t *testing.T) {
+	t.Parallel()
+
+	var version, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"v%d"`, version.Load())
+		if r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		response := apiclient.ListSourcesResponse{
+			Sources: []apiclient.APISource{
+				{ID: "1", Name: "Sudbury Star", URL: "https://www.thesudburystar.com", Enabled: true},
+			},
+		}
+		if version.Load() > 0 {
+			response.Sources = append(response.Sources, apiclient.APISource{
+				ID: "2", Name: "Timmins Today", URL: "https://www.timminstoday.com", Enabled: true,
+			})
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	manager := &sources.Sources{}
+	manager.Replace([]sources.Config{{Name: "Sudbury Star"}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sources.NewReloader(manager, logger.NewNoOp(), nil).PollAPI(ctx, server.URL, 10*time.Millisecond)
+
+	assert.Eventually(t, func() bool { return notModified.Load() > 0 }, 5*time.Second, 10*time.Millisecond)
+	assert.Nil(t, manager.FindByName("Timmins Today"))
+
+	version.Store(1)
+	assert.Eventually(t, func() bool {
+		return manager.FindByName("Timmins Today") != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,53 @@
+package sources_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonesrussell/gocrawl/internal/config"
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
+	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rulesSources = `sources:
+  - name: Sudbury Star
+    url: https://www.thesudburystar.com
+    rules:
+      - pattern: "^/news/[^/]+/\\d{4}/\\d{1,2}/[^/]+/?$"
+        action: allow
+        priority: 2
+      - pattern: "^/news/"
+        action: disallow
+        priority: 1
+`
+
+func TestLoadSourcesKeepsRules(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sources.yml")
+	require.NoError(t, os.WriteFile(path, []byte(rulesSources), 0o600))
+
+	cfg := &config.Config{Crawler: crawlerconfig.New()}
+	manager, err := sources.LoadSourcesFromFile(cfg, path, logger.NewNoOp())
+	require.NoError(t, err)
+	source := manager.FindByName("Sudbury Star")
+	require.NotNil(t, source)
+	require.Len(t, source.Rules, 2)
+
+	rules, err := source.Rules.Compile()
+	require.NoError(t, err)
+	assert.True(t, rules.Allows("/news/local/2026/03/transit-plan-approved"), "the higher priority rule wins")
+	assert.False(t, rules.Allows("/news/tag/transit"))
+	assert.True(t, rules.Allows("/about"), "links no rule matches are followed")
+
+	invalid := rulesSources + `      - pattern: "^/tag/"
+        action: skip
+`
+	require.NoError(t, os.WriteFile(path, []byte(invalid), 0o600))
+	_, err = sources.LoadSourcesFromFile(cfg, path, logger.NewNoOp())
+	require.Error(t, err, "a source with an unknown rule action is not loaded")
+}
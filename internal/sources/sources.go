@@ -7,13 +7,17 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jonesrussell/gocrawl/internal/config"
 
+	crawlerconfig "github.com/jonesrussell/gocrawl/internal/config/crawler"
 	configtypes "github.com/jonesrussell/gocrawl/internal/config/types"
 	"github.com/jonesrussell/gocrawl/internal/logger"
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
 	"github.com/jonesrussell/gocrawl/internal/sources/loader"
 	"github.com/jonesrussell/gocrawl/internal/sources/types"
 	storagetypes "github.com/jonesrussell/gocrawl/internal/storage/types"
@@ -77,6 +81,8 @@ type ArticleSelectors = types.ArticleSelectors
 
 // Sources manages a collection of web content sources.
 type Sources struct {
+	// mu guards sources, which a reload may replace while crawls are running
+	mu      sync.RWMutex
 	sources []Config
 	logger  logger.Interface
 	metrics *types.SourcesMetrics
@@ -246,14 +252,19 @@ func createSelectorConfig(selectors any) types.SelectorConfig {
 	}
 }
 
-// LoadSources creates a new Sources instance by loading sources from either the
-// gosources API or a YAML file specified in the crawler config. Returns an error if no sources are found.
+// LoadSources creates a new Sources instance by loading sources from the
+// gosources API, or from the sources file when the crawler config names one.
+// While the API is unreachable, the cached response of the last successful
+// load is used if it is recent enough. Returns an error if no sources are found.
 // The logger parameter is optional and can be nil.
 func LoadSources(cfg config.Interface, log logger.Interface) (*Sources, error) {
 	var sources []Config
 	var err error
 
 	crawlerCfg := cfg.GetCrawlerConfig()
+	if crawlerCfg != nil && crawlerCfg.SourcesFile != "" {
+		return LoadSourcesFromFile(cfg, crawlerCfg.SourcesFile, log)
+	}
 
 	// API loader is required - no file-based fallback
 	if crawlerCfg == nil || crawlerCfg.SourcesAPIURL == "" {
@@ -263,7 +274,7 @@ func LoadSources(cfg config.Interface, log logger.Interface) (*Sources, error) {
 	if log != nil {
 		log.Info("Loading sources from API", "url", crawlerCfg.SourcesAPIURL)
 	}
-	sources, err = loadSourcesFromAPI(crawlerCfg.SourcesAPIURL, log)
+	sources, err = loadSourcesFromAPI(crawlerCfg.SourcesAPIURL, crawlerCfg.SourcesAPI, crawlerCfg.SourcesCache, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load sources from API: %w", err)
 	}
@@ -273,6 +284,37 @@ func LoadSources(cfg config.Interface, log logger.Interface) (*Sources, error) {
 		return nil, errors.New("no sources found")
 	}
 
+	ApplyGroups(sources, crawlerCfg.Groups)
+	if validateErr := ValidateCrawlerOverrides(sources, crawlerCfg); validateErr != nil {
+		return nil, validateErr
+	}
+
+	return &Sources{
+		sources: sources,
+		logger:  log,
+		metrics: types.NewSourcesMetrics(),
+	}, nil
+}
+
+// LoadSourcesFromFile creates a new Sources instance from a YAML sources file
+// or, for a .json file, a sources snapshot. The logger parameter is optional
+// and can be nil.
+func LoadSourcesFromFile(cfg config.Interface, path string, log logger.Interface) (*Sources, error) {
+	if log != nil {
+		log.Info("Loading sources from file", "path", path)
+	}
+	sources, err := loadSourcesFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if crawlerCfg := cfg.GetCrawlerConfig(); crawlerCfg != nil {
+		ApplyGroups(sources, crawlerCfg.Groups)
+		if validateErr := ValidateCrawlerOverrides(sources, crawlerCfg); validateErr != nil {
+			return nil, validateErr
+		}
+	}
+
 	return &Sources{
 		sources: sources,
 		logger:  log,
@@ -281,8 +323,13 @@ func LoadSources(cfg config.Interface, log logger.Interface) (*Sources, error) {
 }
 
 // loadSourcesFromAPI attempts to load sources from the gosources API
-func loadSourcesFromAPI(apiURL string, log logger.Interface) ([]Config, error) {
-	apiLoader := loader.NewAPILoader(apiURL, log)
+func loadSourcesFromAPI(
+	apiURL string,
+	api crawlerconfig.SourcesAPIConfig,
+	cache crawlerconfig.SourcesCacheConfig,
+	log logger.Interface,
+) ([]Config, error) {
+	apiLoader := newAPILoader(apiURL, api, cache, log)
 
 	configs, err := apiLoader.LoadSources()
 	if err != nil {
@@ -293,13 +340,54 @@ func loadSourcesFromAPI(apiURL string, log logger.Interface) ([]Config, error) {
 		return nil, errors.New("no sources found from API")
 	}
 
-	// Convert loaded configs to our source type
+	return convertLoaderConfigs(configs), nil
+}
+
+// newAPILoader creates an API loader with the API client settings that caches
+// its responses when the cache is enabled.
+func newAPILoader(
+	apiURL string,
+	api crawlerconfig.SourcesAPIConfig,
+	cache crawlerconfig.SourcesCacheConfig,
+	log logger.Interface,
+) *loader.APILoader {
+	apiLoader := loader.NewAPILoader(apiURL, log, apiclient.WithConfig(api))
+	if cache.Enabled {
+		apiLoader.SetCache(cache.Path, cache.MaxStaleness)
+	}
+	return apiLoader
+}
+
+// loadSourcesFromFile loads sources from a YAML sources file or a sources snapshot
+func loadSourcesFromFile(path string) ([]Config, error) {
+	if filepath.Ext(path) == ".json" {
+		configs, _, err := loader.LoadSnapshot(path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load sources snapshot: %w", err)
+		}
+		return convertLoaderConfigs(configs), nil
+	}
+
+	fileLoader, err := loader.NewLoader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sources file loader: %w", err)
+	}
+
+	configs, err := fileLoader.LoadSources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sources from file: %w", err)
+	}
+
+	return convertLoaderConfigs(configs), nil
+}
+
+// convertLoaderConfigs converts loaded configs to our source type
+func convertLoaderConfigs(configs []loader.Config) []Config {
 	sourceConfigs := make([]Config, 0, len(configs))
 	for i := range configs {
 		sourceConfigs = append(sourceConfigs, convertLoaderConfig(configs[i]))
 	}
-
-	return sourceConfigs, nil
+	return sourceConfigs
 }
 
 // convertLoaderConfig converts a loader.Config to a types.SourceConfig
@@ -336,11 +424,18 @@ func convertLoaderConfig(cfg loader.Config) Config {
 		rateLimit = time.Second
 	}
 
+	// Parse retention period; invalid values disable age-based retention
+	retention, retentionErr := configtypes.ParseRetention(cfg.Retention)
+	if retentionErr != nil {
+		retention = 0
+	}
+
 	// Parse URL to get domain
 	u, err := url.Parse(cfg.URL)
 	if err != nil {
 		// If URL parsing fails, use the URL as is
 		return Config{
+			ID:             cfg.ID,
 			Name:           cfg.Name,
 			URL:            cfg.URL,
 			AllowedDomains: []string{cfg.URL},
@@ -352,7 +447,25 @@ func convertLoaderConfig(cfg loader.Config) Config {
 			ArticleIndex:   cfg.ArticleIndex,
 			PageIndex:      cfg.PageIndex,
 			Selectors:      createSelectorConfig(cfg.Selectors),
-			Rules:          configtypes.Rules{},
+			Rules:          cfg.Rules,
+			Retention:      retention,
+			MaxDocs:        cfg.MaxDocs,
+			URLRewrites:    cfg.URLRewrites,
+			Extraction:     cfg.Extraction,
+			StructuredData: cfg.StructuredData,
+			Locale:         cfg.Locale,
+			Timezone:       cfg.Timezone,
+			CityName:       cfg.CityName,
+			Region:         cfg.Region,
+			Latitude:       cfg.Latitude,
+			Longitude:      cfg.Longitude,
+			Group:          cfg.Group,
+			Tags:           cfg.Tags,
+			Enrichers:      cfg.Enrichers,
+			Crawler:        cfg.Crawler,
+			Enabled:        cfg.Enabled,
+			DisabledReason: cfg.DisabledReason,
+			DisabledUntil:  cfg.DisabledUntil,
 		}
 	}
 
@@ -363,6 +476,7 @@ func convertLoaderConfig(cfg loader.Config) Config {
 	}
 
 	return Config{
+		ID:             cfg.ID,
 		Name:           cfg.Name,
 		URL:            cfg.URL,
 		AllowedDomains: []string{domain},
@@ -374,12 +488,33 @@ func convertLoaderConfig(cfg loader.Config) Config {
 		ArticleIndex:   cfg.ArticleIndex,
 		PageIndex:      cfg.PageIndex,
 		Selectors:      createSelectorConfig(cfg.Selectors),
-		Rules:          configtypes.Rules{},
+		Rules:          cfg.Rules,
+		Retention:      retention,
+		MaxDocs:        cfg.MaxDocs,
+		URLRewrites:    cfg.URLRewrites,
+		Extraction:     cfg.Extraction,
+		StructuredData: cfg.StructuredData,
+		Locale:         cfg.Locale,
+		Timezone:       cfg.Timezone,
+		CityName:       cfg.CityName,
+		Region:         cfg.Region,
+		Latitude:       cfg.Latitude,
+		Longitude:      cfg.Longitude,
+		Group:          cfg.Group,
+		Tags:           cfg.Tags,
+		Enrichers:      cfg.Enrichers,
+		Crawler:        cfg.Crawler,
+		Enabled:        cfg.Enabled,
+		DisabledReason: cfg.DisabledReason,
+		DisabledUntil:  cfg.DisabledUntil,
 	}
 }
 
 // ListSources retrieves all sources.
 func (s *Sources) ListSources(ctx context.Context) ([]*Config, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	result := make([]*Config, 0, len(s.sources))
 	for i := range s.sources {
 		result = append(result, &s.sources[i])
@@ -394,8 +529,11 @@ func (s *Sources) AddSource(ctx context.Context, source *Config) error {
 		return ErrInvalidSource
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// Check if source already exists
-	if s.FindByName(source.Name) != nil {
+	if s.findByName(source.Name) != nil {
 		return ErrSourceExists
 	}
 
@@ -412,8 +550,11 @@ func (s *Sources) UpdateSource(ctx context.Context, source *Config) error {
 		return ErrInvalidSource
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// Check if source exists
-	if s.FindByName(source.Name) == nil {
+	if s.findByName(source.Name) == nil {
 		return ErrSourceNotFound
 	}
 
@@ -429,6 +570,9 @@ func (s *Sources) UpdateSource(ctx context.Context, source *Config) error {
 
 // DeleteSource deletes a source by name.
 func (s *Sources) DeleteSource(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	for i := range s.sources {
 		if s.sources[i].Name == name {
 			s.sources = append(s.sources[:i], s.sources[i+1:]...)
@@ -512,16 +656,30 @@ func (s *Sources) ValidateSource(
 
 // GetMetrics returns the current metrics.
 func (s *Sources) GetMetrics() types.SourcesMetrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	return *s.metrics
 }
 
 // GetSources returns all sources.
 func (s *Sources) GetSources() ([]Config, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	return s.sources, nil
 }
 
 // FindByName finds a source by name.
 func (s *Sources) FindByName(name string) *Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.findByName(name)
+}
+
+// findByName finds a source by name; the caller holds the lock.
+func (s *Sources) findByName(name string) *Config {
 	for i := range s.sources {
 		if s.sources[i].Name == name {
 			return &s.sources[i]
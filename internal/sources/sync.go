@@ -0,0 +1,584 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrSyncConflict is returned when a push or pull skipped conflicting sources.
+var ErrSyncConflict = errors.New("sources changed on both sides")
+
+// SyncAction is what a push or pull does with a source.
+type SyncAction string
+
+const (
+	// SyncCreate creates the source on the other side
+	SyncCreate SyncAction = "create"
+	// SyncUpdate overwrites the source on the other side
+	SyncUpdate SyncAction = "update"
+	// SyncUnchanged leaves the source as it is
+	SyncUnchanged SyncAction = "unchanged"
+	// SyncConflict skips a source that changed on both sides, unless forced
+	SyncConflict SyncAction = "conflict"
+)
+
+// syncMetadataFields are set by the API and never compared.
+var syncMetadataFields = []string{"id", "created_at", "updated_at"}
+
+// syncHashField is the file key holding the hash of a source as last pushed or pulled.
+const syncHashField = "sync_hash"
+
+// FieldChange is a changed setting of a source. Field is the JSON path of the
+// setting and From and To are JSON values, empty when the setting is unset.
+type FieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// SyncChange is the planned push or pull of a source.
+type SyncChange struct {
+	// Name is the name of the source
+	Name string
+	// Action is what the sync does with the source
+	Action SyncAction
+	// Reason explains a conflict
+	Reason string
+	// Local is the local copy, nil when the source only exists in the API
+	Local *LocalSource
+	// Remote is the API copy, nil when the source only exists locally
+	Remote *apiclient.APISource
+	// Fields are the settings the sync changes
+	Fields []FieldChange
+}
+
+// LocalSource is a source kept in a file of a sources directory.
+type LocalSource struct {
+	// Path is the file holding the source
+	Path string
+	// Index is the position of the source in the sources list of the file
+	Index int
+	// Source is the source as the API represents it
+	Source apiclient.APISource
+	// SyncHash is the content hash of the source as last pushed or pulled, empty
+	// for files written before hashes were recorded
+	SyncHash string
+}
+
+// Edited reports whether the local copy changed since it was last pushed or
+// pulled. known is false when no hash was recorded.
+func (l *LocalSource) Edited() (edited, known bool) {
+	if l.SyncHash == "" {
+		return false, false
+	}
+	return ContentHash(&l.Source) != l.SyncHash, true
+}
+
+// syncedSource is a source of a file and its recorded content hash.
+type syncedSource struct {
+	source apiclient.APISource
+	hash   string
+}
+
+// SourcesDir is a directory of YAML sources files kept in sync with the
+// gosources API. Every file holds a sources list, as the sources file does,
+// and carries the API ID and update time of its sources so that changes made
+// on both sides can be told apart, along with a hash of their settings that
+// tells local edits apart from the copy that was pulled.
+type SourcesDir struct {
+	path  string
+	files map[string][]syncedSource
+	dirty map[string]bool
+}
+
+// ReadSourcesDir reads the *.yaml and *.yml files of a directory. A missing
+// directory holds no sources.
+func ReadSourcesDir(path string) (*SourcesDir, error) {
+	dir := &SourcesDir{
+		path:  path,
+		files: make(map[string][]syncedSource),
+		dirty: make(map[string]bool),
+	}
+
+	entries, err := os.ReadDir(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return dir, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sources directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		file := filepath.Join(path, entry.Name())
+		apiSources, readErr := readSourcesFile(file)
+		if readErr != nil {
+			return nil, readErr
+		}
+		dir.files[file] = apiSources
+	}
+	return dir, nil
+}
+
+// Sources returns the sources of the directory, ordered by file.
+func (d *SourcesDir) Sources() []LocalSource {
+	paths := make([]string, 0, len(d.files))
+	for path := range d.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var local []LocalSource
+	for _, path := range paths {
+		for i, synced := range d.files[path] {
+			local = append(local, LocalSource{Path: path, Index: i, Source: synced.source, SyncHash: synced.hash})
+		}
+	}
+	return local
+}
+
+// Set replaces a local source, keeping the hash recorded when it was last synced.
+func (d *SourcesDir) Set(local *LocalSource, source *apiclient.APISource) {
+	d.files[local.Path][local.Index].source = *source
+	d.dirty[local.Path] = true
+}
+
+// Record replaces a local source with its API copy after a push or pull and
+// records the hash of its settings.
+func (d *SourcesDir) Record(local *LocalSource, source *apiclient.APISource) {
+	d.files[local.Path][local.Index] = syncedSource{source: *source, hash: ContentHash(source)}
+	d.dirty[local.Path] = true
+}
+
+// Add stores a new source pulled from the API in a file of its own, named after the source.
+func (d *SourcesDir) Add(source *apiclient.APISource) {
+	base := sourceFileName(source.Name)
+	path := filepath.Join(d.path, base+".yaml")
+	for n := 2; d.exists(path); n++ {
+		path = filepath.Join(d.path, fmt.Sprintf("%s-%d.yaml", base, n))
+	}
+	d.files[path] = []syncedSource{{source: *source, hash: ContentHash(source)}}
+	d.dirty[path] = true
+}
+
+// Save writes the files whose sources changed.
+func (d *SourcesDir) Save() error {
+	if len(d.dirty) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(d.path, 0o755); err != nil {
+		return fmt.Errorf("failed to create sources directory: %w", err)
+	}
+	for path := range d.dirty {
+		if err := writeSourcesFile(path, d.files[path]); err != nil {
+			return err
+		}
+		delete(d.dirty, path)
+	}
+	return nil
+}
+
+// exists reports whether a file is taken, in the directory or on disk.
+func (d *SourcesDir) exists(path string) bool {
+	if _, ok := d.files[path]; ok {
+		return true
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// PlanPush compares the local sources with the API and plans their upload. A
+// source conflicts when the API copy was updated after the local copy was
+// pulled, or when it exists in the API but was never pulled.
+func PlanPush(dir *SourcesDir, remote []apiclient.APISource) []SyncChange {
+	local := dir.Sources()
+	changes := make([]SyncChange, 0, len(local))
+	for i := range local {
+		source := &local[i]
+		change := SyncChange{Name: source.Source.Name, Local: source}
+		change.Remote = findRemote(remote, &source.Source)
+		if change.Remote == nil {
+			change.Action = SyncCreate
+			change.Fields = CompareSources(nil, &source.Source)
+			changes = append(changes, change)
+			continue
+		}
+
+		change.Fields = CompareSources(change.Remote, &source.Source)
+		localUpdated, remoteUpdated := source.Source.UpdatedAt, change.Remote.UpdatedAt
+		switch {
+		case len(change.Fields) == 0:
+			change.Action = SyncUnchanged
+		case localUpdated == nil:
+			change.Action = SyncConflict
+			change.Reason = "the source exists in the API but was never pulled"
+		case remoteUpdated != nil && remoteUpdated.After(*localUpdated):
+			change.Action = SyncConflict
+			change.Reason = fmt.Sprintf("the API copy was updated at %s, after it was pulled",
+				remoteUpdated.Format("2006-01-02 15:04:05"))
+		default:
+			change.Action = SyncUpdate
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// PlanPull compares the API sources with the local sources and plans their
+// download. A source conflicts when the local copy was edited since it was
+// pulled, as pulling would lose the edits, whether or not the API copy was
+// updated too. Local edits are told by the recorded content hash; without one,
+// only an API copy updated since the pull is taken.
+func PlanPull(dir *SourcesDir, remote []apiclient.APISource) []SyncChange {
+	local := dir.Sources()
+	changes := make([]SyncChange, 0, len(remote))
+	for i := range remote {
+		source := &remote[i]
+		change := SyncChange{Name: source.Name, Remote: source}
+		change.Local = findLocal(local, source)
+		if change.Local == nil {
+			change.Action = SyncCreate
+			change.Fields = CompareSources(nil, source)
+			changes = append(changes, change)
+			continue
+		}
+
+		change.Fields = CompareSources(&change.Local.Source, source)
+		localUpdated, remoteUpdated := change.Local.Source.UpdatedAt, source.UpdatedAt
+		remoteMoved := localUpdated != nil && remoteUpdated != nil && remoteUpdated.After(*localUpdated)
+		edited, known := change.Local.Edited()
+		switch {
+		case len(change.Fields) == 0 && sameTime(localUpdated, remoteUpdated) &&
+			change.Local.Source.ID == source.ID:
+			change.Action = SyncUnchanged
+		case len(change.Fields) == 0:
+			// Only the API metadata changed; refresh it
+			change.Action = SyncUpdate
+		case localUpdated == nil:
+			change.Action = SyncConflict
+			change.Reason = "the local copy was never pulled"
+		case remoteMoved && edited:
+			change.Action = SyncConflict
+			change.Reason = fmt.Sprintf("the local copy has edits that were not pushed and the API copy "+
+				"was updated at %s, after it was pulled", remoteUpdated.Format("2006-01-02 15:04:05"))
+		case remoteMoved:
+			change.Action = SyncUpdate
+		case edited || !known:
+			change.Action = SyncConflict
+			change.Reason = "the local copy has edits that were not pushed"
+		default:
+			change.Action = SyncUpdate
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// Push applies a push plan through the API and records the API copies in the
+// directory. Conflicting sources are overwritten with force and skipped
+// otherwise, in which case ErrSyncConflict is returned once the rest is pushed.
+func Push(ctx context.Context, client *apiclient.Client, dir *SourcesDir, changes []SyncChange, force bool) error {
+	var conflicts []string
+	for i := range changes {
+		change := &changes[i]
+		if change.Action == SyncConflict && !force {
+			conflicts = append(conflicts, change.Name)
+			continue
+		}
+
+		var (
+			saved *apiclient.APISource
+			err   error
+		)
+		switch change.Action {
+		case SyncCreate:
+			source := change.Local.Source
+			source.ID = ""
+			saved, err = client.CreateSource(ctx, &source)
+		case SyncUpdate, SyncConflict:
+			source := change.Local.Source
+			source.ID = change.Remote.ID
+			saved, err = client.UpdateSource(ctx, change.Remote.ID, &source)
+		case SyncUnchanged:
+			continue
+		}
+		if err != nil {
+			// Keep the IDs and hashes of the sources pushed so far
+			return errors.Join(fmt.Errorf("failed to push source %s: %w", change.Name, err), dir.Save())
+		}
+		dir.Record(change.Local, saved)
+	}
+
+	if err := dir.Save(); err != nil {
+		return err
+	}
+	return conflictError(conflicts)
+}
+
+// Pull applies a pull plan to the directory. Conflicting sources are
+// overwritten with force and skipped otherwise, in which case ErrSyncConflict
+// is returned once the rest is pulled.
+func Pull(dir *SourcesDir, changes []SyncChange, force bool) error {
+	var conflicts []string
+	for i := range changes {
+		change := &changes[i]
+		switch {
+		case change.Action == SyncConflict && !force:
+			conflicts = append(conflicts, change.Name)
+		case change.Action == SyncCreate:
+			dir.Add(change.Remote)
+		case change.Action == SyncUpdate, change.Action == SyncConflict:
+			dir.Record(change.Local, change.Remote)
+		}
+	}
+
+	if err := dir.Save(); err != nil {
+		return err
+	}
+	return conflictError(conflicts)
+}
+
+// CompareSources lists the settings that differ between two sources, by JSON
+// path. A nil source has no settings. API metadata is ignored.
+func CompareSources(from, to *apiclient.APISource) []FieldChange {
+	before, after := flattenSource(from), flattenSource(to)
+
+	fields := make(map[string]bool, len(before)+len(after))
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+
+	var changes []FieldChange
+	for field := range fields {
+		if before[field] != after[field] {
+			changes = append(changes, FieldChange{Field: field, From: before[field], To: after[field]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// flattenSource maps the JSON path of every set setting of a source to its JSON value.
+func flattenSource(source *apiclient.APISource) map[string]string {
+	flat := make(map[string]string)
+	if source == nil {
+		return flat
+	}
+
+	data, err := json.Marshal(source)
+	if err != nil {
+		return flat
+	}
+	var fields map[string]any
+	if unmarshalErr := json.Unmarshal(data, &fields); unmarshalErr != nil {
+		return flat
+	}
+	for _, field := range syncMetadataFields {
+		delete(fields, field)
+	}
+	flattenValue(flat, "", fields)
+	return flat
+}
+
+// flattenValue adds a JSON value, or the members of a JSON object, to flat.
+func flattenValue(flat map[string]string, path string, value any) {
+	if object, ok := value.(map[string]any); ok {
+		for key, member := range object {
+			if path != "" {
+				key = path + "." + key
+			}
+			flattenValue(flat, key, member)
+		}
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	flat[path] = string(data)
+}
+
+// ContentHash returns a hash of the settings of a source. API metadata is ignored.
+func ContentHash(source *apiclient.APISource) string {
+	flat := flattenSource(source)
+	fields := make([]string, 0, len(flat))
+	for field := range flat {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	hash := sha256.New()
+	for _, field := range fields {
+		fmt.Fprintf(hash, "%s=%s\n", field, flat[field])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// findRemote returns the API copy of a local source, by ID and then by name.
+func findRemote(remote []apiclient.APISource, source *apiclient.APISource) *apiclient.APISource {
+	for i := range remote {
+		if source.ID != "" && remote[i].ID == source.ID {
+			return &remote[i]
+		}
+	}
+	for i := range remote {
+		if remote[i].Name == source.Name {
+			return &remote[i]
+		}
+	}
+	return nil
+}
+
+// findLocal returns the local copy of an API source, by ID and then by name.
+func findLocal(local []LocalSource, source *apiclient.APISource) *LocalSource {
+	for i := range local {
+		if source.ID != "" && local[i].Source.ID == source.ID {
+			return &local[i]
+		}
+	}
+	for i := range local {
+		if local[i].Source.Name == source.Name {
+			return &local[i]
+		}
+	}
+	return nil
+}
+
+// conflictError reports the skipped conflicting sources, if any.
+func conflictError(conflicts []string) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrSyncConflict, strings.Join(conflicts, ", "))
+}
+
+// readSourcesFile reads the sources list of a YAML file. Sources are decoded
+// through JSON so that the file keys match the API fields.
+func readSourcesFile(path string) ([]syncedSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sources file: %w", err)
+	}
+
+	var file struct {
+		Sources []map[string]any `yaml:"sources"`
+	}
+	if unmarshalErr := yaml.Unmarshal(data, &file); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse sources file %s: %w", path, unmarshalErr)
+	}
+
+	apiSources := make([]syncedSource, 0, len(file.Sources))
+	for _, fields := range file.Sources {
+		hash, _ := fields[syncHashField].(string)
+		delete(fields, syncHashField)
+		encoded, marshalErr := json.Marshal(fields)
+		if marshalErr != nil {
+			return nil, fmt.Errorf("failed to encode source of %s: %w", path, marshalErr)
+		}
+		var source apiclient.APISource
+		if decodeErr := json.Unmarshal(encoded, &source); decodeErr != nil {
+			return nil, fmt.Errorf("invalid source in %s: %w", path, decodeErr)
+		}
+		apiSources = append(apiSources, syncedSource{source: source, hash: hash})
+	}
+	return apiSources, nil
+}
+
+// writeSourcesFile writes a sources list to a YAML file, keeping the field
+// order of the API. The recorded hash of a source follows its API fields.
+func writeSourcesFile(path string, apiSources []syncedSource) error {
+	list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for i := range apiSources {
+		source := &apiSources[i].source
+		encoded, err := json.Marshal(source)
+		if err != nil {
+			return fmt.Errorf("failed to encode source %s: %w", source.Name, err)
+		}
+		// JSON is YAML; decoding it into a node keeps the field order
+		var doc yaml.Node
+		if decodeErr := yaml.Unmarshal(encoded, &doc); decodeErr != nil {
+			return fmt.Errorf("failed to encode source %s: %w", source.Name, decodeErr)
+		}
+		node := doc.Content[0]
+		blockStyle(node)
+		if hash := apiSources[i].hash; hash != "" {
+			node.Content = append(node.Content, yamlScalar("!!str", syncHashField), yamlScalar("!!str", hash))
+		}
+		list.Content = append(list.Content, node)
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	root.Content = append(root.Content, yamlScalar("!!str", "sources"), list)
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(sourcesFileIndent)
+	if err := encoder.Encode(root); err != nil {
+		return fmt.Errorf("failed to encode sources file: %w", err)
+	}
+	if err := os.WriteFile(path, out.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write sources file: %w", err)
+	}
+	return nil
+}
+
+// blockStyle drops the flow and quoting styles of a node decoded from JSON.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// sourceFileName derives a file name from a source name.
+// Example: "Sudbury Star" -> "sudbury-star"
+func sourceFileName(name string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && builder.Len() > 0 {
+			builder.WriteRune('-')
+			dash = true
+		}
+	}
+	if fileName := strings.TrimSuffix(builder.String(), "-"); fileName != "" {
+		return fileName
+	}
+	return "source"
+}
+
+// sameTime reports whether two optional times are equal.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
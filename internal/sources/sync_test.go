@@ -0,0 +1,267 @@
+package sources_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/gocrawl/internal/sources"
+	"github.com/jonesrussell/gocrawl/internal/sources/apiclient"
+	"github.com/jonesrussell/gocrawl/internal/sources/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSourcesAPI is an in-memory gosources API that stamps every write.
+type fakeSourcesAPI struct {
+	mu      sync.Mutex
+	sources []apiclient.APISource
+	now     time.Time
+	// reject names a source whose writes are refused
+	reject string
+}
+
+func (f *fakeSourcesAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == http.MethodGet {
+		_ = json.NewEncoder(w).Encode(apiclient.ListSourcesResponse{Sources: f.sources, Count: len(f.sources)})
+		return
+	}
+
+	var source apiclient.APISource
+	if err := json.NewDecoder(r.Body).Decode(&source); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if source.Name == f.reject {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+	f.now = f.now.Add(time.Minute)
+	updated := f.now
+	source.UpdatedAt = &updated
+	switch r.Method {
+	case http.MethodPost:
+		source.ID = "id-" + source.Name
+		f.sources = append(f.sources, source)
+	case http.MethodPut:
+		id := strings.TrimPrefix(r.URL.Path, "/")
+		for i := range f.sources {
+			if f.sources[i].ID == id {
+				f.sources[i] = source
+			}
+		}
+	}
+	_ = json.NewEncoder(w).Encode(source)
+}
+
+// edit changes a source in the API, as another user would.
+func (f *fakeSourcesAPI) edit(name string, change func(*apiclient.APISource)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(time.Minute)
+	updated := f.now
+	for i := range f.sources {
+		if f.sources[i].Name == name {
+			change(&f.sources[i])
+			f.sources[i].UpdatedAt = &updated
+		}
+	}
+}
+
+const localSources = `sources:
+  - name: Sudbury Star
+    url: https://www.thesudburystar.com
+    article_index: sudbury_star_articles
+    page_index: sudbury_star_pages
+    enabled: true
+    selectors:
+      article:
+        title: h1.headline
+`
+
+func TestPushAndPull(t *testing.T) {
+	t.Parallel()
+
+	api := &fakeSourcesAPI{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	server := httptest.NewServer(api)
+	defer server.Close()
+	client := apiclient.NewClient(apiclient.WithBaseURL(server.URL))
+	ctx := t.Context()
+
+	path := t.TempDir()
+	file := filepath.Join(path, "sudbury.yml")
+	require.NoError(t, os.WriteFile(file, []byte(localSources), 0o600))
+
+	// A new local source is created and the API copy recorded locally
+	dir, err := sources.ReadSourcesDir(path)
+	require.NoError(t, err)
+	remote, err := client.ListSources(ctx)
+	require.NoError(t, err)
+	changes := sources.PlanPush(dir, remote)
+	require.Len(t, changes, 1)
+	assert.Equal(t, sources.SyncCreate, changes[0].Action)
+	assert.Contains(t, changes[0].Fields, sources.FieldChange{
+		Field: "selectors.article.title", To: `"h1.headline"`,
+	})
+	require.NoError(t, sources.Push(ctx, client, dir, changes, false))
+
+	l, err := loader.NewLoader(file)
+	require.NoError(t, err)
+	configs, err := l.LoadSources()
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "id-Sudbury Star", configs[0].ID)
+	assert.Equal(t, "h1.headline", configs[0].Selectors.Article.Title)
+
+	// A local edit that was not pushed is kept by a pull
+	dir, err = sources.ReadSourcesDir(path)
+	require.NoError(t, err)
+	local := dir.Sources()
+	require.Len(t, local, 1)
+	local[0].Source.Selectors.Article.Body = "div.story"
+	dir.Set(&local[0], &local[0].Source)
+	require.NoError(t, dir.Save())
+	remote, err = client.ListSources(ctx)
+	require.NoError(t, err)
+	changes = sources.PlanPull(dir, remote)
+	require.Len(t, changes, 1)
+	assert.Equal(t, sources.SyncConflict, changes[0].Action)
+	require.ErrorIs(t, sources.Pull(dir, changes, false), sources.ErrSyncConflict)
+
+	// Someone edits the source in the API; pushing the stale copy conflicts
+	api.edit("Sudbury Star", func(source *apiclient.APISource) {
+		source.Selectors.Article.Title = "h1.story-title"
+	})
+	added := api.now
+	api.sources = append(api.sources, apiclient.APISource{
+		ID: "id-Timmins Today", Name: "Timmins Today", UpdatedAt: &added,
+	})
+	dir, err = sources.ReadSourcesDir(path)
+	require.NoError(t, err)
+	assert.Equal(t, "div.story", dir.Sources()[0].Source.Selectors.Article.Body)
+	remote, err = client.ListSources(ctx)
+	require.NoError(t, err)
+	changes = sources.PlanPush(dir, remote)
+	require.Len(t, changes, 1)
+	assert.Equal(t, sources.SyncConflict, changes[0].Action)
+	require.ErrorIs(t, sources.Push(ctx, client, dir, changes, false), sources.ErrSyncConflict)
+
+	// Both copies were edited since the pull, so pulling conflicts too; a forced
+	// pull takes the API copy and adds the new source in a file of its own
+	changes = sources.PlanPull(dir, remote)
+	require.Len(t, changes, 2)
+	assert.Equal(t, sources.SyncConflict, changes[0].Action)
+	assert.Contains(t, changes[0].Reason, "the API copy was updated")
+	assert.Contains(t, changes[0].Fields, sources.FieldChange{
+		Field: "selectors.article.title", From: `"h1.headline"`, To: `"h1.story-title"`,
+	})
+	assert.Equal(t, sources.SyncCreate, changes[1].Action)
+	require.NoError(t, sources.Pull(dir, changes, true))
+
+	dir, err = sources.ReadSourcesDir(path)
+	require.NoError(t, err)
+	local = dir.Sources()
+	require.Len(t, local, 2)
+	assert.Equal(t, "h1.story-title", local[0].Source.Selectors.Article.Title)
+	assert.Empty(t, local[0].Source.Selectors.Article.Body)
+	assert.Equal(t, filepath.Join(path, "timmins-today.yaml"), local[1].Path)
+	assert.Equal(t, "id-Timmins Today", local[1].Source.ID)
+
+	// Everything is in sync now
+	for _, change := range sources.PlanPush(dir, remote) {
+		assert.Equal(t, sources.SyncUnchanged, change.Action, change.Name)
+	}
+
+	// An API edit of an untouched local copy is pulled without a conflict
+	api.edit("Sudbury Star", func(source *apiclient.APISource) {
+		source.Selectors.Article.Body = "div.article-body"
+	})
+	remote, err = client.ListSources(ctx)
+	require.NoError(t, err)
+	changes = sources.PlanPull(dir, remote)
+	require.Len(t, changes, 2)
+	assert.Equal(t, sources.SyncUpdate, changes[0].Action)
+	assert.Equal(t, sources.SyncUnchanged, changes[1].Action)
+}
+
+func TestPushKeepsPushedSourcesOnError(t *testing.T) {
+	t.Parallel()
+
+	api := &fakeSourcesAPI{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), reject: "Timmins Today"}
+	server := httptest.NewServer(api)
+	defer server.Close()
+	client := apiclient.NewClient(apiclient.WithBaseURL(server.URL))
+	ctx := t.Context()
+
+	path := t.TempDir()
+	file := filepath.Join(path, "northern.yml")
+	require.NoError(t, os.WriteFile(file, []byte(localSources+`  - name: Timmins Today
+    url: https://www.timminstoday.com
+    article_index: timmins_today_articles
+    page_index: timmins_today_pages
+    enabled: true
+`), 0o600))
+
+	dir, err := sources.ReadSourcesDir(path)
+	require.NoError(t, err)
+	changes := sources.PlanPush(dir, nil)
+	require.Len(t, changes, 2)
+	require.Error(t, sources.Push(ctx, client, dir, changes, false))
+
+	// The source created before the failure is recorded, so it is not created twice
+	l, err := loader.NewLoader(file)
+	require.NoError(t, err)
+	configs, err := l.LoadSources()
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.Equal(t, "id-Sudbury Star", configs[0].ID)
+	assert.Empty(t, configs[1].ID)
+
+	dir, err = sources.ReadSourcesDir(path)
+	require.NoError(t, err)
+	remote, err := client.ListSources(ctx)
+	require.NoError(t, err)
+	changes = sources.PlanPush(dir, remote)
+	require.Len(t, changes, 2)
+	assert.Equal(t, sources.SyncUnchanged, changes[0].Action)
+	assert.Equal(t, sources.SyncCreate, changes[1].Action)
+}
+
+func TestPullKeepsGroup(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+	dir, err := sources.ReadSourcesDir(path)
+	require.NoError(t, err)
+	remote := []apiclient.APISource{{
+		ID:           "id-Sudbury Star",
+		Name:         "Sudbury Star",
+		URL:          "https://www.thesudburystar.com",
+		ArticleIndex: "sudbury_star_articles",
+		PageIndex:    "sudbury_star_pages",
+		GroupID:      "ontario-news",
+		Enabled:      true,
+		Selectors: apiclient.APISelectors{
+			Article: apiclient.APIArticleSelectors{Title: "h1.headline"},
+		},
+	}}
+	require.NoError(t, sources.Pull(dir, sources.PlanPull(dir, remote), false))
+
+	// The pulled file names the group as the API does and loads into the group
+	l, err := loader.NewLoader(filepath.Join(path, "sudbury-star.yaml"))
+	require.NoError(t, err)
+	configs, err := l.LoadSources()
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "ontario-news", configs[0].Group)
+}
@@ -4,10 +4,12 @@ package types
 import (
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/jonesrussell/gocrawl/internal/config/types"
+	"github.com/jonesrussell/gocrawl/internal/domain"
 )
 
 // Source defines the interface for data sources.
@@ -31,6 +33,7 @@ func NewSourcesMetrics() *SourcesMetrics {
 
 // SourceConfig represents a source configuration.
 type SourceConfig struct {
+	ID             string
 	Name           string
 	URL            string
 	AllowedDomains []string
@@ -43,6 +46,54 @@ type SourceConfig struct {
 	PageIndex      string
 	Selectors      SelectorConfig
 	Rules          types.Rules
+	Retention      time.Duration
+	MaxDocs        int
+	URLRewrites    types.URLRewrites
+	Extraction     string
+	StructuredData string
+	Locale         string
+	Timezone       string
+	CityName       string
+	Region         string
+	Latitude       *float64
+	Longitude      *float64
+	Group          string
+	Tags           []string
+	Enrichers      []string
+	Crawler        map[string]any
+	Enabled        bool
+	DisabledReason string
+	DisabledUntil  *time.Time
+}
+
+// IsEnabled reports whether the source may be crawled at the given time. A
+// disabled source is enabled again once its DisabledUntil time has passed.
+func (s *SourceConfig) IsEnabled(now time.Time) bool {
+	return s.Enabled || (s.DisabledUntil != nil && !now.Before(*s.DisabledUntil))
+}
+
+// Location returns the coordinates of the source's city, or nil when they are not configured.
+func (s *SourceConfig) Location() *domain.GeoPoint {
+	return domain.NewGeoPoint(s.Latitude, s.Longitude)
+}
+
+// Groups returns the groups the source belongs to: its group followed by its tags.
+func (s *SourceConfig) Groups() []string {
+	groups := make([]string, 0, len(s.Tags)+1)
+	if s.Group != "" {
+		groups = append(groups, s.Group)
+	}
+	for _, tag := range s.Tags {
+		if tag != "" && !slices.Contains(groups, tag) {
+			groups = append(groups, tag)
+		}
+	}
+	return groups
+}
+
+// InGroup reports whether the source belongs to a group.
+func (s *SourceConfig) InGroup(group string) bool {
+	return group != "" && slices.Contains(s.Groups(), group)
 }
 
 // SelectorConfig defines the CSS selectors used for content extraction.
@@ -175,7 +226,22 @@ func ConvertToConfigSource(source *SourceConfig) *types.Source {
 				Exclude:       source.Selectors.Page.Exclude,
 			},
 		},
-		Rules: source.Rules,
+		Rules:          source.Rules,
+		Retention:      types.FormatRetention(source.Retention),
+		MaxDocs:        source.MaxDocs,
+		URLRewrites:    source.URLRewrites,
+		Extraction:     source.Extraction,
+		StructuredData: source.StructuredData,
+		Locale:         source.Locale,
+		Timezone:       source.Timezone,
+		CityName:       source.CityName,
+		Region:         source.Region,
+		Latitude:       source.Latitude,
+		Longitude:      source.Longitude,
+		Group:          source.Group,
+		Tags:           source.Tags,
+		Enrichers:      source.Enrichers,
+		Crawler:        source.Crawler,
 	}
 }
 
@@ -39,3 +39,41 @@ func ExtractDomain(sourceURL string) (string, error) {
 
 	return parsedURL.Host, nil
 }
+
+// URLPrefixes returns the prefixes of the URLs of a source's documents: both
+// schemes of the host of its URL and of each of its allowed domains.
+func URLPrefixes(source *Config) []string {
+	hosts := make([]string, 0, 1+len(source.AllowedDomains))
+	if parsedURL, err := url.Parse(source.URL); err == nil && parsedURL.Host != "" {
+		hosts = append(hosts, parsedURL.Host)
+	}
+	hosts = append(hosts, source.AllowedDomains...)
+
+	seen := make(map[string]bool, len(hosts))
+	prefixes := make([]string, 0, 2*len(hosts))
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		prefixes = append(prefixes, "https://"+host+"/", "http://"+host+"/")
+	}
+	return prefixes
+}
+
+// URLFilter returns a query that matches the documents whose URL, stored in a
+// keyword field, starts with one of the prefixes, or nil without prefixes.
+func URLFilter(field string, prefixes []string) map[string]any {
+	if len(prefixes) == 0 {
+		return nil
+	}
+
+	should := make([]any, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		should = append(should, map[string]any{"prefix": map[string]any{field: prefix}})
+	}
+	return map[string]any{
+		"bool": map[string]any{"should": should, "minimum_should_match": 1},
+	}
+}
@@ -93,6 +93,26 @@ func (s *ElasticsearchStorage) GetDocument(ctx context.Context, index, id string
 	return nil
 }
 
+// UpdateDocument partially updates a document with an update request body
+func (s *ElasticsearchStorage) UpdateDocument(ctx context.Context, index, id string, update map[string]any) error {
+	res, err := s.client.Update(
+		index,
+		id,
+		bytes.NewReader(mustJSON(update)),
+		s.client.Update.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update document: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("error updating document: %s", res.String())
+	}
+
+	return nil
+}
+
 // DeleteDocument deletes a document
 func (s *ElasticsearchStorage) DeleteDocument(ctx context.Context, index, id string) error {
 	res, err := s.client.Delete(
@@ -112,6 +132,49 @@ func (s *ElasticsearchStorage) DeleteDocument(ctx context.Context, index, id str
 	return nil
 }
 
+// DeleteByQuery deletes all documents matching a query
+func (s *ElasticsearchStorage) DeleteByQuery(ctx context.Context, index string, query map[string]any) (int64, error) {
+	res, err := s.client.DeleteByQuery(
+		[]string{index},
+		bytes.NewReader(mustJSON(query)),
+		s.client.DeleteByQuery.WithContext(ctx),
+		s.client.DeleteByQuery.WithConflicts("proceed"),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete by query: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("error deleting by query: %s", res.String())
+	}
+
+	var deleteResult struct {
+		Deleted int64 `json:"deleted"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&deleteResult); decodeErr != nil {
+		return 0, fmt.Errorf("error decoding response: %w", decodeErr)
+	}
+
+	return deleteResult.Deleted, nil
+}
+
+// BulkIndex indexes multiple documents with a single bulk request
+func (s *ElasticsearchStorage) BulkIndex(ctx context.Context, index string, documents []types.BulkDocument) error {
+	return bulkIndex(ctx, s.client, index, documents)
+}
+
+// ScrollDocuments pages through all documents matching a query
+func (s *ElasticsearchStorage) ScrollDocuments(
+	ctx context.Context,
+	index string,
+	query map[string]any,
+	batchSize int,
+	handle types.ScrollHandler,
+) error {
+	return scrollDocuments(ctx, s.client, index, query, batchSize, handle)
+}
+
 // SearchDocuments performs a search query
 func (s *ElasticsearchStorage) SearchDocuments(
 	ctx context.Context,
@@ -506,3 +569,13 @@ func (s *ElasticsearchStorage) EnsurePageIndex(ctx context.Context, name string)
 	}
 	return s.CreateIndex(ctx, name, pageMapping)
 }
+
+// GetAliasIndices returns the indices an alias points to
+func (s *ElasticsearchStorage) GetAliasIndices(ctx context.Context, alias string) ([]string, error) {
+	return getAliasIndices(ctx, s.client, alias)
+}
+
+// SetWriteIndex points an alias at an index and marks it as the write index
+func (s *ElasticsearchStorage) SetWriteIndex(ctx context.Context, alias, index string) error {
+	return setWriteIndex(ctx, s.client, alias, index)
+}
@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/jonesrussell/gocrawl/internal/language"
 	"github.com/jonesrussell/gocrawl/internal/logger"
 	"github.com/jonesrussell/gocrawl/internal/storage/types"
 )
@@ -143,6 +145,90 @@ func (m *ElasticsearchIndexManager) GetMapping(ctx context.Context, name string)
 	return result, nil
 }
 
+// GetAliasIndices returns the indices an alias points to.
+func (m *ElasticsearchIndexManager) GetAliasIndices(ctx context.Context, alias string) ([]string, error) {
+	return getAliasIndices(ctx, m.client, alias)
+}
+
+// SetWriteIndex points an alias at an index and marks it as the alias's write index.
+// Any other indices behind the alias stay readable but stop receiving writes.
+func (m *ElasticsearchIndexManager) SetWriteIndex(ctx context.Context, alias, index string) error {
+	return setWriteIndex(ctx, m.client, alias, index)
+}
+
+// getAliasIndices resolves an alias to its backing indices. An unknown alias yields no indices.
+func getAliasIndices(ctx context.Context, client *elasticsearch.Client, alias string) ([]string, error) {
+	res, err := client.Indices.GetAlias(
+		client.Indices.GetAlias.WithName(alias),
+		client.Indices.GetAlias.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("error getting alias: %s", res.String())
+	}
+
+	var result map[string]any
+	if decodeErr := json.NewDecoder(res.Body).Decode(&result); decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	indices := make([]string, 0, len(result))
+	for index := range result {
+		indices = append(indices, index)
+	}
+	sort.Strings(indices)
+
+	return indices, nil
+}
+
+// setWriteIndex adds an index to an alias as its write index, demoting any previous write index.
+func setWriteIndex(ctx context.Context, client *elasticsearch.Client, alias, index string) error {
+	current, err := getAliasIndices(ctx, client, alias)
+	if err != nil {
+		return err
+	}
+
+	actions := make([]map[string]any, 0, len(current)+1)
+	for _, existing := range current {
+		if existing == index {
+			continue
+		}
+		actions = append(actions, map[string]any{
+			"add": map[string]any{"index": existing, "alias": alias, "is_write_index": false},
+		})
+	}
+	actions = append(actions, map[string]any{
+		"add": map[string]any{"index": index, "alias": alias, "is_write_index": true},
+	})
+
+	body, err := json.Marshal(map[string]any{"actions": actions})
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Indices.UpdateAliases(
+		strings.NewReader(string(body)),
+		client.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("error updating aliases: %s", res.String())
+	}
+
+	return nil
+}
+
 // getArticleMapping returns the Elasticsearch mapping configuration for articles.
 func getArticleMapping() map[string]any {
 	return map[string]any{
@@ -151,11 +237,10 @@ func getArticleMapping() map[string]any {
 				"id": map[string]any{
 					"type": "keyword",
 				},
-				"title": map[string]any{
-					"type": "text",
-				},
-				"body": map[string]any{
-					"type": "text",
+				"title": language.TextMapping(),
+				"body":  language.TextMapping(),
+				"language": map[string]any{
+					"type": "keyword",
 				},
 				"author": map[string]any{
 					"type": "keyword",
@@ -166,6 +251,12 @@ func getArticleMapping() map[string]any {
 				"published_date": map[string]any{
 					"type": "date",
 				},
+				"modified_date": map[string]any{
+					"type": "date",
+				},
+				"authors": map[string]any{
+					"type": "keyword",
+				},
 				"source": map[string]any{
 					"type": "keyword",
 				},
@@ -196,6 +287,42 @@ func getArticleMapping() map[string]any {
 				"canonical_url": map[string]any{
 					"type": "keyword",
 				},
+				"extraction_method": map[string]any{
+					"type": "keyword",
+				},
+				"images": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"src":     map[string]any{"type": "keyword"},
+						"alt":     map[string]any{"type": "text"},
+						"caption": map[string]any{"type": "text"},
+						"credit":  map[string]any{"type": "keyword"},
+						"width":   map[string]any{"type": "integer"},
+						"height":  map[string]any{"type": "integer"},
+					},
+				},
+				"media": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"type":     map[string]any{"type": "keyword"},
+						"provider": map[string]any{"type": "keyword"},
+						"src":      map[string]any{"type": "keyword"},
+						"video_id": map[string]any{"type": "keyword"},
+						"poster":   map[string]any{"type": "keyword"},
+						"title":    map[string]any{"type": "text"},
+						"width":    map[string]any{"type": "integer"},
+						"height":   map[string]any{"type": "integer"},
+					},
+				},
+				"publisher": map[string]any{
+					"type": "keyword",
+				},
+				"is_accessible_for_free": map[string]any{
+					"type": "boolean",
+				},
+				"structured_data": map[string]any{
+					"type": "keyword",
+				},
 				"word_count": map[string]any{
 					"type": "integer",
 				},
@@ -205,6 +332,45 @@ func getArticleMapping() map[string]any {
 				"section": map[string]any{
 					"type": "keyword",
 				},
+				"simhash": map[string]any{
+					"type": "keyword",
+				},
+				"simhash_bands": map[string]any{
+					"type": "keyword",
+				},
+				"duplicate_of": map[string]any{
+					"type": "keyword",
+				},
+				"alternate_sources": map[string]any{
+					"type": "keyword",
+				},
+				"keyphrases": map[string]any{
+					"type": "keyword",
+				},
+				"places": map[string]any{
+					"type": "keyword",
+				},
+				"organizations": map[string]any{
+					"type": "keyword",
+				},
+				"reading_time_minutes": map[string]any{
+					"type": "integer",
+				},
+				"groups": map[string]any{
+					"type": "keyword",
+				},
+				"city": map[string]any{
+					"type": "keyword",
+				},
+				"region": map[string]any{
+					"type": "keyword",
+				},
+				"location": map[string]any{
+					"type": "geo_point",
+				},
+				"place_locations": map[string]any{
+					"type": "geo_point",
+				},
 				"created_at": map[string]any{
 					"type": "date",
 				},
@@ -232,11 +398,22 @@ func (m *ElasticsearchIndexManager) EnsurePageIndex(ctx context.Context, name st
 				"url": map[string]any{
 					"type": "keyword",
 				},
-				"title": map[string]any{
-					"type": "text",
+				"title":   language.TextMapping(),
+				"content": language.TextMapping(),
+				"language": map[string]any{
+					"type": "keyword",
 				},
-				"content": map[string]any{
-					"type": "text",
+				"groups": map[string]any{
+					"type": "keyword",
+				},
+				"city": map[string]any{
+					"type": "keyword",
+				},
+				"region": map[string]any{
+					"type": "keyword",
+				},
+				"location": map[string]any{
+					"type": "geo_point",
 				},
 				"description": map[string]any{
 					"type": "text",
@@ -259,6 +436,9 @@ func (m *ElasticsearchIndexManager) EnsurePageIndex(ctx context.Context, name st
 				"canonical_url": map[string]any{
 					"type": "keyword",
 				},
+				"extraction_method": map[string]any{
+					"type": "keyword",
+				},
 				"created_at": map[string]any{
 					"type": "date",
 				},
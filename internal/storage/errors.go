@@ -11,6 +11,10 @@ var (
 	ErrMissingURL = errors.New("elasticsearch URL is required")
 	// ErrInvalidScrollID indicates an invalid or missing scroll ID in response
 	ErrInvalidScrollID = errors.New("invalid scroll ID")
+	// ErrBulkIndexFailed indicates that some documents of a bulk request were rejected
+	ErrBulkIndexFailed = errors.New("bulk index failed")
+	// ErrDocumentNotFound indicates the requested document does not exist
+	ErrDocumentNotFound = errors.New("document not found")
 	// ErrIndexNotFound indicates the requested index does not exist
 	ErrIndexNotFound = errors.New("index not found")
 	// ErrInvalidIndexHealth indicates the index health is invalid
@@ -24,6 +24,9 @@ const (
 	DefaultSearchTimeout         = 10 * time.Second
 )
 
+// updateRetryOnConflict is how often a partial update is retried when the document changed concurrently
+const updateRetryOnConflict = 3
+
 // StorageParams contains dependencies for creating a storage instance
 type StorageParams struct {
 	Config config.Interface
@@ -172,10 +175,71 @@ func (s *Storage) GetDocument(ctx context.Context, index, id string, document an
 		return fmt.Errorf("error getting document: %s", res.String())
 	}
 
-	if decodeErr := json.NewDecoder(res.Body).Decode(document); decodeErr != nil {
+	var doc struct {
+		Source json.RawMessage `json:"_source"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&doc); decodeErr != nil {
 		return fmt.Errorf("error decoding document: %w", decodeErr)
 	}
+	if len(doc.Source) == 0 {
+		return fmt.Errorf("%w: %s", ErrDocumentNotFound, id)
+	}
+
+	if unmarshalErr := json.Unmarshal(doc.Source, document); unmarshalErr != nil {
+		return fmt.Errorf("error decoding document source: %w", unmarshalErr)
+	}
+
+	return nil
+}
+
+// UpdateDocument partially updates a document in Elasticsearch. The update is an
+// update request body, e.g. a partial "doc" or a "script".
+func (s *Storage) UpdateDocument(ctx context.Context, index, id string, update map[string]any) error {
+	if s.client == nil {
+		return errors.New("elasticsearch client is not initialized")
+	}
+
+	ctx, cancel := s.createContextWithTimeout(ctx, DefaultIndexTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal document update: %w", err)
+	}
+
+	res, err := s.client.Update(
+		index,
+		id,
+		bytes.NewReader(body),
+		s.client.Update.WithContext(ctx),
+		s.client.Update.WithRefresh("true"),
+		s.client.Update.WithRetryOnConflict(updateRetryOnConflict),
+	)
+	if err != nil {
+		s.logger.Error("Failed to update document",
+			"error", err,
+			"index", index,
+			"docID", id)
+		return fmt.Errorf("failed to update document: %w", err)
+	}
+	defer func() {
+		if closeErr := res.Body.Close(); closeErr != nil {
+			s.logger.Error("Failed to close response body",
+				"error", closeErr,
+				"index", index,
+				"docID", id)
+		}
+	}()
+
+	if res.IsError() {
+		s.logger.Error("Elasticsearch returned error response",
+			"error", res.String(),
+			"index", index,
+			"docID", id)
+		return fmt.Errorf("elasticsearch error: %s", res.String())
+	}
 
+	s.logger.Debug("Document updated", "index", index, "docID", id)
 	return nil
 }
 
@@ -208,6 +272,78 @@ func (s *Storage) DeleteDocument(ctx context.Context, index, docID string) error
 	return nil
 }
 
+// DeleteByQuery deletes all documents matching the query and returns how many were removed
+func (s *Storage) DeleteByQuery(ctx context.Context, index string, query map[string]any) (int64, error) {
+	if s.client == nil {
+		return 0, errors.New("elasticsearch client is not initialized")
+	}
+
+	ctx, cancel := s.createContextWithTimeout(ctx, DefaultBulkIndexTimeout)
+	defer cancel()
+
+	body, err := marshalJSON(query)
+	if err != nil {
+		return 0, fmt.Errorf("error marshaling delete query: %w", err)
+	}
+
+	res, err := s.client.DeleteByQuery(
+		[]string{index},
+		bytes.NewReader(body),
+		s.client.DeleteByQuery.WithContext(ctx),
+		s.client.DeleteByQuery.WithConflicts("proceed"),
+		s.client.DeleteByQuery.WithRefresh(true),
+	)
+	if err != nil {
+		s.logger.Error("Failed to delete by query", "error", err, "index", index)
+		return 0, fmt.Errorf("error deleting by query: %w", err)
+	}
+	defer func() {
+		if closeErr := res.Body.Close(); closeErr != nil {
+			s.logger.Error("Error closing response body", "error", closeErr)
+		}
+	}()
+
+	if res.IsError() {
+		s.logger.Error("Failed to delete by query", "error", res.String(), "index", index)
+		return 0, fmt.Errorf("error deleting by query: %s", res.String())
+	}
+
+	var result struct {
+		Deleted int64 `json:"deleted"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&result); decodeErr != nil {
+		return 0, fmt.Errorf("error decoding delete by query response: %w", decodeErr)
+	}
+
+	s.logger.Info("Deleted documents by query", "index", index, "deleted", result.Deleted)
+	return result.Deleted, nil
+}
+
+// BulkIndex indexes multiple documents with a single bulk request
+func (s *Storage) BulkIndex(ctx context.Context, index string, documents []types.BulkDocument) error {
+	ctx, cancel := s.createContextWithTimeout(ctx, DefaultBulkIndexTimeout)
+	defer cancel()
+
+	if err := bulkIndex(ctx, s.client, index, documents); err != nil {
+		s.logger.Error("Failed to bulk index documents", "error", err, "index", index)
+		return err
+	}
+
+	s.logger.Debug("Bulk indexed documents", "index", index, "count", len(documents))
+	return nil
+}
+
+// ScrollDocuments pages through all documents matching the query, passing each page of hits to handle
+func (s *Storage) ScrollDocuments(
+	ctx context.Context,
+	index string,
+	query map[string]any,
+	batchSize int,
+	handle types.ScrollHandler,
+) error {
+	return scrollDocuments(ctx, s.client, index, query, batchSize, handle)
+}
+
 // SearchDocuments performs a search query and decodes the result into the provided value
 func (s *Storage) SearchDocuments(ctx context.Context, index string, query map[string]any, result any) error {
 	if s.client == nil {
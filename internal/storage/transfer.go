@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	es "github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+)
+
+const (
+	// DefaultScrollKeepAlive is how long Elasticsearch keeps a scroll context open between pages.
+	DefaultScrollKeepAlive = 5 * time.Minute
+	// DefaultScrollBatchSize is the page size used when no batch size is given.
+	DefaultScrollBatchSize = 500
+)
+
+// bulkIndex indexes documents with a single bulk request and reports item failures.
+func bulkIndex(ctx context.Context, client *es.Client, index string, documents []types.BulkDocument) error {
+	if client == nil {
+		return errors.New("elasticsearch client is not initialized")
+	}
+	if len(documents) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, doc := range documents {
+		action := map[string]any{"_index": index}
+		if doc.ID != "" {
+			action["_id"] = doc.ID
+		}
+		if err := encoder.Encode(map[string]any{"index": action}); err != nil {
+			return fmt.Errorf("failed to encode bulk action: %w", err)
+		}
+		if err := encoder.Encode(doc.Document); err != nil {
+			return fmt.Errorf("failed to encode bulk document: %w", err)
+		}
+	}
+
+	res, err := client.Bulk(
+		bytes.NewReader(body.Bytes()),
+		client.Bulk.WithContext(ctx),
+		client.Bulk.WithIndex(index),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to execute bulk request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("error executing bulk request: %s", res.String())
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID    string `json:"_id"`
+			Error *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&result); decodeErr != nil {
+		return fmt.Errorf("error decoding bulk response: %w", decodeErr)
+	}
+	if !result.Errors {
+		return nil
+	}
+
+	failed := 0
+	var firstReason string
+	for _, item := range result.Items {
+		for _, status := range item {
+			if status.Error == nil {
+				continue
+			}
+			if failed == 0 {
+				firstReason = fmt.Sprintf("%s: %s (%s)", status.ID, status.Error.Reason, status.Error.Type)
+			}
+			failed++
+		}
+	}
+
+	return fmt.Errorf("%w: %d of %d documents failed, first error: %s",
+		ErrBulkIndexFailed, failed, len(documents), firstReason)
+}
+
+// scrollDocuments pages through all documents matching a query using the scroll API.
+func scrollDocuments(
+	ctx context.Context,
+	client *es.Client,
+	index string,
+	query map[string]any,
+	batchSize int,
+	handle types.ScrollHandler,
+) error {
+	if client == nil {
+		return errors.New("elasticsearch client is not initialized")
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultScrollBatchSize
+	}
+
+	body, err := marshalJSON(query)
+	if err != nil {
+		return fmt.Errorf("error marshaling scroll query: %w", err)
+	}
+
+	res, err := client.Search(
+		client.Search.WithContext(ctx),
+		client.Search.WithIndex(index),
+		client.Search.WithBody(bytes.NewReader(body)),
+		client.Search.WithSize(batchSize),
+		client.Search.WithScroll(DefaultScrollKeepAlive),
+		client.Search.WithSort("_doc"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start scroll: %w", err)
+	}
+
+	scrollID, hits, err := decodeScrollPage(res)
+	if err != nil {
+		return err
+	}
+	if scrollID == "" && len(hits) > 0 {
+		return ErrInvalidScrollID
+	}
+	// Elasticsearch may hand out a new scroll ID with any page; clear the latest one
+	defer func() { clearScroll(client, scrollID) }()
+
+	for len(hits) > 0 {
+		if handleErr := handle(hits); handleErr != nil {
+			return handleErr
+		}
+
+		res, err = client.Scroll(
+			client.Scroll.WithContext(ctx),
+			client.Scroll.WithScrollID(scrollID),
+			client.Scroll.WithScroll(DefaultScrollKeepAlive),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to continue scroll: %w", err)
+		}
+
+		var nextID string
+		nextID, hits, err = decodeScrollPage(res)
+		if err != nil {
+			return err
+		}
+		if nextID != "" {
+			scrollID = nextID
+		}
+	}
+
+	return nil
+}
+
+// decodeScrollPage decodes the scroll ID and hits of a scroll response and closes its body.
+func decodeScrollPage(res *esapi.Response) (string, []any, error) {
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", nil, fmt.Errorf("error scrolling documents: %s", res.String())
+	}
+
+	var page struct {
+		ScrollID string `json:"_scroll_id"`
+		Hits     struct {
+			Hits []any `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		return "", nil, fmt.Errorf("error decoding scroll response: %w", err)
+	}
+
+	return page.ScrollID, page.Hits.Hits, nil
+}
+
+// clearScroll releases a scroll context. Failures are ignored because the context expires on its own.
+func clearScroll(client *es.Client, scrollID string) {
+	if scrollID == "" {
+		return
+	}
+	res, err := client.ClearScroll(client.ClearScroll.WithScrollID(scrollID))
+	if err == nil {
+		res.Body.Close()
+	}
+}
@@ -19,4 +19,8 @@ type IndexManager interface {
 	EnsureArticleIndex(ctx context.Context, name string) error
 	// EnsurePageIndex ensures that a page index exists with the appropriate mapping.
 	EnsurePageIndex(ctx context.Context, name string) error
+	// GetAliasIndices returns the indices an alias points to.
+	GetAliasIndices(ctx context.Context, alias string) ([]string, error)
+	// SetWriteIndex points an alias at an index and marks it as the alias's write index.
+	SetWriteIndex(ctx context.Context, alias string, index string) error
 }
@@ -5,6 +5,18 @@ import (
 	"context"
 )
 
+// BulkDocument is a single document in a bulk index request.
+type BulkDocument struct {
+	// ID is the document ID; an empty ID lets Elasticsearch generate one
+	ID string
+	// Document is the document source
+	Document any
+}
+
+// ScrollHandler receives each page of raw search hits while scrolling through an index.
+// Returning an error stops the scroll.
+type ScrollHandler func(hits []any) error
+
 // Interface defines the interface for storage operations.
 // Note: The implementation is in internal/storage/storage.go (Storage type).
 type Interface interface {
@@ -14,8 +26,12 @@ type Interface interface {
 	// Document operations
 	IndexDocument(ctx context.Context, index string, id string, document any) error
 	GetDocument(ctx context.Context, index string, id string, document any) error
+	UpdateDocument(ctx context.Context, index string, id string, update map[string]any) error
 	DeleteDocument(ctx context.Context, index string, id string) error
+	DeleteByQuery(ctx context.Context, index string, query map[string]any) (int64, error)
 	SearchDocuments(ctx context.Context, index string, query map[string]any, result any) error
+	BulkIndex(ctx context.Context, index string, documents []BulkDocument) error
+	ScrollDocuments(ctx context.Context, index string, query map[string]any, batchSize int, handle ScrollHandler) error
 
 	// Search operations
 	Search(ctx context.Context, index string, query any) ([]any, error)
@@ -0,0 +1,82 @@
+// Package testutils provides shared testing utilities across the application.
+package testutils
+
+import (
+	"context"
+
+	"github.com/jonesrussell/gocrawl/internal/storage/types"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockIndexManager is a mock implementation of the index manager interface.
+type MockIndexManager struct {
+	mock.Mock
+}
+
+// EnsureIndex ensures that an index exists with the specified mapping.
+func (m *MockIndexManager) EnsureIndex(ctx context.Context, name string, mapping any) error {
+	args := m.Called(ctx, name, mapping)
+	return args.Error(0)
+}
+
+// DeleteIndex deletes an index.
+func (m *MockIndexManager) DeleteIndex(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+// IndexExists checks if an index exists.
+func (m *MockIndexManager) IndexExists(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+// GetMapping gets the mapping for an index.
+func (m *MockIndexManager) GetMapping(ctx context.Context, name string) (map[string]any, error) {
+	args := m.Called(ctx, name)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+	val, ok := args.Get(0).(map[string]any)
+	if !ok {
+		return nil, ErrInvalidMappingType
+	}
+	return val, nil
+}
+
+// UpdateMapping updates the mapping for an index.
+func (m *MockIndexManager) UpdateMapping(ctx context.Context, name string, mapping map[string]any) error {
+	args := m.Called(ctx, name, mapping)
+	return args.Error(0)
+}
+
+// EnsureArticleIndex ensures that an article index exists.
+func (m *MockIndexManager) EnsureArticleIndex(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+// EnsurePageIndex ensures that a page index exists.
+func (m *MockIndexManager) EnsurePageIndex(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+// GetAliasIndices returns the indices an alias points to.
+func (m *MockIndexManager) GetAliasIndices(ctx context.Context, alias string) ([]string, error) {
+	args := m.Called(ctx, alias)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+	val, _ := args.Get(0).([]string)
+	return val, nil
+}
+
+// SetWriteIndex points an alias at an index and marks it as the alias's write index.
+func (m *MockIndexManager) SetWriteIndex(ctx context.Context, alias string, index string) error {
+	args := m.Called(ctx, alias, index)
+	return args.Error(0)
+}
+
+// Ensure MockIndexManager implements types.IndexManager
+var _ types.IndexManager = (*MockIndexManager)(nil)
@@ -23,6 +23,12 @@ func (m *MockSecurityMiddleware) WaitCleanup() {
 	m.Called()
 }
 
+// WatchKeys mocks the watch keys method
+func (m *MockSecurityMiddleware) WatchKeys(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 // Middleware mocks the middleware method
 func (m *MockSecurityMiddleware) Middleware() gin.HandlerFunc {
 	args := m.Called()
@@ -40,18 +40,49 @@ func (m *MockStorage) GetDocument(ctx context.Context, index, id string, documen
 	return args.Error(0)
 }
 
+// UpdateDocument partially updates a document in Elasticsearch.
+func (m *MockStorage) UpdateDocument(ctx context.Context, index, id string, update map[string]any) error {
+	args := m.Called(ctx, index, id, update)
+	return args.Error(0)
+}
+
 // DeleteDocument deletes a document from Elasticsearch.
 func (m *MockStorage) DeleteDocument(ctx context.Context, index, id string) error {
 	args := m.Called(ctx, index, id)
 	return args.Error(0)
 }
 
+// DeleteByQuery deletes documents matching a query.
+func (m *MockStorage) DeleteByQuery(ctx context.Context, index string, query map[string]any) (int64, error) {
+	args := m.Called(ctx, index, query)
+	if err := args.Error(1); err != nil {
+		return 0, err
+	}
+	val, ok := args.Get(0).(int64)
+	if !ok {
+		return 0, nil
+	}
+	return val, nil
+}
+
 // BulkIndex performs bulk indexing operations.
-func (m *MockStorage) BulkIndex(ctx context.Context, index string, documents []any) error {
+func (m *MockStorage) BulkIndex(ctx context.Context, index string, documents []types.BulkDocument) error {
 	args := m.Called(ctx, index, documents)
 	return args.Error(0)
 }
 
+// ScrollDocuments pages through documents matching a query.
+func (m *MockStorage) ScrollDocuments(
+	ctx context.Context,
+	index string,
+	query map[string]any,
+	batchSize int,
+	handle types.ScrollHandler,
+) error {
+	args := m.Called(ctx, index, query, batchSize, handle)
+	return args.Error(0)
+}
+
 // BulkDelete performs bulk delete operations.
 func (m *MockStorage) BulkDelete(ctx context.Context, index string, ids []string) error {
 	args := m.Called(ctx, index, ids)